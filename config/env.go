@@ -1,26 +1,131 @@
 package config
 
 import (
+	"encoding/base64"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 
 	"BACKEND-UAS/database"
+	"BACKEND-UAS/pgmongo/extract"
+	"BACKEND-UAS/pgmongo/preview"
+	"BACKEND-UAS/pgmongo/scanner"
+	"BACKEND-UAS/pgmongo/storage"
 )
 
 type Config struct {
 	Connection *database.Connection
 	Port       string
 	JWTSecret  string
+
+	// Verification queue
+	ReviewSLA      time.Duration // batas waktu verifikasi sejak submit
+	ReviewClaimTTL time.Duration // claim reviewer dianggap basi setelah durasi ini
+
+	// Team achievements
+	TeamPointsPolicy string // full | split | leader_weighted
+
+	// Draft cleanup
+	DraftReminderAfter   time.Duration // pengingat untuk draft yang tidak diubah selama durasi ini
+	DraftAbandonAfter    time.Duration // draft dihapus (soft delete) setelah durasi ini
+	DraftCleanupInterval time.Duration // jeda antar job cleanup
+
+	// Attachment storage
+	Storage           storage.Config
+	AttachmentLinkTTL time.Duration // masa berlaku signed link attachment
+
+	// Validasi attachment
+	AttachmentMaxPDF   int64 // byte
+	AttachmentMaxImage int64 // byte, JPEG & PNG
+	AttachmentMaxVideo int64 // byte, MP4 (lewat resumable upload)
+	MaxAttachments     int   // per prestasi
+
+	// Resumable upload
+	UploadChunkMax int64         // byte per PATCH
+	UploadExpiry   time.Duration // sesi dibuang jika tidak ada chunk baru selama durasi ini
+
+	// Antivirus
+	Scanner      scanner.Config
+	ScanInterval time.Duration // jeda antar putaran scan (upload baru langsung membangunkan worker)
+
+	// Garbage collection file attachment
+	AttachmentGCInterval time.Duration // jeda antar putaran GC
+	AttachmentGCGrace    time.Duration // file/blob yang lebih muda dari ini tidak pernah dihapus
+	AttachmentGCRepair   bool          // false: hanya laporan di log; true: hapus file yatim & perbaiki referensi
+
+	// Thumbnail & preview attachment
+	Preview preview.Config
+
+	// Ekstraksi teks & QR attachment (petunjuk verifikasi sertifikat)
+	Extract extract.Config
+
+	// Receipt verifikasi: seed Ed25519 32 byte (base64 di env); kosong berarti dibaca dari (atau dibuat di)
+	// ReceiptSigningKeyFile. ReceiptRetiredKeys adalah kunci publik dari kunci yang sudah dirotasi.
+	ReceiptSigningKey     []byte
+	ReceiptSigningKeyFile string
+	ReceiptRetiredKeys    [][]byte
 }
 
 func NewConfig() *Config {
 	godotenv.Load() // Load .env
 
 	cfg := &Config{
-		Connection: database.NewConnection(), // koneksi Postgres + Mongo
-		Port:       os.Getenv("APP_PORT"),
-		JWTSecret:  os.Getenv("JWT_SECRET"),
+		Connection:       database.NewConnection(), // koneksi Postgres + Mongo
+		Port:             os.Getenv("APP_PORT"),
+		JWTSecret:        os.Getenv("JWT_SECRET"),
+		ReviewSLA:        time.Duration(getEnvInt("REVIEW_SLA_DAYS", 7)) * 24 * time.Hour,
+		ReviewClaimTTL:   time.Duration(getEnvInt("REVIEW_CLAIM_TTL_MINUTES", 120)) * time.Minute,
+		TeamPointsPolicy: os.Getenv("TEAM_POINTS_POLICY"),
+
+		DraftReminderAfter:   time.Duration(getEnvInt("DRAFT_REMINDER_DAYS", 14)) * 24 * time.Hour,
+		DraftAbandonAfter:    time.Duration(getEnvInt("DRAFT_ABANDON_DAYS", 60)) * 24 * time.Hour,
+		DraftCleanupInterval: time.Duration(getEnvInt("DRAFT_CLEANUP_INTERVAL_HOURS", 24)) * time.Hour,
+
+		AttachmentLinkTTL:  time.Duration(getEnvInt("ATTACHMENT_LINK_TTL_MINUTES", 5)) * time.Minute,
+		AttachmentMaxPDF:   int64(getEnvInt("ATTACHMENT_MAX_PDF_MB", 10)) << 20,
+		AttachmentMaxImage: int64(getEnvInt("ATTACHMENT_MAX_IMAGE_MB", 5)) << 20,
+		AttachmentMaxVideo: int64(getEnvInt("ATTACHMENT_MAX_VIDEO_MB", 500)) << 20,
+		MaxAttachments:     getEnvInt("ATTACHMENT_MAX_PER_ACHIEVEMENT", 10),
+
+		UploadChunkMax: int64(getEnvInt("UPLOAD_CHUNK_MAX_MB", 5)) << 20,
+		UploadExpiry:   time.Duration(getEnvInt("UPLOAD_EXPIRY_HOURS", 24)) * time.Hour,
+
+		Scanner: scanner.Config{
+			Driver:  os.Getenv("AV_SCANNER"),
+			Network: os.Getenv("CLAMAV_NETWORK"),
+			Address: os.Getenv("CLAMAV_ADDRESS"),
+		},
+		ScanInterval: time.Duration(getEnvInt("AV_SCAN_INTERVAL_SECONDS", 60)) * time.Second,
+
+		AttachmentGCInterval: time.Duration(getEnvInt("ATTACHMENT_GC_INTERVAL_HOURS", 24)) * time.Hour,
+		AttachmentGCGrace:    time.Duration(getEnvInt("ATTACHMENT_GC_GRACE_HOURS", 24)) * time.Hour,
+		AttachmentGCRepair:   os.Getenv("ATTACHMENT_GC_REPAIR") == "true",
+
+		Preview: preview.Config{
+			Driver:       os.Getenv("PREVIEW_RENDERER"),
+			PdftoppmPath: os.Getenv("PDFTOPPM_PATH"),
+		},
+		Extract: extract.Config{
+			Driver:      os.Getenv("METADATA_EXTRACTOR"),
+			ZbarimgPath: os.Getenv("ZBARIMG_PATH"),
+		},
+		Storage: storage.Config{
+			Driver:     os.Getenv("STORAGE_DRIVER"),
+			LocalRoot:  os.Getenv("STORAGE_LOCAL_ROOT"),
+			SignedBase: os.Getenv("STORAGE_SIGNED_BASE"),
+			SigningKey: []byte(os.Getenv("STORAGE_SIGNING_KEY")),
+			S3: storage.S3Config{
+				Endpoint:  os.Getenv("S3_ENDPOINT"),
+				Region:    os.Getenv("S3_REGION"),
+				Bucket:    os.Getenv("S3_BUCKET"),
+				AccessKey: os.Getenv("S3_ACCESS_KEY"),
+				SecretKey: os.Getenv("S3_SECRET_KEY"),
+				PathStyle: os.Getenv("S3_PATH_STYLE") == "true",
+			},
+		},
 	}
 
 	if cfg.Port == "" {
@@ -31,5 +136,45 @@ func NewConfig() *Config {
 		cfg.JWTSecret = "default-secret-ubah-sekarang"
 	}
 
+	if cfg.Storage.SignedBase == "" {
+		cfg.Storage.SignedBase = "/api/v1/files"
+	}
+	if len(cfg.Storage.SigningKey) == 0 {
+		cfg.Storage.SigningKey = storage.DeriveSigningKey(cfg.JWTSecret)
+	}
+
+	if v := os.Getenv("RECEIPT_SIGNING_KEY"); v != "" {
+		cfg.ReceiptSigningKey = decodeKey(v)
+	}
+	cfg.ReceiptSigningKeyFile = os.Getenv("RECEIPT_SIGNING_KEY_FILE")
+	if cfg.ReceiptSigningKeyFile == "" {
+		cfg.ReceiptSigningKeyFile = "keys/receipt_signing.key"
+	}
+	for _, v := range strings.Split(os.Getenv("RECEIPT_RETIRED_KEYS"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			cfg.ReceiptRetiredKeys = append(cfg.ReceiptRetiredKeys, decodeKey(v))
+		}
+	}
+
 	return cfg
 }
+
+// decodeKey membaca kunci base64 (standar, atau base64url seperti nilai x di JWK). Nilai yang bukan base64
+// diteruskan apa adanya agar ditolak receipt.NewSigner saat startup.
+func decodeKey(v string) []byte {
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.RawURLEncoding, base64.URLEncoding} {
+		if key, err := enc.DecodeString(v); err == nil {
+			return key
+		}
+	}
+	return []byte(v)
+}
+
+// getEnvInt membaca env integer, fallback ke def jika kosong/tidak valid
+func getEnvInt(key string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || v <= 0 {
+		return def
+	}
+	return v
+}
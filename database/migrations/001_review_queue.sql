@@ -0,0 +1,8 @@
+-- Review queue: claim/assign reviewer pada achievement_references
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS claimed_by UUID REFERENCES users(id),
+    ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_submitted
+    ON achievement_references (submitted_at)
+    WHERE status = 'submitted';
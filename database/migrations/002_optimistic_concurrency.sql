@@ -0,0 +1,3 @@
+-- Optimistic concurrency: setiap perubahan achievement_references menaikkan version (dipakai sebagai ETag)
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
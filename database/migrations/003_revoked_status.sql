@@ -0,0 +1,8 @@
+-- Status baru 'revoked' (verifikasi dicabut admin). Jika status memakai enum, tambahkan nilainya.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_type WHERE typname = 'achievement_status') THEN
+        ALTER TYPE achievement_status ADD VALUE IF NOT EXISTS 'revoked';
+    END IF;
+END
+$$;
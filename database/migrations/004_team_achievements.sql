@@ -0,0 +1,7 @@
+-- Prestasi tim: setiap anggota yang sudah konfirmasi punya reference sendiri yang menunjuk ke reference ketua
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS primary_reference_id UUID REFERENCES achievement_references(id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_achievement_references_team_member
+    ON achievement_references (primary_reference_id, student_id)
+    WHERE primary_reference_id IS NOT NULL;
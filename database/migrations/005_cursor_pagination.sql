@@ -0,0 +1,6 @@
+-- Keyset pagination: list diurutkan (created_at DESC, id DESC) dan dilanjutkan dari cursor baris terakhir
+CREATE INDEX IF NOT EXISTS idx_achievement_references_created_id
+    ON achievement_references (created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_students_created_id
+    ON students (created_at DESC, id DESC);
@@ -0,0 +1,12 @@
+-- Vocabulary tag prestasi: slug kanonik disimpan di achievements.tags (Mongo), sinonim dipetakan ke slug tersebut
+CREATE TABLE IF NOT EXISTS tags (
+    id UUID PRIMARY KEY,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    parent_id UUID REFERENCES tags(id) ON DELETE RESTRICT,
+    synonyms TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tags_parent ON tags (parent_id);
@@ -0,0 +1,12 @@
+-- Kalender akademik: statistik dikelompokkan per semester berdasarkan tanggal kejadian prestasi
+CREATE TABLE IF NOT EXISTS academic_periods (
+    id UUID PRIMARY KEY,
+    code VARCHAR(30) NOT NULL UNIQUE,
+    academic_year VARCHAR(9) NOT NULL,
+    semester VARCHAR(10) NOT NULL CHECK (semester IN ('ganjil', 'genap', 'pendek')),
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL CHECK (end_date >= start_date),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_academic_periods_start ON academic_periods (start_date);
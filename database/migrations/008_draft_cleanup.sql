@@ -0,0 +1,7 @@
+-- Draft cleanup: pengingat draft yang lama tidak disentuh dicatat agar tidak dikirim berulang
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS draft_reminded_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_stale_drafts
+    ON achievement_references (updated_at)
+    WHERE status = 'draft' AND primary_reference_id IS NULL;
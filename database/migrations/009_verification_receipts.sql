@@ -0,0 +1,3 @@
+-- Receipt verifikasi: JWS (Ed25519) yang ditandatangani saat prestasi diverifikasi, untuk dicek pihak luar
+ALTER TABLE achievement_references
+    ADD COLUMN IF NOT EXISTS verification_receipt TEXT;
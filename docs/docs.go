@@ -15,9 +15,40 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
+        "/academic-periods": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Kalender akademik (semester beserta rentang tanggal), urut tanggal mulai",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Academic Periods"
+                ],
+                "summary": "List academic periods",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AcademicPeriod"
+                            }
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Melakukan login user/admin dan menghasilkan JWT token",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Menambah semester ke kalender akademik (admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -25,45 +56,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Academic Periods"
                 ],
-                "summary": "Login user atau admin",
+                "summary": "Create academic period",
                 "parameters": [
                     {
-                        "description": "Username dan Password",
-                        "name": "request",
+                        "description": "Period",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/model.AcademicPeriodRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AcademicPeriod"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid period",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Not an admin",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Period exists or overlaps",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -71,9 +98,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "description": "Memperbarui access dan refresh token menggunakan refresh token yang valid",
+        "/academic-periods/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Menghapus semester dari kalender akademik (admin)",
+                "tags": [
+                    "Academic Periods"
+                ],
+                "summary": "Delete academic period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Period ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar prestasi berdasarkan role user (mahasiswa: own, dosen: advisees, admin: all), dengan filter, sorting dan pagination. Filter konten (type, level, tags, points, tanggal kejadian) dibatasi 2000 prestasi yang cocok",
                 "consumes": [
                     "application/json"
                 ],
@@ -81,57 +147,183 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Refresh token",
+                "summary": "List achievements (filtered by role)",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "refresh_token": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status (draft, submitted, verified, rejected, deleted)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter achievement type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter level",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter tags, dipisah koma (semua harus ada)",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum points",
+                        "name": "min_points",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum points",
+                        "name": "max_points",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter program studi",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter angkatan",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter student ID (UUID)",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created from (YYYY-MM-DD atau RFC3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created to, inklusif",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Submitted from",
+                        "name": "submitted_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Submitted to, inklusif",
+                        "name": "submitted_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Verified from",
+                        "name": "verified_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Verified to, inklusif",
+                        "name": "verified_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal kejadian dari (eventDate, atau tanggal dibuat jika kosong)",
+                        "name": "event_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal kejadian sampai, inklusif",
+                        "name": "event_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Isi tambahan per item: achievement (konten Mongo dimuat batch per halaman)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor untuk keyset pagination (halaman pertama); respons berbentuk model.CursorResponse",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari halaman sebelumnya (mengaktifkan keyset pagination)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan total pada keyset pagination (menjalankan COUNT)",
+                        "name": "with_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields dipisah koma, awalan - untuk descending (created_at, submitted_at, verified_at, status, points, title, type, level, event_date), mis. -points,submitted_at. Sort field konten (points, title, type, level, event_date) dibatasi 2000 hasil",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.PaginatedResponse-model_AchievementReference"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid role or filter, or content filter/sort over too many results",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/auth/logout": {
+            },
             "post": {
-                "description": "Melakukan logout dengan invalidasi refresh token (client-side untuk demo)",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Membuat prestasi baru untuk mahasiswa (draft status)",
                 "consumes": [
                     "application/json"
                 ],
@@ -139,41 +331,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Logout user",
+                "summary": "Create achievement",
                 "parameters": [
                     {
-                        "description": "Refresh token to invalidate",
-                        "name": "body",
+                        "description": "Achievement data",
+                        "name": "achievement",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "refresh_token": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/model.Achievement"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.AchievementReference"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Student not found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to create",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -181,104 +367,74 @@ const docTemplate = `{
                 }
             }
         },
-        "auth/profile": {
+        "/achievements/attachments/gc": {
             "get": {
-                "description": "Mengambil data user berdasarkan JWT token",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Admin: membandingkan storage dengan attachment di Mongo. GET hanya melaporkan file yatim, attachment yang file-nya hilang dan refCount blob yang melenceng; POST juga memperbaikinya (file yatim yang lebih tua dari grace period dihapus, attachment yang file-nya hilang diarahkan ke blob dengan hash sama atau ditandai scan_failed, refCount dikoreksi).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Mendapatkan profil user dari token",
+                "summary": "Attachment storage garbage collection",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.AttachmentGCReport"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Admin only",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-
-
-        "/users": {
-            "get": {
-                "description": "Mengambil daftar semua user dari database (dengan pagination, search, sorting)",
-                "consumes": [
-                    "application/json"
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Admin: membandingkan storage dengan attachment di Mongo. GET hanya melaporkan file yatim, attachment yang file-nya hilang dan refCount blob yang melenceng; POST juga memperbaikinya (file yatim yang lebih tua dari grace period dihapus, attachment yang file-nya hilang diarahkan ke blob dengan hash sama atau ditandai scan_failed, refCount dikoreksi).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
-                ],
-                "summary": "Dapatkan semua user",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Nomor halaman (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah item per halaman (default 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kolom untuk sorting (id, username, email, role, created_at)",
-                        "name": "sortBy",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan sorting (asc/desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Pencarian user",
-                        "name": "search",
-                        "in": "query"
-                    }
+                    "Achievements"
                 ],
+                "summary": "Attachment storage garbage collection",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.AttachmentGCReport"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Admin only",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/batch/reject": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            },
-            "post": {
-                "description": "Membuat user baru dengan data yang diberikan",
+                ],
+                "description": "Tolak beberapa prestasi sekaligus dengan satu rejection note, hasil per item. Setiap item membawa version dari queue; item yang berubah sejak itu gagal dengan code 412.",
                 "consumes": [
                     "application/json"
                 ],
@@ -286,97 +442,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Buat user baru",
+                "summary": "Batch reject achievements",
                 "parameters": [
                     {
-                        "description": "Data user baru",
+                        "description": "Items (id + version) and note",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/service.CreateUserReq"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "$ref": "#/definitions/model.BatchReviewResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Invalid request or note missing",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
-                "security": [
-                    {
-                        "ApiKeyAuth": []
-                    }
-                ]
+                }
             }
         },
-        "/users/{id}": {
-            "get": {
-                "description": "Mengambil detail user dengan ID tertentu",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Dapatkan user berdasarkan ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    }
-                },
+        "/achievements/batch/verify": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            },
-            "put": {
-                "description": "Memperbarui data user dengan ID tertentu",
+                ],
+                "description": "Verifikasi beberapa prestasi sekaligus, hasil per item. Setiap item membawa version dari queue; item yang berubah sejak itu gagal dengan code 412.",
                 "consumes": [
                     "application/json"
                 ],
@@ -384,24 +487,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Update user",
+                "summary": "Batch verify achievements",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Data user yang diupdate",
+                        "description": "Items (id + version)",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "object"
                         }
                     }
                 ],
@@ -409,136 +505,101 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "$ref": "#/definitions/model.BatchReviewResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/export": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            },
-            "delete": {
-                "description": "Menghapus user dengan ID tertentu",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Export daftar prestasi (CSV) dengan filter dan sort yang sama seperti list, mengikuti visibilitas role. Termasuk tanggal kejadian, penyelenggara, lokasi, nomor sertifikat dan URL verifikasi.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Hapus user",
+                "summary": "Export achievements",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
+                        "description": "Format export (saat ini hanya csv)",
+                        "name": "format",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
+                    {
+                        "type": "string",
+                        "description": "Filter status",
+                        "name": "status",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    }
-                },
-                "security": [
                     {
-                        "ApiKeyAuth": []
-                    }
-                ]
-            }
-        },
-        "/users/{id}/role": {
-            "put": {
-                "description": "Memperbarui role user dengan ID tertentu",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Update role user",
-                "parameters": [
+                        "type": "string",
+                        "description": "Filter achievement type",
+                        "name": "type",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Tanggal kejadian dari",
+                        "name": "event_from",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Role ID baru",
-                        "name": "role_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Tanggal kejadian sampai, inklusif",
+                        "name": "event_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort fields, sama seperti list",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "file"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Invalid filter, unsupported format or too many rows",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/queue": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-
-
-
-
-                "/achievements": {
-            "get": {
-                "description": "Mengambil daftar prestasi berdasarkan role user (mahasiswa: own, dosen wali: advisees, admin: all), dengan filter status dan pagination",
+                ],
+                "description": "Daftar prestasi submitted milik mahasiswa bimbingan (admin: semua) plus item yang sedang di-claim dosen tsb, urut dari submission terlama, dengan indikator SLA.\nFilter type/level dibatasi 2000 prestasi yang cocok.",
                 "consumes": [
                     "application/json"
                 ],
@@ -548,12 +609,24 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get user achievements",
+                "summary": "Verification queue",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter status (draft, submitted, verified, rejected, deleted)",
-                        "name": "status",
+                        "description": "Filter achievement type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter level",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter student ID (UUID)",
+                        "name": "student_id",
                         "in": "query"
                     },
                     {
@@ -573,81 +646,190 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.PaginatedResponse-model_VerificationQueueItem"
                         }
                     },
                     "400": {
-                        "description": "Invalid role",
+                        "description": "Type/level filter matches too many achievements",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Not a reviewer",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
-                    }
-                },
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/search": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            },
-            "post": {
-                "description": "Membuat prestasi baru untuk mahasiswa (draft status)",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Full-text search pada judul, deskripsi, tags, penyelenggara, lokasi, nomor sertifikat dan details, digabung dengan filter Postgres. Hasil mengikuti visibilitas role: mahasiswa hanya miliknya, dosen wali mahasiswa bimbingan, admin semua. Hanya 1000 kecocokan teratas (skor relevansi) yang dipertimbangkan; total dihitung dari batas itu. Highlight berupa HTML yang sudah di-escape dengan kecocokan dibungkus \u003cem\u003e.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Create achievement",
+                "summary": "Search achievements",
                 "parameters": [
                     {
-                        "description": "Achievement data",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Achievement"
-                        }
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter student ID (UUID)",
+                        "name": "student_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter program studi",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter angkatan",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter dosen wali (UUID)",
+                        "name": "advisor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created from (YYYY-MM-DD atau RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created to, inklusif (YYYY-MM-DD atau RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal kejadian dari (YYYY-MM-DD)",
+                        "name": "event_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tanggal kejadian sampai, inklusif (YYYY-MM-DD)",
+                        "name": "event_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementReference"
+                            "$ref": "#/definitions/model.PaginatedResponse-model_AchievementSearchResult"
                         }
                     },
                     "400": {
-                        "description": "Student not found",
+                        "description": "Missing query or invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to create in DB",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/team/invitations": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
+                ],
+                "description": "Prestasi tim yang menunggu konfirmasi mahasiswa yang sedang login",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "List team invitations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Achievement"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not a student",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
         "/achievements/{id}": {
             "get": {
-                "description": "Mengambil detail lengkap prestasi berdasarkan ID, termasuk history status",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil detail lengkap prestasi berdasarkan ID, termasuk history status. Untuk user yang boleh melihat attachment, previews berisi signed link thumbnail dan preview (halaman pertama PDF) yang sudah lolos scan antivirus.",
                 "consumes": [
                     "application/json"
                 ],
@@ -674,12 +856,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.AchievementDetailResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Achievement not found or deleted",
                         "schema": {
@@ -687,20 +863,20 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            },
-            "put": {
-                "description": "Memperbarui prestasi (hanya untuk draft atau rejected status)",
+                ],
+                "description": "Memperbarui prestasi (hanya untuk draft, rejected, atau revoked status). Attachments, status history, points dan timestamp tidak ikut diganti.",
                 "consumes": [
                     "application/json"
                 ],
@@ -721,44 +897,69 @@ const docTemplate = `{
                     },
                     {
                         "description": "Updated achievement data",
-                        "name": "body",
+                        "name": "achievement",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "$ref": "#/definitions/model.Achievement"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag (version) dari GET /achievements/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "message: Updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Cannot update this achievement (wrong status)",
+                        "description": "Cannot update this achievement",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Reference not found",
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Version mismatch (ETag out of date)",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update in DB",
+                        "description": "Failed to update",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            },
-            "delete": {
+                ],
                 "description": "Soft delete prestasi (hanya untuk draft status, oleh mahasiswa)",
                 "consumes": [
                     "application/json"
@@ -777,48 +978,39 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "User ID (UUID) - must be student owner",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
-                    },
-                    "400": {
-                        "description": "Can only delete draft achievement",
+                        "description": "message: Deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Achievement not found",
+                    "400": {
+                        "description": "Can only delete draft achievement",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete in DB",
+                        "description": "Failed to delete",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "patch": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/achievements/{id}/submit": {
-            "post": {
-                "description": "Submit prestasi untuk verifikasi (dari draft atau rejected)",
+                ],
+                "description": "Mengubah sebagian field prestasi (draft/rejected) dengan JSON Merge Patch (application/merge-patch+json) atau JSON Patch (application/json-patch+json). Field milik server (statusHistory, attachments, points, timestamps, studentId) tidak bisa diubah.",
                 "consumes": [
                     "application/json"
                 ],
@@ -828,7 +1020,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Submit achievement",
+                "summary": "Partially update achievement",
                 "parameters": [
                     {
                         "type": "string",
@@ -837,47 +1029,84 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Merge patch object atau array operasi JSON patch",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "User ID (UUID) - submitter",
-                        "name": "user_id",
-                        "in": "query",
+                        "description": "ETag (version) dari GET /achievements/{id}",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "message + daftar perubahan field",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "400": {
-                        "description": "Only draft/rejected can be submitted",
+                        "description": "Invalid patch / unknown field",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Achievement not found",
+                    "403": {
+                        "description": "Not the owner",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to submit",
+                    "409": {
+                        "description": "JSON patch test operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Version mismatch (ETag out of date)",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported patch content type",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Patch cannot be applied or touches a read-only field",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/assign": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/achievements/{id}/verify": {
-            "post": {
-                "description": "Verifikasi prestasi (oleh dosen/admin, dari submitted status)",
+                ],
+                "description": "Menyerahkan prestasi submitted ke dosen lain (reviewer_id adalah user ID dosen)",
                 "consumes": [
                     "application/json"
                 ],
@@ -887,7 +1116,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Verify achievement",
+                "summary": "Assign reviewer",
                 "parameters": [
                     {
                         "type": "string",
@@ -897,48 +1126,56 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Verifier User ID (UUID)",
-                        "name": "verified_by",
-                        "in": "query",
-                        "required": true
+                        "description": "Reviewer",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "status: assigned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Only submitted can be verified",
+                        "description": "Invalid reviewer",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Achievement not found",
+                    "403": {
+                        "description": "Not a reviewer of this achievement",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to verify",
+                    "409": {
+                        "description": "Claimed by another reviewer",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/attachments": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/achievements/{id}/reject": {
-            "post": {
-                "description": "Tolak prestasi dengan note (oleh dosen/admin, dari submitted status)",
+                ],
+                "description": "Upload file attachment ke prestasi (pemilik saja, hanya saat draft atau rejected). Hanya PDF, JPEG dan PNG (dideteksi dari isi file); executable dan file polyglot ditolak. File disimpan di storage backend yang dikonfigurasi (local atau S3-compatible).",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
@@ -946,7 +1183,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Reject achievement",
+                "summary": "Upload attachment",
                 "parameters": [
                     {
                         "type": "string",
@@ -956,33 +1193,28 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Rejector User ID (UUID)",
-                        "name": "verified_by",
-                        "in": "query",
+                        "type": "file",
+                        "description": "Attachment file",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
-                    },
-                    {
-                        "description": "Rejection note",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "rejection_note": {
-                                    "type": "string"
-                                }
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Attachment"
+                        }
                     },
                     "400": {
-                        "description": "Only submitted can be rejected",
+                        "description": "No file, or achievement is not draft or rejected",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -993,33 +1225,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Too many attachments",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    },
+                    "413": {
+                        "description": "File too large for its type",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    },
+                    "415": {
+                        "description": "Type not allowed, executable or polyglot",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    },
                     "500": {
-                        "description": "Failed to reject",
+                        "description": "Failed to upload",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/attachments/{attachmentId}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/achievements/{id}/history": {
-            "get": {
-                "description": "Mengambil riwayat status prestasi",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Mengunduh file attachment (mahasiswa pemilik, dosen wali pembimbing, atau admin). Hanya file yang lolos scan antivirus. Mendukung header Range; gunakan inline=true untuk ditampilkan di browser.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get achievement history",
+                "summary": "Download attachment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1027,41 +1274,67 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID (id, atau fileName untuk upload lama)",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Content-Disposition inline alih-alih attachment",
+                        "name": "inline",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.StatusHistory"
-                            }
+                            "type": "file"
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
+                    "206": {
+                        "description": "Partial content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this achievement, or attachment quarantined",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Attachment is waiting for the virus scan",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "416": {
+                        "description": "Range not satisfiable",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/achievements/{id}/attachments": {
-            "post": {
-                "description": "Upload file attachment ke prestasi (hanya untuk non-deleted)",
+                ],
+                "description": "Mengganti file attachment (pemilik saja, hanya saat draft atau rejected). Validasi sama seperti upload; ID attachment tetap. File lama dihapus, atau dikarantina jika prestasi sudah pernah direview (rejected).",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -1071,7 +1344,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Upload attachment",
+                "summary": "Replace attachment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1080,24 +1353,17 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "file",
-                        "description": "Attachment file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
-                    },
                     {
                         "type": "string",
-                        "description": "Original file name",
-                        "name": "file_name",
-                        "in": "formData",
+                        "description": "Attachment ID (id, atau fileName untuk upload lama)",
+                        "name": "attachmentId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "MIME type",
-                        "name": "file_type",
+                        "type": "file",
+                        "description": "Attachment file",
+                        "name": "file",
                         "in": "formData",
                         "required": true
                     }
@@ -1110,106 +1376,125 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "No file uploaded",
+                        "description": "Achievement is not draft or rejected",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Achievement not found",
+                    "403": {
+                        "description": "Not the owner",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Failed to upload",
+                    "404": {
+                        "description": "Attachment not found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
+                    },
+                    "413": {
+                        "description": "File too large for its type",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    },
+                    "415": {
+                        "description": "Type not allowed, executable or polyglot",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
                     }
-                },
+                }
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-
-
-        
-             "/lecturers": {
-            "get": {
-                "description": "Mengambil daftar dosen berdasarkan role user (lecturer: own data, student: advisor data, admin: all with pagination)",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Menghapus attachment (pemilik saja, hanya saat draft atau rejected). File dihapus dari storage, atau dikarantina jika prestasi sudah pernah direview (rejected); tercatat di status history.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Get all lecturers",
+                "summary": "Delete attachment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Items per page (default 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Attachment ID (id, atau fileName untuk upload lama)",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "message",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "No advisor assigned",
+                        "description": "Achievement is not draft or rejected",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/attachments/{attachmentId}/link": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/lecturers/{lecturer_id}/advisees": {
-            "get": {
-                "description": "Mengambil daftar mahasiswa bimbingan dosen (dengan access check: own, advisor, or admin)",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Membuat link unduhan berumur pendek (tanpa header Authorization) untuk disematkan di frontend, mis. pada img/iframe",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Get lecturer's advisees",
+                "summary": "Signed attachment link",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID (UUID)",
-                        "name": "lecturer_id",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID (id, atau fileName untuk upload lama)",
+                        "name": "attachmentId",
                         "in": "path",
                         "required": true
                     }
@@ -1218,95 +1503,70 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Student"
-                            }
+                            "$ref": "#/definitions/model.AttachmentLink"
                         }
                     },
-                    "400": {
-                        "description": "Access denied",
+                    "403": {
+                        "description": "Not allowed to view this achievement",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Lecturer not found",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Attachment not found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/autosave": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-
-
-
-
-
-
-        "/students": {
-            "get": {
-                "description": "Mengambil daftar mahasiswa berdasarkan role user (student: own, lecturer: advisees, admin: all with pagination)",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Mengambil autosave terakhir (pemilik saja); base_version yang berbeda dari ETag sekarang berarti konten sudah disimpan ulang sejak autosave",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Get all students",
+                "summary": "Get autosaved draft",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (default 10)",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
+                            "$ref": "#/definitions/model.AchievementAutosave"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "No autosaved draft",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/students/me": {
-            "get": {
-                "description": "Mengambil profil mahasiswa untuk user yang login (jika mahasiswa)",
+                ],
+                "description": "Menyimpan konten draft yang sedang dikerjakan (pemilik saja). Tidak butuh If-Match, tidak menaikkan version, tidak menambah status history maupun revisi; autosave dihapus saat konten disimpan lewat PUT/PATCH atau disubmit.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1314,53 +1574,68 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Get own student profile",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Student"
-                        }
+                "summary": "Autosave draft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "404": {
-                        "description": "No student profile found",
+                    {
+                        "description": "Konten draft",
+                        "name": "achievement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Achievement"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementAutosave"
+                        }
+                    },
+                    "400": {
+                        "description": "Achievement is not editable",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Not the owner",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/claim": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/students/{id}": {
-            "get": {
-                "description": "Mengambil detail mahasiswa berdasarkan ID, dengan access check (own, advisor, admin)",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Menandai prestasi sedang diperiksa reviewer agar tidak diproses dua dosen sekaligus",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Get student by ID",
+                "summary": "Claim achievement for review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
+                        "description": "Achievement ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1368,113 +1643,78 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Student"
-                        }
-                    },
-                    "400": {
-                        "description": "Access denied",
+                        "description": "status: claimed",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Student not found",
+                    "403": {
+                        "description": "Not a reviewer of this achievement",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Claimed by another reviewer",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/students/{student_id}/achievements": {
-            "get": {
-                "description": "Mengambil prestasi mahasiswa, dengan access check dan filter status/pagination",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Melepas claim reviewer (hanya pemilik claim atau admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Get student achievements",
+                "summary": "Release review claim",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
-                        "name": "student_id",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter status (draft, submitted, verified, rejected, deleted)",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number (default 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Items per page (default 10)",
-                        "name": "limit",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.UserResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Access denied",
-                        "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Student not found",
+                        "description": "status: released",
                         "schema": {
-                            "$ref": "#/definitions/model.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Not the claiming reviewer",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/history": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-        "/students/{student_id}/advisor": {
-            "put": {
-                "description": "Memperbarui dosen wali mahasiswa (hanya untuk admin)",
+                ],
+                "description": "Mengambil riwayat status prestasi",
                 "consumes": [
                     "application/json"
                 ],
@@ -1482,97 +1722,103 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Update student advisor",
+                "summary": "Get achievement history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
-                        "name": "student_id",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New Advisor ID (UUID)",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "advisor_id": {
-                                    "type": "string"
-                                }
-                            }
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StatusHistory"
+                            }
+                        }
                     },
-                    "400": {
-                        "description": "Unauthorized (only admin)",
+                    "404": {
+                        "description": "Achievement not found",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/achievements/{id}/receipt": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
-                ]
-            }
-        },
-
-
-
-
-        "/reports/statistics": {
-            "get": {
-                "description": "Mengambil statistik prestasi berdasarkan role user (student: own, lecturer: advisees, admin: global)",
-                "consumes": [
-                    "application/json"
                 ],
+                "description": "Admin: menerbitkan ulang receipt prestasi verified yang receipt-nya hilang (gagal diterbitkan saat verifikasi) atau tidak lagi sah dengan kunci aktif maupun kunci retired. Receipt yang masih sah tidak diganti.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Verification Receipts"
                 ],
-                "summary": "Get achievement statistics",
+                "summary": "Reissue a verification receipt",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "user_id",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "receipt",
                         "schema": {
-                            "$ref": "#/definitions/model.AchievementStatistics"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "No profile found",
+                        "description": "Not verified",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Receipt still valid",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Receipts disabled",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
@@ -1580,9 +1826,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/reports/students/{student_id}/statistics": {
-            "get": {
-                "description": "Mengambil statistik prestasi mahasiswa spesifik (dengan access check: own, advisor, or admin)",
+        "/achievements/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Tolak prestasi dengan note (oleh dosen/admin, dari submitted status)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1590,383 +1841,3728 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Achievements"
                 ],
-                "summary": "Get student achievement statistics",
+                "summary": "Reject achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID (UUID)",
-                        "name": "student_id",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Rejection note",
+                        "name": "rejection_note",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "User ID (UUID) for access check",
-                        "name": "user_id",
-                        "in": "query",
+                        "description": "ETag (version) dari GET /achievements/{id}",
+                        "name": "If-Match",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "status: rejected",
                         "schema": {
-                            "$ref": "#/definitions/model.StudentAchievementStatistics"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Access denied",
+                        "description": "Note required or wrong status",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Student not found",
+                    "403": {
+                        "description": "Not a reviewer of this achievement",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Claimed by another reviewer",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Version mismatch (ETag out of date)",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to reject",
                         "schema": {
                             "$ref": "#/definitions/model.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "model.User": {
+        },
+        "/achievements/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Daftar snapshot konten prestasi (dibuat saat create, update, dan submit), urut dari yang paling lama",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "List achievement revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AchievementRevision"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this achievement",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Achievement not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/revisions/{a}/diff/{b}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Perubahan konten antara revisi a dan b (b boleh \"latest\"), mis. untuk melihat apa yang diubah mahasiswa sejak ditolak",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Diff two achievement revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revisi awal",
+                        "name": "a",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Revisi akhir atau latest",
+                        "name": "b",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.RevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid revision number",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed to view this achievement",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mencabut verifikasi prestasi (hanya admin, dari verified status) dengan alasan wajib. Prestasi kembali bisa diedit dan disubmit ulang.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Revoke verification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Alasan pencabutan",
+                        "name": "reason",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag (version) dari GET /achievements/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: revoked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Reason required or wrong status",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Version mismatch (ETag out of date)",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Submit prestasi untuk verifikasi (dari draft atau rejected)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Submit achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag (version) dari GET /achievements/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: submitted, duplicates: kemungkinan duplikat (jika ada)",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Only draft/rejected/revoked can be submitted",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Version mismatch (ETag out of date)",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to submit",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/team/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Anggota tim mengonfirmasi keikutsertaan; prestasi lalu muncul di daftar \u0026 statistik anggota tersebut",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Confirm team membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID) milik ketua tim",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementReference"
+                        }
+                    },
+                    "404": {
+                        "description": "No pending invitation",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/team/decline": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Anggota tim menolak keikutsertaan",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Decline team membership",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID) milik ketua tim",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: declined",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "No pending invitation",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/uploads": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Membuka sesi resumable upload (protokol tus 1.0.0: creation, checksum, expiration, termination) untuk file besar seperti video. Hanya pemilik, saat prestasi draft atau rejected. Jenis file di-sniff dari chunk pertama; batas ukuran sama dengan upload biasa.",
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Create resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Ukuran file (byte)",
+                        "name": "Upload-Length",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "filename \u003cbase64\u003e",
+                        "name": "Upload-Metadata",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Location: URL upload"
+                    },
+                    "400": {
+                        "description": "Achievement is not draft or rejected",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Too many attachments",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    },
+                    "412": {
+                        "description": "Unsupported tus version"
+                    },
+                    "413": {
+                        "description": "File too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/uploads/{uploadId}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Membatalkan sesi upload dan menghapus chunk yang sudah diterima",
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Cancel resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Upload not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengembalikan offset sesi upload (Upload-Offset) agar client bisa melanjutkan setelah koneksi putus",
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Resumable upload offset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload-Offset, Upload-Length, Upload-Expires"
+                    },
+                    "404": {
+                        "description": "Upload not found or expired"
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengirim chunk pada Upload-Offset (Content-Type application/offset+octet-stream). Upload-Checksum \"sha256 \u003cbase64\u003e\" opsional; mismatch menghasilkan 460. Setelah chunk terakhir file digabung, divalidasi dan ditambahkan sebagai attachment (200 dengan metadata attachment); sebelum itu 204. Status prestasi dicek lagi saat penggabungan; PATCH akhir yang bersamaan dengan penggabungan lain mendapat 204 tanpa menambah attachment.",
+                "consumes": [
+                    "application/offset+octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Upload chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "1.0.0",
+                        "name": "Tus-Resumable",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset chunk",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sha256 \u003cbase64\u003e",
+                        "name": "Upload-Checksum",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload selesai",
+                        "schema": {
+                            "$ref": "#/definitions/model.Attachment"
+                        }
+                    },
+                    "204": {
+                        "description": "Chunk diterima"
+                    },
+                    "400": {
+                        "description": "Achievement is no longer draft or rejected",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Upload not found or expired"
+                    },
+                    "409": {
+                        "description": "Offset mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Chunk or file too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    },
+                    "415": {
+                        "description": "Type not allowed, executable or polyglot",
+                        "schema": {
+                            "$ref": "#/definitions/model.AttachmentError"
+                        }
+                    },
+                    "460": {
+                        "description": "Checksum mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Verifikasi prestasi (oleh dosen/admin, dari submitted status)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Verify achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag (version) dari GET /achievements/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: verified",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Only submitted can be verified",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a reviewer of this achievement",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Claimed by another reviewer",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Version mismatch (ETag out of date)",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to verify",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/achievements/{id}/withdraw": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Menarik kembali submission (oleh mahasiswa pemilik, dari submitted status) menjadi draft",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Withdraw submission",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag (version) dari GET /achievements/{id}",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "status: draft",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Only submitted can be withdrawn",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not the owner",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Version mismatch (ETag out of date)",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header required",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Melakukan login dengan username/email dan password, menghasilkan access dan refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Credentials (username or email and password)",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid credentials or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Account inactive",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Melakukan logout dengan invalidasi refresh token (client-side untuk demo)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user",
+                "parameters": [
+                    {
+                        "description": "Refresh token to invalidate",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil data user berdasarkan JWT token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Memperbarui access dan refresh token menggunakan refresh token yang valid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AuthResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Account inactive",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{key}": {
+            "get": {
+                "description": "Melayani signed link dari storage lokal (tanpa autentikasi; signature dan masa berlaku diverifikasi). Storage S3 memberikan presigned URL langsung sehingga route ini tidak dipakai.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Open signed file link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Storage key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Unix timestamp kedaluwarsa",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired signature",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar dosen berdasarkan role user (lecturer: own data, student: advisor data, admin: all with pagination)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturers"
+                ],
+                "summary": "Get all lecturers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Paginated lecturers",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_Lecturer"
+                        }
+                    },
+                    "400": {
+                        "description": "No advisor assigned",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers/{id}/advisees": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar mahasiswa bimbingan dosen (dengan access check: own, advisor, or admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturers"
+                ],
+                "summary": "Get lecturer's advisees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "data array of model.Student",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Lecturer not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/statistics": {
+            "get": {
+                "description": "Mengambil statistik prestasi berdasarkan role user (student: own, lecturer: advisees, admin: global)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get achievement statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Kode semester (2024/2025-ganjil) atau tahun akademik (2024/2025); default tahun akademik berjalan",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event date to, inklusif (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.AchievementStatistics"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown period or invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "No profile found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/students/{student_id}/statistics": {
+            "get": {
+                "description": "Mengambil statistik prestasi mahasiswa spesifik (dengan access check: own, advisor, or admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get student achievement statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "student_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kode semester (2024/2025-ganjil) atau tahun akademik (2024/2025); default tahun akademik berjalan",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event date from (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event date to, inklusif (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.StudentAchievementStatistics"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown period or invalid date",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar mahasiswa berdasarkan role user (student: own, lecturer: advisees, admin: all with pagination)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get all students",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor untuk keyset pagination (halaman pertama); respons berbentuk model.CursorResponse",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari halaman sebelumnya (mengaktifkan keyset pagination)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan total pada keyset pagination (menjalankan COUNT)",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_Student"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil profil mahasiswa untuk user yang login (jika mahasiswa)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get own student profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Student"
+                        }
+                    },
+                    "404": {
+                        "description": "No student profile found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil detail mahasiswa berdasarkan ID, dengan access check (own, advisor, admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get student by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Student"
+                        }
+                    },
+                    "400": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/achievements": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil prestasi mahasiswa, dengan access check dan filter status/pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get student achievements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter status (draft, submitted, verified, rejected, deleted)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Isi tambahan per item: achievement (konten Mongo dimuat batch per halaman)",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "cursor untuk keyset pagination (halaman pertama); respons berbentuk model.CursorResponse",
+                        "name": "pagination",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor dari halaman sebelumnya (mengaktifkan keyset pagination)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sertakan total pada keyset pagination (menjalankan COUNT)",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaginatedResponse-model_AchievementReference"
+                        }
+                    },
+                    "400": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/students/{id}/advisor": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Memperbarui dosen wali mahasiswa (hanya untuk admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Update student advisor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "message: Advisor updated successfully",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Seluruh vocabulary tag beserta parent dan sinonim",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "List tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Tag"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Menambah tag ke vocabulary (admin)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Create tag",
+                "parameters": [
+                    {
+                        "description": "Tag",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid tag",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an admin",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug or synonym already used",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/autocomplete": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Saran tag berdasarkan awalan slug, nama atau sinonim; hasil berisi slug kanonik dan path hierarki",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Autocomplete tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Prefix",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max suggestions (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TagSuggestion"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengubah nama, parent atau sinonim tag (admin); slug lama tetap dikenali sebagai sinonim",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Update tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tag",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TagRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Tag"
+                        }
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Slug or synonym already used",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Menghapus tag (admin); tag yang masih punya turunan tidak bisa dihapus",
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Delete tag",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tag ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Tag not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Tag has child tags",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil daftar semua user dari database (dengan pagination, search, sorting)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Dapatkan semua user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Nomor halaman (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Jumlah item per halaman (default 10)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Kolom untuk sorting (id, username, email, role, created_at)",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Urutan sorting (asc/desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pencarian user",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.UserResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Membuat user baru dengan data yang diberikan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Buat user baru",
+                "parameters": [
+                    {
+                        "description": "Data user baru",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.CreateUserReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Mengambil detail user dengan ID tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Dapatkan user berdasarkan ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Memperbarui data user dengan ID tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Data user yang diupdate",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Menghapus user dengan ID tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Hapus user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Memperbarui role user dengan ID tertentu",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update role user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role ID baru",
+                        "name": "role_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verification-receipts/keys": {
+            "get": {
+                "description": "Publik: kunci publik Ed25519 (JWK Set) untuk memeriksa tanda tangan receipt secara offline",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Verification Receipts"
+                ],
+                "summary": "Verification receipt public keys",
+                "responses": {
+                    "200": {
+                        "description": "keys",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/receipt.JWK"
+                                }
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Receipts disabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verification-receipts/verify": {
+            "get": {
+                "description": "Publik (tanpa login): memeriksa receipt verifikasi prestasi. genuine=true jika tanda tangan sah dari server ini; unchanged=true jika prestasi masih verified dengan receipt yang sama dan isi serta attachment-nya tidak berubah sejak diverifikasi. Receipt dikirim lewat query ?receipt= (GET) atau body {\"receipt\": \"...\"} (POST).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Verification Receipts"
+                ],
+                "summary": "Verify a verification receipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Receipt (JWS compact)",
+                        "name": "receipt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReceiptVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Receipt missing",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Receipts disabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Publik (tanpa login): memeriksa receipt verifikasi prestasi. genuine=true jika tanda tangan sah dari server ini; unchanged=true jika prestasi masih verified dengan receipt yang sama dan isi serta attachment-nya tidak berubah sejak diverifikasi. Receipt dikirim lewat query ?receipt= (GET) atau body {\"receipt\": \"...\"} (POST).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Verification Receipts"
+                ],
+                "summary": "Verify a verification receipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Receipt (JWS compact)",
+                        "name": "receipt",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReceiptVerification"
+                        }
+                    },
+                    "400": {
+                        "description": "Receipt missing",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Receipts disabled",
+                        "schema": {
+                            "$ref": "#/definitions/model.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "bson.M": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "model.AcademicPeriod": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "contoh: \"2024/2025-ganjil\"",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AcademicPeriodRequest": {
+            "type": "object",
+            "properties": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "semester": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Achievement": {
+            "type": "object",
+            "properties": {
+                "_id": {
+                    "type": "string"
+                },
+                "achievementType": {
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Attachment"
+                    }
+                },
+                "certificateNumber": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "$ref": "#/definitions/bson.M"
+                },
+                "eventDate": {
+                    "description": "Data kejadian \u0026 verifikasi eksternal; data lama mungkin masih menyimpannya di details",
+                    "type": "string"
+                },
+                "level": {
+                    "description": "Added for competition level distribution",
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "organizer": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "statusHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StatusHistory"
+                    }
+                },
+                "studentId": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "team": {
+                    "description": "nil untuk prestasi individu",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Team"
+                        }
+                    ]
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "verificationUrl": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "optimistic concurrency counter dokumen",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementAutosave": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "base_version": {
+                    "description": "version saat autosave; berbeda dari ETag sekarang berarti basi",
+                    "type": "integer"
+                },
+                "content": {
+                    "$ref": "#/definitions/model.Achievement"
+                },
+                "saved_at": {
+                    "type": "string"
+                },
+                "saved_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementDetailResponse": {
+            "type": "object",
+            "properties": {
+                "achievement": {
+                    "$ref": "#/definitions/model.Achievement"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "previews": {
+                    "description": "hanya untuk yang boleh melihat attachment",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AttachmentPreview"
+                    }
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "statusHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StatusHistory"
+                    }
+                },
+                "student": {
+                    "$ref": "#/definitions/model.Student"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "verification_hints": {
+                    "description": "hanya untuk reviewer (dosen wali/admin)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.VerificationHint"
+                    }
+                },
+                "verification_receipt": {
+                    "description": "JWS receipt verifikasi, bisa dicek di /verification-receipts/verify",
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementReference": {
+            "type": "object",
+            "properties": {
+                "achievement": {
+                    "$ref": "#/definitions/model.Achievement"
+                },
+                "claimed_at": {
+                    "type": "string"
+                },
+                "claimed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duplicates": {
+                    "description": "peringatan duplikat saat create",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DuplicateWarning"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mongo_achievement_id": {
+                    "type": "string"
+                },
+                "primary_reference_id": {
+                    "description": "diisi pada reference anggota tim",
+                    "type": "string"
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student": {
+                    "$ref": "#/definitions/model.Student"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementRevision": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/model.Achievement"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AchievementSearchResult": {
+            "type": "object",
+            "properties": {
+                "achievement": {
+                    "$ref": "#/definitions/model.Achievement"
+                },
+                "claimed_at": {
+                    "type": "string"
+                },
+                "claimed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "duplicates": {
+                    "description": "peringatan duplikat saat create",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DuplicateWarning"
+                    }
+                },
+                "highlights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mongo_achievement_id": {
+                    "type": "string"
+                },
+                "primary_reference_id": {
+                    "description": "diisi pada reference anggota tim",
+                    "type": "string"
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student": {
+                    "$ref": "#/definitions/model.Student"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "submitted_at": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementStatistics": {
+            "type": "object",
+            "properties": {
+                "distribution": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "organizer_counts": {
+                    "description": "per penyelenggara (field organizer atau details)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "tag_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "top_students": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TopStudent"
+                    }
+                },
+                "total_per_period": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "total_per_type": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "total_revoked": {
+                    "description": "verifikasi yang dicabut, tidak ikut dihitung di atas",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Attachment": {
+            "type": "object",
+            "properties": {
+                "contentHash": {
+                    "description": "sha256 isi file",
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "fileType": {
+                    "type": "string"
+                },
+                "fileUrl": {
+                    "type": "string"
+                },
+                "id": {
+                    "description": "tetap sama walau file diganti; kosong untuk upload lama",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "teks \u0026 QR hasil ekstraksi saat upload",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.AttachmentMetadata"
+                        }
+                    ]
+                },
+                "scanResult": {
+                    "description": "nama signature jika terinfeksi, atau keterangan",
+                    "type": "string"
+                },
+                "scanStatus": {
+                    "description": "kosong (upload lama) diperlakukan sebagai pending_scan",
+                    "type": "string"
+                },
+                "scannedAt": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "uploadedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AttachmentError": {
+            "type": "object",
+            "properties": {
+                "allowed_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "detected_type": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "max_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AttachmentGCReport": {
+            "type": "object",
+            "properties": {
+                "missing_files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MissingFile"
+                    }
+                },
+                "objects_scanned": {
+                    "type": "integer"
+                },
+                "orphan_bytes": {
+                    "type": "integer"
+                },
+                "orphan_files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrphanFile"
+                    }
+                },
+                "ref_count_fixes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RefCountFix"
+                    }
+                },
+                "references": {
+                    "type": "integer"
+                },
+                "repair": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.AttachmentLink": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AttachmentMetadata": {
+            "type": "object",
+            "properties": {
+                "certificateNumber": {
+                    "type": "string"
+                },
+                "extractedAt": {
+                    "type": "string"
+                },
+                "qrCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "urls": {
+                    "description": "QR dulu, lalu link PDF, lalu URL di teks",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.AttachmentPreview": {
+            "type": "object",
+            "properties": {
+                "attachment_id": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "preview_url": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "properties": {
+                        "permissions": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        },
+                        "refreshToken": {
+                            "type": "string"
+                        },
+                        "role": {
+                            "type": "string"
+                        },
+                        "token": {
+                            "type": "string"
+                        },
+                        "user": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BatchReviewResponse": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BatchReviewResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.BatchReviewResult": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DuplicateMatch": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DuplicateWarning": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "new": {},
+                "old": {}
+            }
+        },
+        "model.Lecturer": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lecturer_id": {
+                    "type": "string"
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Notification"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "description": "or email",
+                    "type": "string"
+                }
+            }
+        },
+        "model.MissingFile": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "attachment_id": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "mongo_achievement_id": {
+                    "type": "string"
+                },
+                "revision": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Notification": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "read": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrphanFile": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "mod_time": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_AchievementReference": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementReference"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_AchievementSearchResult": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementSearchResult"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_Lecturer": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Lecturer"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_Student": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Student"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedResponse-model_VerificationQueueItem": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.VerificationQueueItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ReceiptAttachment": {
+            "type": "object",
+            "properties": {
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReceiptVerification": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ReceiptAttachment"
+                    }
+                },
+                "content_sha256": {
+                    "type": "string"
+                },
+                "genuine": {
+                    "type": "boolean"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "problems": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "description": "status prestasi saat ini",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "boolean"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RefCountFix": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "integer"
+                },
+                "fixed": {
+                    "type": "boolean"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "stored": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RevisionDiff": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldChange"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/model.AchievementRevision"
+                },
+                "to": {
+                    "$ref": "#/definitions/model.AchievementRevision"
+                }
+            }
+        },
+        "model.StatusHistory": {
+            "type": "object",
+            "properties": {
+                "changedAt": {
+                    "type": "string"
+                },
+                "changedBy": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FieldChange"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Student": {
             "type": "object",
             "properties": {
-                "id": {
+                "academic_year": {
                     "type": "string"
                 },
-                "username": {
+                "advisor": {
+                    "$ref": "#/definitions/model.Lecturer"
+                },
+                "advisor_id": {
                     "type": "string"
                 },
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "passwordHash": {
+                "id": {
                     "type": "string"
                 },
-                "fullName": {
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Notification"
+                    }
+                },
+                "program_study": {
                     "type": "string"
                 },
-                "roleID": {
+                "student_id": {
                     "type": "string"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "user": {
+                    "$ref": "#/definitions/model.User"
                 },
-                "createdAt": {
+                "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "model.StudentAchievementStatistics": {
+            "type": "object",
+            "properties": {
+                "distribution": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
                 },
-                "updatedAt": {
-                    "type": "string"
+                "organizer_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "per_period": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "per_type": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "tag_counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "total_achievements": {
+                    "type": "integer"
+                },
+                "total_revoked": {
+                    "type": "integer"
                 }
             }
         },
-        "service.CreateUserReq": {
+        "model.Tag": {
             "type": "object",
             "properties": {
-                "username": {
+                "created_at": {
                     "type": "string"
                 },
-                "email": {
+                "id": {
                     "type": "string"
                 },
-                "password": {
+                "name": {
                     "type": "string"
                 },
-                "full_name": {
+                "parent_id": {
                     "type": "string"
                 },
-                "role_id": {
+                "slug": {
+                    "type": "string"
+                },
+                "synonyms": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "model.ErrorResponse": {
+        "model.TagRequest": {
             "type": "object",
             "properties": {
-                "status": {
+                "name": {
                     "type": "string"
                 },
-                "message": {
+                "parent_id": {
                     "type": "string"
-                }
-            }
-        },
-        "model.UserResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
+                },
+                "synonyms": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.User"
-                    }
-                },
-                "meta": {
-                    "type": "object",
-                    "properties": {
-                        "page": {
-                            "type": "integer"
-                        },
-                        "limit": {
-                            "type": "integer"
-                        },
-                        "total": {
-                            "type": "integer"
-                        },
-                        "totalPages": {
-                            "type": "integer"
-                        }
+                        "type": "string"
                     }
                 }
             }
         },
-        "model.AchievementReference": {
+        "model.TagSuggestion": {
             "type": "object",
             "properties": {
-                "id": {
+                "matched_synonym": {
+                    "description": "diisi jika yang cocok adalah sinonim",
                     "type": "string"
                 },
-                "studentID": {
+                "name": {
                     "type": "string"
                 },
-                "mongoAchievementID": {
-                    "type": "string"
+                "path": {
+                    "description": "nama dari root sampai tag ini",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "status": {
+                "slug": {
                     "type": "string"
+                }
+            }
+        },
+        "model.Team": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TeamMember"
+                    }
                 },
-                "createdAt": {
+                "name": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "pointsPolicy": {
                     "type": "string"
                 }
             }
         },
-        "model.AchievementDetailResponse": {
+        "model.TeamMember": {
             "type": "object",
             "properties": {
-                "id": {
+                "respondedAt": {
                     "type": "string"
                 },
-                "student": {
-                    "$ref": "#/definitions/model.Student"
+                "role": {
+                    "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
-                "submittedAt": {
+                "studentId": {
                     "type": "string"
+                }
+            }
+        },
+        "model.TopStudent": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
                 },
-                "verifiedAt": {
+                "full_name": {
                     "type": "string"
                 },
-                "verifiedBy": {
-                    "type": "string"
+                "points": {
+                    "type": "integer"
                 },
-                "rejectionNote": {
+                "student_id": {
                     "type": "string"
-                },
-                "achievement": {
-                    "$ref": "#/definitions/model.Achievement"
-                },
-                "statusHistory": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.StatusHistory"
-                    }
                 }
             }
         },
-        "model.Achievement": {
+        "model.User": {
             "type": "object",
             "properties": {
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "studentID": {
+                "email": {
                     "type": "string"
                 },
-                "title": {
+                "full_name": {
                     "type": "string"
                 },
-                "description": {
+                "id": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "level": {
+                "role_id": {
                     "type": "string"
                 },
-                "date": {
+                "updated_at": {
                     "type": "string"
                 },
-                "deletedAt": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "model.StatusHistory": {
+        "model.UserResponse": {
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
                 },
-                "changedBy": {
-                    "type": "string"
+                "limit": {
+                    "type": "integer"
                 },
-                "changedAt": {
-                    "type": "string"
+                "page": {
+                    "type": "integer"
                 },
-                "note": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
                 }
             }
         },
-        "model.Attachment": {
+        "model.VerificationHint": {
             "type": "object",
             "properties": {
-                "id": {
+                "attachment_id": {
                     "type": "string"
                 },
-                "fileName": {
+                "certificate_number": {
                     "type": "string"
                 },
-                "fileType": {
+                "file_name": {
                     "type": "string"
                 },
-                "url": {
+                "issuer_url": {
                     "type": "string"
                 },
-                "uploadedAt": {
+                "source": {
                     "type": "string"
                 }
             }
         },
-        "model.Lecturer": {
+        "model.VerificationQueueItem": {
             "type": "object",
             "properties": {
-                "id": {
+                "achievement_type": {
                     "type": "string"
                 },
-                "userID": {
+                "claimed_at": {
                     "type": "string"
                 },
-                "name": {
+                "claimed_by": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
-                }
-            }
-        },
-        "model.Student": {
-            "type": "object",
-            "properties": {
-                "id": {
+                "due_at": {
                     "type": "string"
                 },
-                "userID": {
-                    "type": "string"
+                "duplicates": {
+                    "description": "kemungkinan duplikat, untuk dicek reviewer",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DuplicateMatch"
+                    }
                 },
-                "advisorID": {
+                "id": {
                     "type": "string"
                 },
-                "name": {
+                "level": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "points": {
+                    "type": "integer"
                 },
-                "advisor": {
-                    "$ref": "#/definitions/model.Lecturer"
+                "sla_status": {
+                    "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/model.User"
-                }
-            }
-        },
-        "model.AchievementStatistics": {
-            "type": "object",
-            "properties": {
-                "totalPerType": {
-                    "type": "object"
+                "student": {
+                    "$ref": "#/definitions/model.Student"
                 },
-                "totalPerPeriod": {
-                    "type": "object"
+                "submitted_at": {
+                    "type": "string"
                 },
-                "distribution": {
-                    "type": "object"
+                "title": {
+                    "type": "string"
                 },
-                "topStudents": {
+                "verification_hints": {
+                    "description": "nomor sertifikat \u0026 URL verifikasi dari attachment",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.TopStudent"
+                        "$ref": "#/definitions/model.VerificationHint"
                     }
-                }
-            }
-        },
-        "model.StudentAchievementStatistics": {
-            "type": "object",
-            "properties": {
-                "perType": {
-                    "type": "object"
-                },
-                "perPeriod": {
-                    "type": "object"
                 },
-                "distribution": {
-                    "type": "object"
+                "version": {
+                    "type": "integer"
                 },
-                "totalAchievements": {
+                "waiting_hours": {
                     "type": "integer"
                 }
             }
         },
-        "model.TopStudent": {
+        "receipt.JWK": {
             "type": "object",
             "properties": {
-                "studentID": {
+                "alg": {
                     "type": "string"
                 },
-                "fullName": {
+                "crv": {
                     "type": "string"
                 },
-                "points": {
-                    "type": "number"
+                "kid": {
+                    "type": "string"
                 },
-                "count": {
-                    "type": "integer"
+                "kty": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
                 }
             }
         },
-        "model.Notification": {
+        "service.CreateUserReq": {
             "type": "object",
             "properties": {
-                "type": {
+                "email": {
                     "type": "string"
                 },
-                "title": {
+                "full_name": {
                     "type": "string"
                 },
-                "message": {
+                "password": {
+                    "description": "Plain password for input",
                     "type": "string"
                 },
-                "read": {
-                    "type": "boolean"
+                "role_id": {
+                    "type": "string"
                 },
-                "createdAt": {
+                "username": {
                     "type": "string"
                 }
             }
@@ -1975,8 +5571,8 @@ const docTemplate = `{
     "securityDefinitions": {
         "ApiKeyAuth": {
             "type": "apiKey",
-            "in": "header",
-            "name": "Authorization"
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
@@ -1985,7 +5581,7 @@ const docTemplate = `{
 var SwaggerInfo = &swag.Spec{
 	Version:          "1.0",
 	Host:             "localhost:3000",
-	BasePath:         "/api/v1/",
+	BasePath:         "/api/v1",
 	Schemes:          []string{"http"},
 	Title:            "BACKEND-UAS API",
 	Description:      "API untuk sistem manajemen user, achievement, student, lecturer, dan report",
@@ -1995,4 +5591,4 @@ var SwaggerInfo = &swag.Spec{
 
 func init() {
 	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
-}
\ No newline at end of file
+}
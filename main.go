@@ -16,9 +16,15 @@ import (
 
 	"BACKEND-UAS/config"
 	"BACKEND-UAS/middleware"
+	"BACKEND-UAS/pgmongo/extract"
 	"BACKEND-UAS/pgmongo/jwt"
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/preview"
+	"BACKEND-UAS/pgmongo/receipt"
 	"BACKEND-UAS/pgmongo/repository"
+	"BACKEND-UAS/pgmongo/scanner"
 	"BACKEND-UAS/pgmongo/service"
+	"BACKEND-UAS/pgmongo/storage"
 	"BACKEND-UAS/route"
 
 	// Swagger docs (generated by swag init)
@@ -29,8 +35,11 @@ import (
 // @version 1.0
 // @description API untuk sistem manajemen user, achievement, student, lecturer, dan report
 // @host localhost:3000
-// @BasePath /api/v1/  //
+// @BasePath /api/v1
 // @schemes http
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
 
 func main() {
 	cfg := config.NewConfig()
@@ -47,11 +56,82 @@ func main() {
 	// Achievement repos
 	achievementPgRepo := repository.NewAchievementRepository(cfg.Connection.PostgresDB)
 	achievementMongoRepo := repository.NewAchievementRepositoryMongo(cfg.Connection.MongoClient)
-	achievementSvc := service.NewAchievementService(achievementPgRepo, achievementMongoRepo)
+	if err := achievementMongoRepo.EnsureIndexes(context.Background()); err != nil {
+		log.Printf("⚠️ Failed to ensure Mongo indexes: %v", err)
+	}
+	// edit konten mencocokkan version Mongo secara persis, jadi dokumen lama harus sudah punya version
+	if n, err := achievementMongoRepo.BackfillVersions(context.Background()); err != nil {
+		log.Fatalf("❌ Failed to backfill achievement versions: %v", err)
+	} else if n > 0 {
+		log.Printf("✅ Backfilled version on %d achievements", n)
+	}
+	if n, err := achievementMongoRepo.BackfillEventFields(context.Background()); err != nil {
+		log.Printf("⚠️ Failed to backfill achievement event fields: %v", err)
+	} else if n > 0 {
+		log.Printf("✅ Backfilled event fields on %d achievements", n)
+	}
+	tagRepo := repository.NewTagRepository(cfg.Connection.PostgresDB)
+	tagSvc := service.NewTagService(tagRepo)
+	attachmentStore, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ Failed to init attachment storage: %v", err)
+	}
+	avScanner, err := scanner.New(cfg.Scanner)
+	if err != nil {
+		log.Fatalf("❌ Failed to init antivirus scanner: %v", err)
+	}
+	previewRenderer, err := preview.New(cfg.Preview)
+	if err != nil {
+		log.Fatalf("❌ Failed to init attachment preview renderer: %v", err)
+	}
+	metadataExtractor, err := extract.New(cfg.Extract, previewRenderer)
+	if err != nil {
+		log.Fatalf("❌ Failed to init attachment metadata extractor: %v", err)
+	}
+	receiptSeed := cfg.ReceiptSigningKey
+	if len(receiptSeed) == 0 {
+		var created bool
+		receiptSeed, created, err = receipt.LoadOrCreateSeed(cfg.ReceiptSigningKeyFile)
+		if err != nil {
+			log.Fatalf("❌ Failed to load verification receipt signing key: %v", err)
+		}
+		if created {
+			log.Printf("🔑 Generated verification receipt signing key at %s (set RECEIPT_SIGNING_KEY to share it across instances)", cfg.ReceiptSigningKeyFile)
+		}
+	}
+	receiptSigner, err := receipt.NewSigner(receiptSeed, cfg.ReceiptRetiredKeys...)
+	if err != nil {
+		log.Fatalf("❌ Failed to init verification receipt signer: %v", err)
+	}
+	achievementSvc := service.NewAchievementService(achievementPgRepo, achievementMongoRepo, tagRepo, attachmentStore, avScanner, previewRenderer, metadataExtractor, receiptSigner, service.AchievementConfig{
+		ReviewSLA:        cfg.ReviewSLA,
+		ReviewClaimTTL:   cfg.ReviewClaimTTL,
+		TeamPointsPolicy: cfg.TeamPointsPolicy,
+
+		DraftReminderAfter: cfg.DraftReminderAfter,
+		DraftAbandonAfter:  cfg.DraftAbandonAfter,
+
+		AttachmentLinkTTL: cfg.AttachmentLinkTTL,
+		AttachmentMaxSize: map[string]int64{
+			model.MIMETypePDF:  cfg.AttachmentMaxPDF,
+			model.MIMETypeJPEG: cfg.AttachmentMaxImage,
+			model.MIMETypePNG:  cfg.AttachmentMaxImage,
+			model.MIMETypeMP4:  cfg.AttachmentMaxVideo,
+		},
+		MaxAttachments:    cfg.MaxAttachments,
+		AttachmentGCGrace: cfg.AttachmentGCGrace,
+		UploadChunkMax:    cfg.UploadChunkMax,
+		UploadExpiry:      cfg.UploadExpiry,
+	})
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	achievementSvc.StartDraftCleanup(jobsCtx, cfg.DraftCleanupInterval)
+	achievementSvc.StartAttachmentScanner(jobsCtx, cfg.ScanInterval)
+	achievementSvc.StartAttachmentGC(jobsCtx, cfg.AttachmentGCInterval, cfg.AttachmentGCRepair)
+	achievementSvc.StartUploadCleanup(jobsCtx, time.Hour)
 
 	// Student repos and services
 	studentRepo := repository.NewStudentRepository(cfg.Connection.PostgresDB)
-	studentSvc := service.NewStudentService(studentRepo, achievementPgRepo)
+	studentSvc := service.NewStudentService(studentRepo, achievementPgRepo, achievementMongoRepo)
 
 	// Lecturer repos and services
 	lecturerRepo := repository.NewLecturerRepository(cfg.Connection.PostgresDB)
@@ -59,19 +139,26 @@ func main() {
 
 	// Report repos and services
 	reportRepo := repository.NewReportRepository(cfg.Connection.PostgresDB, achievementMongoRepo)
-	reportSvc := service.NewReportService(reportRepo, studentRepo, lecturerRepo)
+	periodRepo := repository.NewAcademicPeriodRepository(cfg.Connection.PostgresDB)
+	periodSvc := service.NewAcademicPeriodService(periodRepo)
+	reportSvc := service.NewReportService(reportRepo, studentRepo, lecturerRepo, periodRepo)
 
 	// ============================
 	// INIT FIBER APP
 	// ============================
 	app := fiber.New(fiber.Config{
+		// body multipart harus muat attachment terbesar (video lewat resumable upload per chunk);
+		// batas per jenis file dicek di service
+		BodyLimit: int(max(cfg.AttachmentMaxPDF, cfg.AttachmentMaxImage, cfg.UploadChunkMax)) + 1<<20,
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			return c.Status(500).JSON(fiber.Map{"status": "error", "message": err.Error()})
 		},
 	})
 
 	// CORS middleware (buat Postman/browser)
-	app.Use(cors.New())
+	app.Use(cors.New(cors.Config{
+		ExposeHeaders: "ETag, Location, Tus-Resumable, Tus-Version, Upload-Offset, Upload-Length, Upload-Expires",
+	}))
 
 	// Routes
 	route.AuthRoute(app, authSvc, authMiddleware)
@@ -80,6 +167,8 @@ func main() {
 	route.SetupStudentRoutes(app, studentSvc, authMiddleware)   // Pass authMiddleware for student routes
 	route.SetupLecturerRoutes(app, lecturerSvc, authMiddleware) // Pass authMiddleware for lecturer routes
 	route.SetupReportRoutes(app, reportSvc, authMiddleware)
+	route.SetupTagRoutes(app, tagSvc, authMiddleware)
+	route.SetupAcademicPeriodRoutes(app, periodSvc, authMiddleware)
 
 	// Health check
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -103,6 +192,7 @@ func main() {
 	go func() {
 		<-quit
 		log.Println("🛑 Shutting down server...")
+		stopJobs()
 
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
@@ -0,0 +1,421 @@
+// File: BACKEND-UAS/pgmongo/extract/builtin.go
+package extract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+// Builtin adalah extractor pure-Go yang berjalan offline: teks diambil dari operator teks di content stream PDF
+// (tanpa filter atau /FlateDecode) dan link dari anotasi /URI. Gambar dan PDF hasil scan tidak punya text layer
+// dan tidak ada decoder QR di standard library; pakai driver zbar untuk membaca kode QR.
+type Builtin struct{}
+
+var _ Extractor = Builtin{}
+
+// Batas untuk decompression bomb: maxStream per stream, maxDecoded untuk total hasil dekompresi satu file dan
+// maxStreams untuk jumlah stream yang dibaca (banyak stream kecil yang masing-masing di bawah maxStream)
+const (
+	maxStream  = 4 << 20
+	maxDecoded = 16 << 20
+	maxStreams = 1000
+)
+
+func (Builtin) Extract(ctx context.Context, data []byte, mimeType string) (*Result, error) {
+	if mimeType != "application/pdf" {
+		return nil, ErrUnsupported
+	}
+	w := &textWriter{}
+	links := pdfLinks(data)
+	budget := maxDecoded
+	for _, stream := range pdfStreams(data, maxStreams) {
+		decoded, ok := stream.decode(min(maxStream, budget))
+		if !ok {
+			continue
+		}
+		budget -= len(decoded)
+		// object stream (PDF 1.5) bisa berisi anotasi link yang terkompresi
+		links = append(links, pdfLinks(decoded)...)
+		if bytes.Contains(decoded, []byte("BT")) {
+			contentText(decoded, w)
+		}
+		if w.full() || budget <= 0 {
+			break
+		}
+	}
+	return &Result{Text: w.String(), Links: dedupe(links)}, nil
+}
+
+type pdfStream struct {
+	dict []byte
+	data []byte
+}
+
+// pdfStreams mengembalikan paling banyak limit stream beserta dictionary-nya sesuai urutan di file
+func pdfStreams(data []byte, limit int) []pdfStream {
+	var streams []pdfStream
+	for pos := 0; len(streams) < limit; {
+		i := bytes.Index(data[pos:], []byte("stream"))
+		if i < 0 {
+			return streams
+		}
+		i += pos
+		pos = i + len("stream")
+		// "endstream" juga mengandung kata stream
+		if bytes.HasSuffix(data[:i], []byte("end")) {
+			continue
+		}
+		before := bytes.TrimRight(data[:i], " \t\r\n")
+		if !bytes.HasSuffix(before, []byte(">>")) {
+			continue
+		}
+		objStart := bytes.LastIndex(before, []byte("obj"))
+		if objStart < 0 {
+			continue
+		}
+		// keyword stream diikuti CRLF atau LF
+		body := bytes.TrimPrefix(data[pos:], []byte("\r"))
+		body = bytes.TrimPrefix(body, []byte("\n"))
+		end := bytes.Index(body, []byte("endstream"))
+		if end < 0 {
+			return streams
+		}
+		streams = append(streams, pdfStream{dict: before[objStart:], data: body[:end]})
+		pos = len(data) - len(body) + end + len("endstream")
+	}
+	return streams
+}
+
+// decode mengembalikan isi stream yang bisa dibaca sebagai teks, paling banyak limit byte; gambar dan filter
+// selain Flate dilewati
+func (s pdfStream) decode(limit int) ([]byte, bool) {
+	if bytes.Contains(s.dict, []byte("/Image")) {
+		return nil, false
+	}
+	if !bytes.Contains(s.dict, []byte("/Filter")) {
+		return s.data[:min(len(s.data), limit)], true
+	}
+	filters := bytes.Count(s.dict, []byte("Decode"))
+	if filters != 1 || !bytes.Contains(s.dict, []byte("/FlateDecode")) {
+		return nil, false
+	}
+	r, err := zlib.NewReader(bytes.NewReader(s.data))
+	if err != nil {
+		return nil, false
+	}
+	defer r.Close()
+	// stream yang terpotong tetap dipakai sebagian
+	out, err := io.ReadAll(io.LimitReader(r, int64(limit)))
+	if err != nil && len(out) == 0 {
+		return nil, false
+	}
+	return out, true
+}
+
+// pdfLinks mengambil URI http(s) dari action /URI
+func pdfLinks(data []byte) []string {
+	var links []string
+	for rest := data; ; {
+		i := bytes.Index(rest, []byte("/URI"))
+		if i < 0 {
+			return links
+		}
+		rest = bytes.TrimLeft(rest[i+len("/URI"):], " \t\r\n")
+		var raw []byte
+		switch {
+		case bytes.HasPrefix(rest, []byte("(")):
+			raw, rest = parseLiteral(rest)
+		case bytes.HasPrefix(rest, []byte("<")) && !bytes.HasPrefix(rest, []byte("<<")):
+			raw, rest = parseHex(rest)
+		default:
+			continue
+		}
+		link := strings.TrimSpace(decodeText(raw))
+		if strings.HasPrefix(link, "http://") || strings.HasPrefix(link, "https://") {
+			links = append(links, link)
+		}
+	}
+}
+
+type operand struct {
+	str   string
+	num   float64
+	isNum bool
+}
+
+// contentText mengambil string dari operator teks (Tj, TJ, ', ") di dalam blok BT..ET; perpindahan baris
+// (Td/TD dengan ty != 0, T*, ', ") menjadi baris baru
+func contentText(content []byte, w *textWriter) {
+	var operands []operand
+	inText := false
+	for i := 0; i < len(content) && !w.full(); {
+		c := content[i]
+		switch {
+		case isSpace(c):
+			i++
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case c == '(':
+			raw, rest := parseLiteral(content[i:])
+			operands = append(operands, operand{str: decodeText(raw)})
+			i = len(content) - len(rest)
+		case c == '<' && i+1 < len(content) && content[i+1] == '<', c == '>' && i+1 < len(content) && content[i+1] == '>':
+			i += 2
+		case c == '<':
+			raw, rest := parseHex(content[i:])
+			operands = append(operands, operand{str: decodeText(raw)})
+			i = len(content) - len(rest)
+		case c == '[' || c == ']' || c == '{' || c == '}' || c == '>':
+			i++
+		case c == '/':
+			i++
+			for i < len(content) && isRegular(content[i]) {
+				i++
+			}
+		case c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9'):
+			start := i
+			for i++; i < len(content) && isRegular(content[i]); i++ {
+			}
+			n, _ := strconv.ParseFloat(string(content[start:i]), 64)
+			operands = append(operands, operand{num: n, isNum: true})
+		default:
+			start := i
+			for i++; i < len(content) && isRegular(content[i]) && content[i] != '\'' && content[i] != '"'; i++ {
+			}
+			op := string(content[start:i])
+			if op == "BI" {
+				// inline image: data biner sampai EI
+				end := bytes.Index(content[i:], []byte("EI"))
+				if end < 0 {
+					return
+				}
+				i += end + len("EI")
+			}
+			inText = textOperator(op, operands, inText, w)
+			operands = operands[:0]
+		}
+	}
+}
+
+func textOperator(op string, operands []operand, inText bool, w *textWriter) bool {
+	switch op {
+	case "BT":
+		return true
+	case "ET":
+		w.newline()
+		return false
+	}
+	if !inText {
+		return inText
+	}
+	switch op {
+	case "Td", "TD":
+		if len(operands) >= 2 && operands[len(operands)-1].num != 0 {
+			w.newline()
+		} else {
+			w.space()
+		}
+	case "T*":
+		w.newline()
+	case "Tm":
+		w.space()
+	case "'", "\"":
+		w.newline()
+		fallthrough
+	case "Tj", "TJ":
+		for _, o := range operands {
+			switch {
+			case !o.isNum:
+				w.write(o.str)
+			case op == "TJ" && o.num < -200:
+				// kerning besar di dalam TJ dipakai sebagai spasi antar kata
+				w.space()
+			}
+		}
+	}
+	return inText
+}
+
+// parseLiteral membaca string literal "( ... )" (kurung bersarang dan escape); mengembalikan isi dan sisa data
+func parseLiteral(data []byte) ([]byte, []byte) {
+	var out []byte
+	depth := 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case c == '(':
+			depth++
+			if depth == 1 {
+				continue
+			}
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return out, data[i+1:]
+			}
+		case c == '\\' && i+1 < len(data):
+			i++
+			switch e := data[i]; e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// line continuation
+				if e == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+					i++
+				}
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := 0
+					for n := 0; n < 3 && i < len(data) && data[i] >= '0' && data[i] <= '7'; n++ {
+						v = v*8 + int(data[i]-'0')
+						i++
+					}
+					i--
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// parseHex membaca string hex "<...>"; digit terakhir yang ganjil dianggap diikuti 0
+func parseHex(data []byte) ([]byte, []byte) {
+	end := bytes.IndexByte(data, '>')
+	if end < 0 {
+		return nil, nil
+	}
+	var digits []byte
+	for _, c := range data[1:end] {
+		if isHexDigit(c) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	for i := range out {
+		v, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		out[i] = byte(v)
+	}
+	return out, data[end+1:]
+}
+
+// decodeText mengubah string PDF menjadi teks: UTF-16BE dengan BOM, 2 byte per karakter dengan byte tinggi nol
+// (font CID berisi teks ASCII), selain itu PDFDocEncoding/Latin-1. Karakter yang tidak bisa dicetak dibuang.
+func decodeText(raw []byte) string {
+	var runes []rune
+	switch {
+	case len(raw) >= 2 && raw[0] == 0xFE && raw[1] == 0xFF:
+		units := make([]uint16, 0, len(raw)/2)
+		for i := 2; i+1 < len(raw); i += 2 {
+			units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+		}
+		runes = utf16.Decode(units)
+	case len(raw) >= 2 && len(raw)%2 == 0 && highBytesZero(raw):
+		for i := 1; i < len(raw); i += 2 {
+			runes = append(runes, rune(raw[i]))
+		}
+	default:
+		for _, b := range raw {
+			runes = append(runes, rune(b))
+		}
+	}
+	var sb strings.Builder
+	for _, r := range runes {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			sb.WriteByte(' ')
+		case unicode.IsPrint(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func highBytesZero(raw []byte) bool {
+	for i := 0; i < len(raw); i += 2 {
+		if raw[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// textWriter menyusun teks per baris (spasi dirapatkan, baris kosong dibuang) sampai MaxText byte
+type textWriter struct {
+	sb   strings.Builder
+	line strings.Builder
+}
+
+func (w *textWriter) write(s string) { w.line.WriteString(s) }
+func (w *textWriter) space()         { w.line.WriteByte(' ') }
+
+func (w *textWriter) newline() {
+	line := strings.Join(strings.Fields(w.line.String()), " ")
+	w.line.Reset()
+	if line == "" || w.full() {
+		return
+	}
+	if w.sb.Len() > 0 {
+		w.sb.WriteByte('\n')
+	}
+	w.sb.WriteString(line)
+}
+
+func (w *textWriter) full() bool { return w.sb.Len() >= MaxText }
+
+func (w *textWriter) String() string {
+	w.newline()
+	text := w.sb.String()
+	if len(text) > MaxText {
+		text = strings.ToValidUTF8(text[:MaxText], "")
+	}
+	return text
+}
+
+func dedupe(values []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+// isRegular: bukan whitespace dan bukan delimiter PDF
+func isRegular(c byte) bool {
+	return !isSpace(c) && !strings.ContainsRune("()<>[]{}/%", rune(c))
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
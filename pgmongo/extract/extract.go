@@ -0,0 +1,51 @@
+// File: BACKEND-UAS/pgmongo/extract/extract.go
+package extract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"BACKEND-UAS/pgmongo/preview"
+)
+
+// ErrUnsupported dikembalikan extractor saat jenis file tidak bisa dibaca teks maupun QR-nya
+var ErrUnsupported = errors.New("extract: unsupported content")
+
+// Result adalah teks dan payload kode QR yang ditemukan di satu file
+type Result struct {
+	Text    string   // text layer PDF (kosong untuk gambar dan PDF hasil scan)
+	Links   []string // URI dari anotasi link PDF
+	QRCodes []string // payload QR/barcode apa adanya
+}
+
+// Extractor membaca teks dan kode QR dari isi attachment untuk petunjuk verifikasi sertifikat
+type Extractor interface {
+	Extract(ctx context.Context, data []byte, mimeType string) (*Result, error)
+}
+
+// MaxText membatasi jumlah teks (byte) yang diambil dari satu file
+const MaxText = 64 << 10
+
+// Config memilih extractor; Driver "builtin" (default, teks PDF saja), "zbar" (teks PDF + QR lewat zbarimg)
+// atau "none" untuk mematikan ekstraksi
+type Config struct {
+	Driver      string
+	ZbarimgPath string // default: dicari di PATH
+}
+
+// New membuat extractor sesuai cfg.Driver; renderer dipakai zbar untuk merender halaman pertama PDF sebelum
+// mencari QR. "none" mengembalikan nil (ekstraksi dimatikan).
+func New(cfg Config, renderer preview.Renderer) (Extractor, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "builtin":
+		return Builtin{}, nil
+	case "zbar":
+		return NewZbar(cfg.ZbarimgPath, renderer)
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("extract: unknown extractor %q", cfg.Driver)
+	}
+}
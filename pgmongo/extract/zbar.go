@@ -0,0 +1,111 @@
+// File: BACKEND-UAS/pgmongo/extract/zbar.go
+package extract
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"BACKEND-UAS/pgmongo/preview"
+)
+
+// Zbar membaca kode QR/barcode dengan zbarimg (zbar-tools) sebagai proses terpisah, di atas teks dari Builtin.
+// Gambar dikirim apa adanya; PDF dirender dulu halaman pertamanya dengan renderer preview (tanpa renderer,
+// PDF hanya diambil teksnya).
+type Zbar struct {
+	path     string
+	renderer preview.Renderer
+	timeout  time.Duration
+}
+
+var _ Extractor = (*Zbar)(nil)
+
+func NewZbar(path string, renderer preview.Renderer) (*Zbar, error) {
+	if path == "" {
+		path = "zbarimg"
+	}
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("extract: %w", err)
+	}
+	return &Zbar{path: resolved, renderer: renderer, timeout: 30 * time.Second}, nil
+}
+
+func (z *Zbar) Extract(ctx context.Context, data []byte, mimeType string) (*Result, error) {
+	res, err := Builtin{}.Extract(ctx, data, mimeType)
+	if errors.Is(err, ErrUnsupported) {
+		res, err = &Result{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	img, ext := data, ".jpg"
+	switch mimeType {
+	case "image/jpeg":
+	case "image/png":
+		ext = ".png"
+	case "application/pdf":
+		if z.renderer == nil {
+			return res, nil
+		}
+		page, err := z.renderer.Render(ctx, data, mimeType)
+		if errors.Is(err, preview.ErrUnsupported) {
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, page); err != nil {
+			return nil, err
+		}
+		img, ext = buf.Bytes(), ".png"
+	default:
+		return nil, ErrUnsupported
+	}
+	if res.QRCodes, err = z.scan(ctx, img, ext); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// scan menjalankan zbarimg pada file gambar sementara; exit status 4 berarti tidak ada kode yang ditemukan
+func (z *Zbar) scan(ctx context.Context, img []byte, ext string) ([]string, error) {
+	dir, err := os.MkdirTemp("", "extract-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, "in"+ext)
+	if err := os.WriteFile(in, img, 0600); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, z.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, z.path, "--quiet", "--raw", in)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 4 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("extract: zbarimg: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	var codes []string
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			codes = append(codes, line)
+		}
+	}
+	return dedupe(codes), nil
+}
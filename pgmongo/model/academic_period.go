@@ -0,0 +1,173 @@
+// File: BACKEND-UAS/pgmongo/model/academic_period.go
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Semester dalam satu tahun akademik
+const (
+	SemesterGanjil = "ganjil"
+	SemesterGenap  = "genap"
+	SemesterPendek = "pendek"
+)
+
+const academicDateLayout = "2006-01-02"
+
+var academicYearPattern = regexp.MustCompile(`^(\d{4})/(\d{4})$`)
+
+// AcademicPeriod adalah satu semester pada kalender akademik; EndDate inklusif
+type AcademicPeriod struct {
+	ID           uuid.UUID `json:"id"`
+	Code         string    `json:"code"` // contoh: "2024/2025-ganjil"
+	AcademicYear string    `json:"academic_year"`
+	Semester     string    `json:"semester"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AcademicPeriodRequest is the body for creating a period; dates are YYYY-MM-DD
+type AcademicPeriodRequest struct {
+	AcademicYear string `json:"academic_year"`
+	Semester     string `json:"semester"`
+	StartDate    string `json:"start_date"`
+	EndDate      string `json:"end_date"`
+}
+
+// PeriodCode builds the code of a semester, e.g. "2024/2025-genap"
+func PeriodCode(academicYear, semester string) string {
+	return academicYear + "-" + semester
+}
+
+// ToPeriod validates the request and converts it into a period (tanpa ID)
+func (r AcademicPeriodRequest) ToPeriod() (*AcademicPeriod, error) {
+	year := strings.TrimSpace(r.AcademicYear)
+	m := academicYearPattern.FindStringSubmatch(year)
+	if m == nil {
+		return nil, fmt.Errorf("academic_year must look like 2024/2025")
+	}
+	var first, second int
+	fmt.Sscan(m[1], &first)
+	fmt.Sscan(m[2], &second)
+	if second != first+1 {
+		return nil, fmt.Errorf("academic_year must span two consecutive years")
+	}
+	semester := strings.ToLower(strings.TrimSpace(r.Semester))
+	switch semester {
+	case SemesterGanjil, SemesterGenap, SemesterPendek:
+	default:
+		return nil, fmt.Errorf("semester must be one of ganjil, genap, pendek")
+	}
+	start, err := time.Parse(academicDateLayout, r.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start_date")
+	}
+	end, err := time.Parse(academicDateLayout, r.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end_date")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end_date must not be before start_date")
+	}
+	return &AcademicPeriod{
+		Code:         PeriodCode(year, semester),
+		AcademicYear: year,
+		Semester:     semester,
+		StartDate:    start,
+		EndDate:      end,
+	}, nil
+}
+
+// end returns the exclusive upper bound (awal hari setelah EndDate)
+func (p *AcademicPeriod) end() time.Time {
+	return p.EndDate.AddDate(0, 0, 1)
+}
+
+func (p *AcademicPeriod) Contains(t time.Time) bool {
+	return !t.Before(p.StartDate) && t.Before(p.end())
+}
+
+// Overlaps reports whether the two periods share at least one day
+func (p *AcademicPeriod) Overlaps(other *AcademicPeriod) bool {
+	return p.StartDate.Before(other.end()) && other.StartDate.Before(p.end())
+}
+
+// AcademicCalendar mengindeks periode akademik untuk memetakan tanggal ke semester
+type AcademicCalendar struct {
+	periods []AcademicPeriod // urut StartDate
+}
+
+func NewAcademicCalendar(periods []AcademicPeriod) *AcademicCalendar {
+	sorted := append([]AcademicPeriod(nil), periods...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartDate.Before(sorted[j].StartDate) })
+	return &AcademicCalendar{periods: sorted}
+}
+
+func (c *AcademicCalendar) Periods() []AcademicPeriod {
+	if c == nil {
+		return nil
+	}
+	return c.periods
+}
+
+// PeriodFor returns the period containing t, or nil if t falls outside the calendar
+func (c *AcademicCalendar) PeriodFor(t time.Time) *AcademicPeriod {
+	for i := range c.Periods() {
+		if c.periods[i].Contains(t) {
+			return &c.periods[i]
+		}
+	}
+	return nil
+}
+
+// Range menerjemahkan kode periode ("2024/2025-ganjil") atau tahun akademik ("2024/2025", seluruh semesternya)
+// menjadi rentang [from, to). ok false jika tidak ada periode yang cocok.
+func (c *AcademicCalendar) Range(code string) (from, to time.Time, ok bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for i := range c.Periods() {
+		p := &c.periods[i]
+		if p.Code != code && p.AcademicYear != code {
+			continue
+		}
+		if !ok || p.StartDate.Before(from) {
+			from = p.StartDate
+		}
+		if !ok || p.end().After(to) {
+			to = p.end()
+		}
+		ok = true
+	}
+	return from, to, ok
+}
+
+// ReportFilter membatasi statistik ke rentang tanggal kejadian [From, To) dan menentukan kunci per periode
+type ReportFilter struct {
+	From     *time.Time
+	To       *time.Time
+	Calendar *AcademicCalendar
+}
+
+func (f ReportFilter) Contains(t time.Time) bool {
+	if f.From != nil && t.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !t.Before(*f.To) {
+		return false
+	}
+	return true
+}
+
+// PeriodKey returns the academic period code for t, falling back to the month (2006-01) outside the calendar
+func (f ReportFilter) PeriodKey(t time.Time) string {
+	if p := f.Calendar.PeriodFor(t); p != nil {
+		return p.Code
+	}
+	return t.Format("2006-01")
+}
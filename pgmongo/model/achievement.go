@@ -23,15 +23,36 @@ type Achievement struct {
 	UpdatedAt      time.Time          `bson:"updatedAt" json:"updatedAt"`
 	DeletedAt      *time.Time         `bson:"deletedAt,omitempty" json:"deletedAt"`
 	Level          string             `bson:"level,omitempty" json:"level"` // Added for competition level distribution
+	Version        int64              `bson:"version" json:"version"`         // optimistic concurrency counter dokumen
+	Team           *Team              `bson:"team,omitempty" json:"team,omitempty"` // nil untuk prestasi individu
+	TitleKey       string             `bson:"titleKey,omitempty" json:"-"`           // judul ternormalisasi untuk deteksi duplikat
+
+	// Data kejadian & verifikasi eksternal; data lama mungkin masih menyimpannya di details
+	EventAt           *time.Time `bson:"eventDate,omitempty" json:"eventDate,omitempty"`
+	Organizer         string     `bson:"organizer,omitempty" json:"organizer,omitempty"`
+	Location          string     `bson:"location,omitempty" json:"location,omitempty"`
+	CertificateNumber string     `bson:"certificateNumber,omitempty" json:"certificateNumber,omitempty"`
+	CertificateKey    string     `bson:"certificateKey,omitempty" json:"-"` // nomor sertifikat ternormalisasi untuk deteksi duplikat
+	VerificationURL   string     `bson:"verificationUrl,omitempty" json:"verificationUrl,omitempty"`
 
 	StatusHistory []StatusHistory `bson:"statusHistory" json:"statusHistory"`
 }
 
 type Attachment struct {
+	ID         string    `bson:"id,omitempty" json:"id,omitempty"` // tetap sama walau file diganti; kosong untuk upload lama
 	FileName   string    `bson:"fileName" json:"fileName"`
 	FileURL    string    `bson:"fileUrl" json:"fileUrl"`
 	FileType   string    `bson:"fileType" json:"fileType"`
 	UploadedAt time.Time `bson:"uploadedAt" json:"uploadedAt"`
+	ContentHash string   `bson:"contentHash,omitempty" json:"contentHash,omitempty"` // sha256 isi file
+	Size        int64    `bson:"size,omitempty" json:"size,omitempty"`
+	StorageKey  string   `bson:"storageKey,omitempty" json:"-"` // key di storage backend; kosong untuk upload lama
+	ScanStatus  string     `bson:"scanStatus,omitempty" json:"scanStatus,omitempty"` // kosong (upload lama) diperlakukan sebagai pending_scan
+	ScanResult  string     `bson:"scanResult,omitempty" json:"scanResult,omitempty"` // nama signature jika terinfeksi, atau keterangan
+	ScannedAt   *time.Time `bson:"scannedAt,omitempty" json:"scannedAt,omitempty"`
+	ThumbnailKey string    `bson:"thumbnailKey,omitempty" json:"-"` // JPEG kecil untuk daftar/antrian; kosong jika tidak bisa dirender
+	PreviewKey   string    `bson:"previewKey,omitempty" json:"-"`   // JPEG halaman pertama untuk halaman detail
+	Metadata     *AttachmentMetadata `bson:"metadata,omitempty" json:"metadata,omitempty"` // teks & QR hasil ekstraksi saat upload
 }
 
 type StatusHistory struct {
@@ -40,6 +61,14 @@ type StatusHistory struct {
 	ChangedBy    *uuid.UUID `bson:"changedBy,omitempty" json:"changedBy"`
 	ChangedAt    time.Time  `bson:"changedAt" json:"changedAt"`
 	Note         string     `bson:"note" json:"note"`
+	Changes      []FieldChange `bson:"changes,omitempty" json:"changes,omitempty"`
+}
+
+// FieldChange mencatat perubahan satu field (path dipisah titik, mis. "details.rank") pada edit konten
+type FieldChange struct {
+	Field string      `bson:"field" json:"field"`
+	Old   interface{} `bson:"old" json:"old"`
+	New   interface{} `bson:"new" json:"new"`
 }
 
 type Notification struct {
@@ -53,10 +82,13 @@ type Notification struct {
 
 // AchievementStatistics for global reports
 type AchievementStatistics struct {
-	TotalPerType   map[string]int64    `json:"total_per_type"`
-	TotalPerPeriod map[string]int64    `json:"total_per_period"`
-	TopStudents    []TopStudent        `json:"top_students"`
-	Distribution   map[string]int64    `json:"distribution"`
+	TotalPerType    map[string]int64 `json:"total_per_type"`
+	TotalPerPeriod  map[string]int64 `json:"total_per_period"`
+	TopStudents     []TopStudent     `json:"top_students"`
+	Distribution    map[string]int64 `json:"distribution"`
+	TotalRevoked    int64            `json:"total_revoked"` // verifikasi yang dicabut, tidak ikut dihitung di atas
+	TagCounts       map[string]int64 `json:"tag_counts"`
+	OrganizerCounts map[string]int64 `json:"organizer_counts"` // per penyelenggara (field organizer atau details)
 }
 
 type TopStudent struct {
@@ -72,4 +104,7 @@ type StudentAchievementStatistics struct {
 	PerType           map[string]int64 `json:"per_type"`
 	PerPeriod         map[string]int64 `json:"per_period"`
 	Distribution      map[string]int64 `json:"distribution"`
+	TotalRevoked      int64            `json:"total_revoked"`
+	TagCounts         map[string]int64 `json:"tag_counts"`
+	OrganizerCounts   map[string]int64 `json:"organizer_counts"`
 }
\ No newline at end of file
@@ -14,6 +14,10 @@ type AchievementDetailResponse struct {
     VerifiedAt    *time.Time      `json:"verified_at,omitempty"`
     VerifiedBy    *uuid.UUID      `json:"verified_by,omitempty"`
     RejectionNote string          `json:"rejection_note,omitempty"`
+    Version       int64           `json:"version"`
     Achievement   Achievement      `json:"achievement"`
     StatusHistory []StatusHistory `json:"statusHistory"`
+    Previews      []AttachmentPreview `json:"previews,omitempty"` // hanya untuk yang boleh melihat attachment
+    Hints         []VerificationHint  `json:"verification_hints,omitempty"` // hanya untuk reviewer (dosen wali/admin)
+    Receipt       string              `json:"verification_receipt,omitempty"` // JWS receipt verifikasi, bisa dicek di /verification-receipts/verify
 }
\ No newline at end of file
@@ -0,0 +1,25 @@
+// File: BACKEND-UAS/pgmongo/model/achievement_draft.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AchievementAutosave adalah revisi draft yang sedang dikerjakan. Disimpan terpisah dari dokumen utama
+// sehingga version (ETag), status history dan revisi tidak berubah sampai mahasiswa menyimpan lewat PUT/PATCH.
+type AchievementAutosave struct {
+	MongoAchievementID string      `bson:"_id" json:"-"`
+	AchievementID      uuid.UUID   `bson:"achievementId" json:"achievement_id"`
+	BaseVersion        int64       `bson:"baseVersion" json:"base_version"` // version saat autosave; berbeda dari ETag sekarang berarti basi
+	Content            Achievement `bson:"content" json:"content"`
+	SavedBy            uuid.UUID   `bson:"savedBy" json:"saved_by"`
+	SavedAt            time.Time   `bson:"savedAt" json:"saved_at"`
+}
+
+// DraftCleanupResult is the outcome of one run of the abandoned-draft job
+type DraftCleanupResult struct {
+	Reminded int `json:"reminded"`
+	Deleted  int `json:"deleted"`
+}
@@ -0,0 +1,90 @@
+// File: BACKEND-UAS/pgmongo/model/achievement_event.go
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Jenis prestasi
+const (
+	AchievementTypeAcademic      = "academic"
+	AchievementTypeCompetition   = "competition"
+	AchievementTypeOrganization  = "organization"
+	AchievementTypePublication   = "publication"
+	AchievementTypeCertification = "certification"
+	AchievementTypeOther         = "other"
+)
+
+// requiredEventFields: field yang wajib terisi sebelum submit, per jenis prestasi (nama field mengikuti JSON)
+var requiredEventFields = map[string][]string{
+	AchievementTypeCompetition:   {"eventDate", "organizer"},
+	AchievementTypePublication:   {"eventDate"},
+	AchievementTypeCertification: {"eventDate", "organizer", "certificateNumber"},
+	AchievementTypeOrganization:  {"organizer"},
+}
+
+// eventDateFutureTolerance memberi kelonggaran zona waktu untuk event yang terjadi "hari ini"
+const eventDateFutureTolerance = 24 * time.Hour
+
+// NormalizeEventFields trims the free-text event fields
+func (a *Achievement) NormalizeEventFields() {
+	a.Organizer = strings.TrimSpace(a.Organizer)
+	a.Location = strings.TrimSpace(a.Location)
+	a.CertificateNumber = strings.TrimSpace(a.CertificateNumber)
+	a.VerificationURL = strings.TrimSpace(a.VerificationURL)
+}
+
+// ValidateEventFields memeriksa format field kejadian; kelengkapan per jenis dicek saat submit (MissingEventFields)
+func (a *Achievement) ValidateEventFields(now time.Time) error {
+	if a.EventAt != nil && a.EventAt.After(now.Add(eventDateFutureTolerance)) {
+		return fmt.Errorf("eventDate must not be in the future")
+	}
+	if a.VerificationURL != "" {
+		u, err := url.Parse(a.VerificationURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("verificationUrl must be an absolute http(s) URL")
+		}
+	}
+	for name, v := range map[string]string{"organizer": a.Organizer, "location": a.Location, "certificateNumber": a.CertificateNumber} {
+		if len(v) > 200 {
+			return fmt.Errorf("%s must be at most 200 characters", name)
+		}
+	}
+	return nil
+}
+
+// MissingEventFields returns the required fields (per achievement type) that are still empty.
+// Tanggal dan penyelenggara yang masih tersimpan di details (data lama) dianggap terisi.
+func (a *Achievement) MissingEventFields() []string {
+	missing := []string{}
+	for _, field := range requiredEventFields[strings.ToLower(a.AchievementType)] {
+		var filled bool
+		switch field {
+		case "eventDate":
+			filled = a.EventAt != nil || detailValue(a, eventDateDetailKeys) != nil
+		case "organizer":
+			filled = a.EffectiveOrganizer() != ""
+		case "certificateNumber":
+			filled = strings.TrimSpace(a.CertificateNumber) != ""
+		}
+		if !filled {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// PromoteLegacyEventFields menyalin tanggal & penyelenggara dari details ke field baru jika belum diisi
+func (a *Achievement) PromoteLegacyEventFields() {
+	if a.EventAt == nil {
+		if t, ok := parseEventDate(detailValue(a, eventDateDetailKeys)); ok {
+			a.EventAt = &t
+		}
+	}
+	if a.Organizer == "" {
+		a.Organizer = a.EffectiveOrganizer()
+	}
+}
@@ -0,0 +1,112 @@
+// File: BACKEND-UAS/pgmongo/model/achievement_list_filter.go
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sortable fields on the achievement list; kolom Postgres atau field dokumen Mongo
+const (
+	SortCreatedAt   = "created_at"
+	SortSubmittedAt = "submitted_at"
+	SortVerifiedAt  = "verified_at"
+	SortStatus      = "status"
+	SortPoints      = "points"
+	SortTitle       = "title"
+	SortType        = "type"
+	SortLevel       = "level"
+	SortEventDate   = "event_date"
+)
+
+var contentSortFields = map[string]bool{SortPoints: true, SortTitle: true, SortType: true, SortLevel: true, SortEventDate: true}
+
+var referenceSortFields = map[string]bool{SortCreatedAt: true, SortSubmittedAt: true, SortVerifiedAt: true, SortStatus: true}
+
+const maxSortFields = 3
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// IsContentField reports whether the field lives on the Mongo document rather than the reference row
+func (f SortField) IsContentField() bool {
+	return contentSortFields[f.Field]
+}
+
+// ParseSortFields mem-parse "-points,submitted_at" (awalan "-" = descending)
+func ParseSortFields(value string) ([]SortField, error) {
+	fields := []SortField{}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		f := SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+		if !contentSortFields[f.Field] && !referenceSortFields[f.Field] {
+			return nil, fmt.Errorf("unsupported sort field %q", f.Field)
+		}
+		if seen[f.Field] {
+			continue
+		}
+		seen[f.Field] = true
+		fields = append(fields, f)
+	}
+	if len(fields) > maxSortFields {
+		return nil, fmt.Errorf("at most %d sort fields are allowed", maxSortFields)
+	}
+	return fields, nil
+}
+
+// AchievementContentFilter adalah filter sisi Mongo; MongoIDs/StudentIDs nil berarti tidak dibatasi.
+// StudentIDs mencakup prestasi tim yang beranggotakan mahasiswa tersebut; Limit 0 berarti tanpa batas.
+type AchievementContentFilter struct {
+	MongoIDs        []string
+	StudentIDs      []uuid.UUID
+	Limit           int
+	AchievementType string
+	Level           string
+	Tags            []string   // semua tag harus ada (tanpa hierarki)
+	TagGroups       [][]string // setiap grup minimal satu tag harus ada; diisi dari Tags + turunannya
+	MinPoints       *int
+	MaxPoints       *int
+	EventFrom       *time.Time // field eventDate, [EventFrom, EventTo)
+	EventTo         *time.Time
+}
+
+func (f AchievementContentFilter) IsEmpty() bool {
+	return f.AchievementType == "" && f.Level == "" && len(f.Tags) == 0 && len(f.TagGroups) == 0 && f.MinPoints == nil && f.MaxPoints == nil &&
+		f.EventFrom == nil && f.EventTo == nil
+}
+
+// AchievementListKey holds the Mongo fields needed to filter and sort the list without loading whole documents
+type AchievementListKey struct {
+	MongoID         string     `bson:"-"`
+	Title           string     `bson:"title"`
+	AchievementType string     `bson:"achievementType"`
+	Level           string     `bson:"level"`
+	Points          int        `bson:"points"`
+	EventDate       *time.Time `bson:"eventDate,omitempty"` // diisi createdAt jika kosong, sama dengan Achievement.EventDate()
+	CreatedAt       time.Time  `bson:"createdAt"`
+}
+
+// AchievementListFilter is the input of the achievement list endpoint
+type AchievementListFilter struct {
+	ReferenceFilter
+	Content AchievementContentFilter
+	Sort    []SortField
+}
+
+// IsSimple true jika hanya memakai filter status dengan urutan default (jalur query lama)
+func (f AchievementListFilter) IsSimple() bool {
+	r := f.ReferenceFilter
+	return f.Content.IsEmpty() && len(f.Sort) == 0 &&
+		r.StudentID == nil && r.ProgramStudy == "" && r.AcademicYear == "" && r.AdvisorID == nil &&
+		r.CreatedFrom == nil && r.CreatedTo == nil &&
+		r.SubmittedFrom == nil && r.SubmittedTo == nil && r.VerifiedFrom == nil && r.VerifiedTo == nil
+}
@@ -16,9 +16,14 @@ type AchievementReference struct {
 	VerifiedAt       *time.Time     `json:"verified_at" bson:"verified_at"`
 	VerifiedBy       *uuid.UUID     `json:"verified_by" bson:"verified_by"`
 	RejectionNote    string         `json:"rejection_note" bson:"rejection_note"`
+	ClaimedBy        *uuid.UUID     `json:"claimed_by,omitempty" bson:"claimed_by"`
+	ClaimedAt        *time.Time     `json:"claimed_at,omitempty" bson:"claimed_at"`
+	Version          int64          `json:"version" bson:"version"`
+	PrimaryReferenceID *uuid.UUID   `json:"primary_reference_id,omitempty" bson:"primary_reference_id"` // diisi pada reference anggota tim
 	CreatedAt        time.Time      `json:"created_at" bson:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at" bson:"updated_at"`
 
 	Student    Student      `json:"student" bson:"student"`
 	Achievement *Achievement `json:"achievement" bson:"-"`
+	Duplicates  []DuplicateWarning `json:"duplicates,omitempty" bson:"-"` // peringatan duplikat saat create
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+// File: BACKEND-UAS/pgmongo/model/achievement_revision.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Revision events
+const (
+	RevisionCreated   = "created"
+	RevisionUpdated   = "updated"
+	RevisionSubmitted = "submitted"
+)
+
+// AchievementRevision is an immutable snapshot of the achievement document.
+// Revision sama dengan version achievement_references setelah perubahan (juga nilai ETag).
+type AchievementRevision struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"-"`
+	AchievementID      uuid.UUID          `bson:"achievementId" json:"achievement_id"`
+	MongoAchievementID string             `bson:"mongoAchievementId" json:"-"`
+	Revision           int64              `bson:"revision" json:"revision"`
+	Event              string             `bson:"event" json:"event"`
+	Status             string             `bson:"status" json:"status"`
+	CreatedBy          *uuid.UUID         `bson:"createdBy,omitempty" json:"created_by,omitempty"`
+	CreatedAt          time.Time          `bson:"createdAt" json:"created_at"`
+	Snapshot           *Achievement       `bson:"snapshot,omitempty" json:"snapshot,omitempty"`
+}
+
+// RevisionDiff lists the content changes between two revisions
+type RevisionDiff struct {
+	AchievementID uuid.UUID           `json:"achievement_id"`
+	From          AchievementRevision `json:"from"`
+	To            AchievementRevision `json:"to"`
+	Changes       []FieldChange       `json:"changes"`
+}
@@ -0,0 +1,55 @@
+// File: BACKEND-UAS/pgmongo/model/achievement_search.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReferenceFilter adalah filter sisi Postgres untuk achievement_references.
+// Slice nil berarti tidak dibatasi; slice kosong berarti tidak ada yang cocok.
+type ReferenceFilter struct {
+	StudentIDs   []uuid.UUID // visibilitas berdasarkan role
+	MongoIDs     []string
+	Status       *string
+	StudentID    *uuid.UUID
+	ProgramStudy string
+	AcademicYear string
+	AdvisorID    *uuid.UUID
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+
+	SubmittedFrom *time.Time
+	SubmittedTo   *time.Time
+	VerifiedFrom  *time.Time
+	VerifiedTo    *time.Time
+}
+
+// AchievementSearchFilter is the input of the full-text search endpoint
+type AchievementSearchFilter struct {
+	Query string
+	ReferenceFilter
+	EventFrom *time.Time // tanggal kejadian (EventDate), [EventFrom, EventTo)
+	EventTo   *time.Time
+}
+
+// MatchesEventDate reports whether the achievement happened within the event date range
+func (f AchievementSearchFilter) MatchesEventDate(ach *Achievement) bool {
+	d := ach.EventDate()
+	return (f.EventFrom == nil || !d.Before(*f.EventFrom)) && (f.EventTo == nil || d.Before(*f.EventTo))
+}
+
+// AchievementSearchHit is one MongoDB text-search match
+type AchievementSearchHit struct {
+	Achievement Achievement
+	Score       float64
+}
+
+// AchievementSearchResult is a visible achievement with its relevance score and highlighted snippets
+// (HTML-escaped text with matches wrapped in <em>)
+type AchievementSearchResult struct {
+	AchievementReference
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights,omitempty"`
+}
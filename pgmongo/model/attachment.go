@@ -0,0 +1,51 @@
+// File: BACKEND-UAS/pgmongo/model/attachment.go
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Prefix key storage: file attachment aktif dan file yang dikarantina (dihapus/diganti setelah direview)
+const (
+	AttachmentKeyPrefix = "attachments/"
+	QuarantineKeyPrefix = "quarantine/"
+)
+
+// Key returns the storage key of the attachment. Upload lama tidak punya StorageKey dan
+// tersimpan langsung di root ./uploads, sehingga key diturunkan dari FileURL "/uploads/<nama>".
+func (a Attachment) Key() string {
+	if a.StorageKey != "" {
+		return a.StorageKey
+	}
+	return strings.TrimPrefix(a.FileURL, "/uploads/")
+}
+
+// AttachmentID returns the stable ID, atau nama file unik (<uuid>.<ext>) untuk upload lama tanpa ID
+func (a Attachment) AttachmentID() string {
+	if a.ID != "" {
+		return a.ID
+	}
+	return a.FileName
+}
+
+// FindAttachment mencari attachment berdasarkan AttachmentID
+func (a *Achievement) FindAttachment(attachmentID string) *Attachment {
+	for i := range a.Attachments {
+		if a.Attachments[i].AttachmentID() == attachmentID {
+			return &a.Attachments[i]
+		}
+	}
+	return nil
+}
+
+// DownloadPath returns the authenticated download route of the attachment
+func (a Attachment) DownloadPath(achievementID string) string {
+	return "/api/v1/achievements/" + achievementID + "/attachments/" + a.AttachmentID()
+}
+
+// AttachmentLink adalah signed link berumur pendek untuk disematkan di frontend (img/iframe)
+type AttachmentLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,84 @@
+// File: BACKEND-UAS/pgmongo/model/attachment_blob.go
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// BlobKeyPrefix adalah prefix file content-addressed: attachments/sha256/<2 hex pertama>/<sha256><ext>
+const BlobKeyPrefix = AttachmentKeyPrefix + "sha256/"
+
+// BlobKey returns the content address of a file with the given sha256 (hex) and sniffed type
+func BlobKey(hash, mimeType string) string {
+	return BlobKeyPrefix + hash[:2] + "/" + hash + AllowedAttachmentTypes[mimeType]
+}
+
+// IsBlobKey reports whether key is content-addressed (dipakai bersama, dihitung lewat refCount)
+func IsBlobKey(key string) bool {
+	return strings.HasPrefix(key, BlobKeyPrefix)
+}
+
+// AttachmentBlob mencatat satu file content-addressed di storage dan jumlah attachment yang memakainya.
+// RefCount hanya menghitung attachment di dokumen achievement; snapshot revisi tidak ikut dihitung
+// tetapi tetap dilindungi oleh GC.
+type AttachmentBlob struct {
+	Hash        string    `bson:"_id" json:"hash"`
+	Key         string    `bson:"key" json:"key"`
+	Size        int64     `bson:"size" json:"size"`
+	ContentType string    `bson:"contentType" json:"contentType"`
+	RefCount    int       `bson:"refCount" json:"refCount"`
+	CreatedAt   time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time `bson:"updatedAt" json:"updatedAt"`
+}
+
+// AttachmentRef adalah satu attachment beserta dokumen achievement-nya; Revision > 0 berarti attachment
+// berasal dari snapshot revisi, bukan dari dokumen achievement itu sendiri
+type AttachmentRef struct {
+	MongoAchievementID string
+	Revision           int64
+	Attachment         Attachment
+}
+
+// Tindakan GC untuk attachment yang file-nya hilang
+const (
+	GCActionRelinked     = "relinked"      // file dengan hash yang sama ditemukan dan attachment diarahkan ke sana
+	GCActionMarkedFailed = "marked_failed" // tidak bisa dipulihkan; attachment ditandai scan_failed agar diganti pemilik
+)
+
+// AttachmentGCReport adalah hasil satu putaran garbage collection storage attachment
+type AttachmentGCReport struct {
+	Repair         bool          `json:"repair"`
+	ObjectsScanned int           `json:"objects_scanned"`
+	References     int           `json:"references"`
+	OrphanFiles    []OrphanFile  `json:"orphan_files"`
+	MissingFiles   []MissingFile `json:"missing_files"`
+	RefCountFixes  []RefCountFix `json:"ref_count_fixes"`
+	OrphanBytes    int64         `json:"orphan_bytes"`
+}
+
+// OrphanFile adalah file di storage yang tidak dipakai attachment mana pun
+type OrphanFile struct {
+	Key     string    `json:"key"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	Deleted bool      `json:"deleted"`
+}
+
+// MissingFile adalah attachment yang file-nya tidak ada di storage
+type MissingFile struct {
+	MongoAchievementID string `json:"mongo_achievement_id"`
+	Revision           int64  `json:"revision,omitempty"`
+	AttachmentID       string `json:"attachment_id"`
+	FileName           string `json:"file_name"`
+	Key                string `json:"key"`
+	Action             string `json:"action,omitempty"`
+}
+
+// RefCountFix adalah blob yang refCount-nya tidak sesuai jumlah attachment yang memakainya
+type RefCountFix struct {
+	Hash   string `json:"hash"`
+	Stored int    `json:"stored"`
+	Actual int    `json:"actual"`
+	Fixed  bool   `json:"fixed"`
+}
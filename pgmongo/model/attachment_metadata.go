@@ -0,0 +1,148 @@
+// File: BACKEND-UAS/pgmongo/model/attachment_metadata.go
+package model
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxMetadataText membatasi teks hasil ekstraksi yang disimpan di dokumen Mongo (byte)
+const MaxMetadataText = 4 << 10
+
+// AttachmentMetadata adalah hasil ekstraksi teks dan kode QR dari file attachment saat upload
+type AttachmentMetadata struct {
+	Text              string    `bson:"text,omitempty" json:"-"`
+	QRCodes           []string  `bson:"qrCodes,omitempty" json:"qrCodes,omitempty"`
+	URLs              []string  `bson:"urls,omitempty" json:"urls,omitempty"` // QR dulu, lalu link PDF, lalu URL di teks
+	CertificateNumber string    `bson:"certificateNumber,omitempty" json:"certificateNumber,omitempty"`
+	ExtractedAt       time.Time `bson:"extractedAt" json:"extractedAt"`
+}
+
+// Sumber petunjuk verifikasi
+const (
+	HintSourceQR   = "qr"
+	HintSourceText = "text"
+)
+
+// VerificationHint adalah petunjuk keaslian sertifikat untuk reviewer: nomor sertifikat dan URL verifikasi
+// penerbit yang ditemukan di attachment. Hanya petunjuk; reviewer tetap membuka URL-nya sendiri.
+type VerificationHint struct {
+	AttachmentID      string `json:"attachment_id"`
+	FileName          string `json:"file_name"`
+	CertificateNumber string `json:"certificate_number,omitempty"`
+	IssuerURL         string `json:"issuer_url,omitempty"`
+	Source            string `json:"source"`
+}
+
+var (
+	urlPattern = regexp.MustCompile(`https?://[^\s<>"'()\[\]{}]+`)
+	// label nomor sertifikat (Indonesia/Inggris) diikuti kodenya; kode harus mengandung angka
+	certNumberPattern = regexp.MustCompile(`(?i)\b(?:nomor\s+sertifikat|no\.?\s*sertifikat|certificate\s+(?:no\.?|number|id)|credential\s+id|cert\.?\s*id|nomor|no\.|number)\s*[:#.]?\s*([A-Z0-9][A-Z0-9./\-]{3,63})`)
+	// parameter query yang biasa dipakai URL verifikasi sertifikat
+	certQueryParams = []string{"certificate", "cert", "certificate_id", "credential", "serial", "code", "no", "id"}
+)
+
+// NewAttachmentMetadata menyusun metadata dari teks, link dan payload QR hasil ekstraksi
+func NewAttachmentMetadata(text string, links, qrCodes []string, now time.Time) *AttachmentMetadata {
+	if len(text) > MaxMetadataText {
+		text = strings.ToValidUTF8(text[:MaxMetadataText], "")
+	}
+	meta := &AttachmentMetadata{Text: text, QRCodes: qrCodes, ExtractedAt: now}
+	seen := map[string]bool{}
+	addURL := func(u string) {
+		u = strings.TrimRight(u, ".,;:")
+		if !seen[u] {
+			seen[u] = true
+			meta.URLs = append(meta.URLs, u)
+		}
+	}
+	for _, code := range qrCodes {
+		if isHTTPURL(code) {
+			addURL(code)
+		}
+	}
+	for _, link := range links {
+		addURL(link)
+	}
+	for _, u := range urlPattern.FindAllString(text, -1) {
+		addURL(u)
+	}
+
+	_, meta.CertificateNumber = meta.certificateNumber()
+	if meta.empty() {
+		return nil
+	}
+	return meta
+}
+
+// certificateNumber mencari nomor sertifikat: payload QR yang berupa kode, parameter URL verifikasi dari QR,
+// lalu label "No./Nomor/Certificate No." di teks
+func (m *AttachmentMetadata) certificateNumber() (string, string) {
+	for _, code := range m.QRCodes {
+		if !isHTTPURL(code) && looksLikeCode(code) {
+			return HintSourceQR, code
+		}
+	}
+	for _, code := range m.QRCodes {
+		if n := certNumberFromURL(code); n != "" {
+			return HintSourceQR, n
+		}
+	}
+	for _, match := range certNumberPattern.FindAllStringSubmatch(m.Text, -1) {
+		if n := strings.TrimRight(match[1], ".-/"); looksLikeCode(n) {
+			return HintSourceText, n
+		}
+	}
+	return "", ""
+}
+
+func (m *AttachmentMetadata) empty() bool {
+	return m.Text == "" && len(m.QRCodes) == 0 && len(m.URLs) == 0
+}
+
+// VerificationHint menyusun petunjuk verifikasi dari metadata attachment; nil jika tidak ada nomor maupun URL
+func (a Attachment) VerificationHint() *VerificationHint {
+	m := a.Metadata
+	if m == nil || (m.CertificateNumber == "" && len(m.URLs) == 0) {
+		return nil
+	}
+	hint := &VerificationHint{AttachmentID: a.AttachmentID(), FileName: a.FileName, CertificateNumber: m.CertificateNumber, Source: HintSourceText}
+	if len(m.URLs) > 0 {
+		hint.IssuerURL = m.URLs[0]
+	}
+	// QR lebih bisa dipercaya daripada teks yang bisa diketik siapa saja
+	if source, _ := m.certificateNumber(); source == HintSourceQR || slices.Contains(m.QRCodes, hint.IssuerURL) {
+		hint.Source = HintSourceQR
+	}
+	return hint
+}
+
+func isHTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func certNumberFromURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || !isHTTPURL(s) {
+		return ""
+	}
+	q := u.Query()
+	for _, name := range certQueryParams {
+		if v := q.Get(name); looksLikeCode(v) {
+			return v
+		}
+	}
+	return ""
+}
+
+// looksLikeCode: satu token 4-64 karakter tanpa spasi yang mengandung angka
+func looksLikeCode(s string) bool {
+	if len(s) < 4 || len(s) > 64 || strings.ContainsAny(s, " \t\n") {
+		return false
+	}
+	return strings.ContainsAny(s, "0123456789")
+}
@@ -0,0 +1,24 @@
+// File: BACKEND-UAS/pgmongo/model/attachment_preview.go
+package model
+
+import "time"
+
+// ThumbnailKey dan PreviewKey diturunkan dari hash file sumber dan disimpan di samping blob-nya,
+// sehingga file identik berbagi preview dan GC menghitungnya bersama blob tersebut
+func ThumbnailKey(hash string) string {
+	return BlobKeyPrefix + hash[:2] + "/" + hash + ".thumb.jpg"
+}
+
+func PreviewKey(hash string) string {
+	return BlobKeyPrefix + hash[:2] + "/" + hash + ".preview.jpg"
+}
+
+// AttachmentPreview berisi signed link gambar preview satu attachment untuk ditampilkan inline (img)
+type AttachmentPreview struct {
+	AttachmentID string    `json:"attachment_id"`
+	FileName     string    `json:"file_name"`
+	FileType     string    `json:"file_type"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	PreviewURL   string    `json:"preview_url"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
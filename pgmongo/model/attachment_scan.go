@@ -0,0 +1,35 @@
+// File: BACKEND-UAS/pgmongo/model/attachment_scan.go
+package model
+
+// Status scan antivirus attachment
+const (
+	ScanStatusPending  = "pending_scan"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected"
+	ScanStatusFailed   = "scan_failed" // file tidak bisa di-scan (mis. hilang dari storage); perlu diganti
+)
+
+// IsScanPending reports whether the attachment still waits for the scanner (termasuk upload lama tanpa status)
+func (a Attachment) IsScanPending() bool {
+	return a.ScanStatus == "" || a.ScanStatus == ScanStatusPending
+}
+
+// UnscannedOrInfected returns, dalam urutan aslinya, attachment yang menghalangi submit:
+// belum di-scan, terinfeksi, atau gagal di-scan
+func (a *Achievement) UnscannedOrInfected() []Attachment {
+	blocked := []Attachment{}
+	for _, att := range a.Attachments {
+		if att.ScanStatus != ScanStatusClean {
+			blocked = append(blocked, att)
+		}
+	}
+	return blocked
+}
+
+// ScanRunResult merangkum satu putaran scan
+type ScanRunResult struct {
+	Clean    int `json:"clean"`
+	Infected int `json:"infected"`
+	Failed   int `json:"failed"`
+	Retry    int `json:"retry"` // scanner error, dicoba lagi di putaran berikutnya
+}
@@ -0,0 +1,49 @@
+// File: BACKEND-UAS/pgmongo/model/attachment_upload.go
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadKeyPrefix adalah prefix storage untuk chunk resumable upload yang belum selesai; GC tidak menyentuhnya,
+// chunk dibersihkan saat upload selesai, dibatalkan atau kedaluwarsa
+const UploadKeyPrefix = "resumable/"
+
+// AttachmentUpload adalah sesi resumable upload (gaya tus): file dikirim per chunk dan digabung menjadi
+// attachment setelah Offset mencapai Length
+type AttachmentUpload struct {
+	ID                 string        `bson:"_id" json:"id"`
+	AchievementID      uuid.UUID     `bson:"achievementId" json:"achievement_id"`
+	MongoAchievementID string        `bson:"mongoAchievementId" json:"-"`
+	UserID             uuid.UUID     `bson:"userId" json:"-"`
+	FileName           string        `bson:"fileName" json:"file_name"`
+	FileType           string        `bson:"fileType,omitempty" json:"file_type,omitempty"` // hasil sniffing chunk pertama
+	Length             int64         `bson:"length" json:"length"`
+	Offset             int64         `bson:"offset" json:"offset"`
+	Chunks             []UploadChunk `bson:"chunks" json:"-"`
+	Finalized          bool          `bson:"finalized,omitempty" json:"-"` // chunk sedang/sudah digabung; PATCH akhir lain tidak menggabung lagi
+	CreatedAt          time.Time     `bson:"createdAt" json:"created_at"`
+	ExpiresAt          time.Time     `bson:"expiresAt" json:"expires_at"`
+}
+
+// UploadChunk adalah satu chunk yang sudah diterima; SHA256 dihitung server dan diperiksa lagi saat penggabungan
+type UploadChunk struct {
+	Key    string `bson:"key"`
+	Offset int64  `bson:"offset"`
+	Size   int64  `bson:"size"`
+	SHA256 string `bson:"sha256"`
+}
+
+// ChunkKey returns a unique storage key for a chunk at offset; suffix acak mencegah PATCH yang
+// berebut offset yang sama saling menimpa chunk
+func (u *AttachmentUpload) ChunkKey(offset int64) string {
+	return fmt.Sprintf("%s%s/%016d-%s", UploadKeyPrefix, u.ID, offset, uuid.New().String())
+}
+
+// Path returns the tus upload URL (Location)
+func (u *AttachmentUpload) Path() string {
+	return "/api/v1/achievements/" + u.AchievementID.String() + "/uploads/" + u.ID
+}
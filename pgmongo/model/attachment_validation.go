@@ -0,0 +1,177 @@
+// File: BACKEND-UAS/pgmongo/model/attachment_validation.go
+package model
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+)
+
+// Jenis file attachment yang diizinkan (hasil sniffing isi file, bukan header dari client)
+const (
+	MIMETypePDF  = "application/pdf"
+	MIMETypeJPEG = "image/jpeg"
+	MIMETypePNG  = "image/png"
+	MIMETypeMP4  = "video/mp4" // rekaman penampilan/lomba; file besar diunggah lewat resumable upload
+)
+
+// AllowedAttachmentTypes adalah allow-list attachment beserta ekstensi kanoniknya
+var AllowedAttachmentTypes = map[string]string{
+	MIMETypePDF:  ".pdf",
+	MIMETypeJPEG: ".jpg",
+	MIMETypePNG:  ".png",
+	MIMETypeMP4:  ".mp4",
+}
+
+// Kode error validasi attachment
+const (
+	AttachmentErrTooLarge    = "attachment_too_large"
+	AttachmentErrUnsupported = "unsupported_media_type"
+	AttachmentErrExecutable  = "executable_content"
+	AttachmentErrPolyglot    = "polyglot_file"
+	AttachmentErrTooMany     = "too_many_attachments"
+)
+
+// AttachmentError adalah error validasi upload yang dikirim apa adanya sebagai body response
+type AttachmentError struct {
+	Status       int      `json:"-"`
+	Code         string   `json:"code"`
+	Message      string   `json:"error"`
+	DetectedType string   `json:"detected_type,omitempty"`
+	MaxSize      int64    `json:"max_size,omitempty"`
+	AllowedTypes []string `json:"allowed_types,omitempty"`
+}
+
+func (e *AttachmentError) Error() string { return e.Message }
+
+func NewAttachmentTooLargeError(detected string, maxSize int64) *AttachmentError {
+	return &AttachmentError{
+		Status: http.StatusRequestEntityTooLarge, Code: AttachmentErrTooLarge, DetectedType: detected, MaxSize: maxSize,
+		Message: "attachment exceeds the " + strconv.FormatInt(maxSize, 10) + " byte limit for " + detected,
+	}
+}
+
+func NewAttachmentTypeError(code, detected, message string) *AttachmentError {
+	return &AttachmentError{
+		Status: http.StatusUnsupportedMediaType, Code: code, DetectedType: detected, Message: message,
+		AllowedTypes: []string{MIMETypePDF, MIMETypeJPEG, MIMETypePNG, MIMETypeMP4},
+	}
+}
+
+var (
+	pdfMagic  = []byte("%PDF-")
+	jpegMagic = []byte{0xFF, 0xD8, 0xFF}
+	pngMagic  = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1A, '\n'}
+	pngIEND   = []byte{'I', 'E', 'N', 'D', 0xAE, 0x42, 0x60, 0x82}
+	zipMagic  = []byte("PK\x03\x04")
+	mp4Ftyp   = []byte("ftyp")
+)
+
+// mp4Brands: major brand box ftyp untuk MP4 biasa (QuickTime/MOV dan format lain tidak diterima)
+var mp4Brands = []string{"isom", "iso2", "iso4", "iso5", "iso6", "mp41", "mp42", "avc1", "M4V ", "dash"}
+
+// executableMagics: PE/DOS, ELF, Mach-O (32/64 bit, kedua endian, fat binary), script shebang
+var executableMagics = [][]byte{
+	[]byte("MZ"),
+	[]byte("\x7fELF"),
+	{0xFE, 0xED, 0xFA, 0xCE}, {0xFE, 0xED, 0xFA, 0xCF},
+	{0xCE, 0xFA, 0xED, 0xFE}, {0xCF, 0xFA, 0xED, 0xFE},
+	{0xCA, 0xFE, 0xBA, 0xBE},
+	[]byte("#!"),
+}
+
+// pdfActiveContent: nama PDF untuk aksi yang menjalankan kode atau membawa file lain
+var pdfActiveContent = []string{"/JavaScript", "/JS", "/Launch", "/EmbeddedFile"}
+
+// SniffAttachmentType mendeteksi jenis file dari magic bytes di awal; "" jika tidak dikenali
+func SniffAttachmentType(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, pdfMagic):
+		return MIMETypePDF
+	case bytes.HasPrefix(head, jpegMagic):
+		return MIMETypeJPEG
+	case bytes.HasPrefix(head, pngMagic):
+		return MIMETypePNG
+	case len(head) >= 12 && bytes.Equal(head[4:8], mp4Ftyp):
+		for _, brand := range mp4Brands {
+			if string(head[8:12]) == brand {
+				return MIMETypeMP4
+			}
+		}
+	}
+	return ""
+}
+
+// IsExecutable reports whether the file starts with a known executable or script signature
+func IsExecutable(head []byte) bool {
+	for _, magic := range executableMagics {
+		if bytes.HasPrefix(head, magic) {
+			return true
+		}
+	}
+	return false
+}
+
+// PolyglotReason memeriksa isi file yang jenisnya sudah di-sniff dan mengembalikan alasan penolakan
+// jika file sekaligus valid sebagai format lain (data tambahan setelah penanda akhir, arsip atau PDF
+// tersisip) atau PDF membawa konten aktif. "" berarti aman. Video tidak diperiksa: isinya biner acak
+// tanpa penanda akhir, dan file besar tidak pernah dibaca utuh ke memori.
+func PolyglotReason(data []byte, mimeType string) string {
+	switch mimeType {
+	case MIMETypePDF:
+		for _, name := range pdfActiveContent {
+			if containsPDFName(data, name) {
+				return "pdf contains active content (" + name[1:] + ")"
+			}
+		}
+		if bytes.Contains(data, zipMagic) {
+			return "pdf contains an embedded archive"
+		}
+		// setelah %%EOF terakhir hanya boleh whitespace
+		eof := bytes.LastIndex(data, []byte("%%EOF"))
+		if eof < 0 {
+			return "pdf has no end-of-file marker"
+		}
+		if len(bytes.TrimSpace(data[eof+len("%%EOF"):])) > 0 {
+			return "data appended after pdf end-of-file marker"
+		}
+	case MIMETypeJPEG, MIMETypePNG:
+		var end int
+		if mimeType == MIMETypePNG {
+			if i := bytes.LastIndex(data, pngIEND); i >= 0 {
+				end = i + len(pngIEND)
+			}
+		} else if i := bytes.LastIndex(data, []byte{0xFF, 0xD9}); i >= 0 {
+			end = i + 2
+		}
+		if end == 0 {
+			return "image has no end marker"
+		}
+		if len(bytes.TrimRight(data[end:], "\x00")) > 0 {
+			return "data appended after image end marker"
+		}
+		if bytes.Contains(data, zipMagic) || bytes.Contains(data, pdfMagic) {
+			return "image contains an embedded archive or document"
+		}
+	}
+	return ""
+}
+
+// containsPDFName mencari name object utuh, mis. "/JS" tetapi bukan "/JSON"
+func containsPDFName(data []byte, name string) bool {
+	for rest := data; ; {
+		i := bytes.Index(rest, []byte(name))
+		if i < 0 {
+			return false
+		}
+		next := i + len(name)
+		if next >= len(rest) || !isPDFRegularChar(rest[next]) {
+			return true
+		}
+		rest = rest[next:]
+	}
+}
+
+func isPDFRegularChar(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == '#' || c == '_' || c == '-' || c == '.'
+}
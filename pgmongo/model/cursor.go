@@ -0,0 +1,60 @@
+// File: BACKEND-UAS/pgmongo/model/cursor.go
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor menunjuk baris terakhir halaman sebelumnya; urutan list selalu (created_at DESC, id DESC)
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// Encode returns the opaque cursor string sent to clients
+func (c Cursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(value string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{CreatedAt: createdAt, ID: parsedID}, nil
+}
+
+// CursorPage is a keyset pagination request; After nil berarti halaman pertama
+type CursorPage struct {
+	After     *Cursor
+	Limit     int
+	WithTotal bool // COUNT(*) hanya dijalankan jika diminta
+}
+
+// CursorResponse is the keyset alternative to PaginatedResponse
+type CursorResponse[T any] struct {
+	Data       []T    `json:"data"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"` // kosong jika tidak ada halaman berikutnya
+	Total      *int64 `json:"total,omitempty"`
+}
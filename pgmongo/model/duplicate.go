@@ -0,0 +1,200 @@
+// File: BACKEND-UAS/pgmongo/model/duplicate.go
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Duplicate reasons
+const (
+	DuplicateSameAttachment  = "same_attachment"
+	DuplicateSameTitle       = "same_title"
+	DuplicateSameEventDate   = "same_event_date"
+	DuplicateSameOrganizer   = "same_organizer"
+	DuplicateSameCertificate = "same_certificate"
+)
+
+// DuplicateMatch points to another achievement that looks like the same entry
+type DuplicateMatch struct {
+	AchievementID uuid.UUID `json:"achievement_id"`
+	StudentID     uuid.UUID `json:"student_id"`
+	Title         string    `json:"title"`
+	Status        string    `json:"status"`
+	Reasons       []string  `json:"reasons"`
+	Link          string    `json:"link"`
+}
+
+// DuplicateWarning is the owner-facing form of DuplicateMatch: hanya id dan alasan, tanpa judul, pemilik atau
+// status achievement milik mahasiswa lain. Detail lengkap hanya untuk reviewer (verification queue).
+type DuplicateWarning struct {
+	AchievementID uuid.UUID `json:"achievement_id"`
+	Reasons       []string  `json:"reasons"`
+}
+
+func (m DuplicateMatch) Warning() DuplicateWarning {
+	return DuplicateWarning{AchievementID: m.AchievementID, Reasons: m.Reasons}
+}
+
+// key di details yang dianggap tanggal event / penyelenggara
+var (
+	eventDateDetailKeys = []string{"eventdate", "event_date", "date", "competitiondate", "competition_date", "tanggal"}
+	organizerDetailKeys = []string{"organizer", "organiser", "penyelenggara", "issuer"}
+)
+
+// NormalizeTitle lowercases, strips punctuation and collapses whitespace
+func NormalizeTitle(title string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// DuplicateReasons membandingkan dua achievement; hasil kosong berarti bukan duplikat.
+// Dianggap duplikat jika ada lampiran dengan isi sama, nomor sertifikat sama, atau judul sama ditambah tanggal/penyelenggara yang sama.
+func DuplicateReasons(a, b *Achievement) []string {
+	var reasons []string
+	if sharesAttachment(a, b) {
+		reasons = append(reasons, DuplicateSameAttachment)
+	}
+	if c := CertificateKey(a); c != "" && c == CertificateKey(b) {
+		reasons = append(reasons, DuplicateSameCertificate)
+	}
+
+	titleA, titleB := NormalizeTitle(a.Title), NormalizeTitle(b.Title)
+	if titleA != "" && titleA == titleB {
+		var corroborating []string
+		if d := eventDateKey(a); d != "" && d == eventDateKey(b) {
+			corroborating = append(corroborating, DuplicateSameEventDate)
+		}
+		if o := organizerKey(a); o != "" && o == organizerKey(b) {
+			corroborating = append(corroborating, DuplicateSameOrganizer)
+		}
+		if len(corroborating) > 0 || len(reasons) > 0 {
+			reasons = append(reasons, DuplicateSameTitle)
+			reasons = append(reasons, corroborating...)
+		}
+	}
+	return reasons
+}
+
+func sharesAttachment(a, b *Achievement) bool {
+	hashes := map[string]bool{}
+	for _, att := range a.Attachments {
+		if att.ContentHash != "" {
+			hashes[att.ContentHash] = true
+		}
+	}
+	for _, att := range b.Attachments {
+		if hashes[att.ContentHash] {
+			return true
+		}
+	}
+	return false
+}
+
+// detailValue mengambil nilai details pertama yang key-nya cocok (tanpa memperhatikan huruf besar/kecil)
+func detailValue(ach *Achievement, keys []string) interface{} {
+	for k, v := range ach.Details {
+		lk := strings.ToLower(k)
+		for _, want := range keys {
+			if lk == want {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+func eventDateKey(ach *Achievement) string {
+	if ach.EventAt != nil {
+		return ach.EventAt.Format("2006-01-02")
+	}
+	v := detailValue(ach, eventDateDetailKeys)
+	if v == nil {
+		return ""
+	}
+	if t, ok := parseEventDate(v); ok {
+		return t.Format("2006-01-02")
+	}
+	return NormalizeTitle(fmt.Sprint(v))
+}
+
+// parseEventDate menerima time.Time atau string dengan format tanggal yang umum dipakai di details
+func parseEventDate(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case primitive.DateTime:
+		return v.Time(), true
+	case nil:
+		return time.Time{}, false
+	default:
+		s := strings.TrimSpace(fmt.Sprint(v))
+		for _, layout := range []string{time.RFC3339, "2006-01-02", "02-01-2006", "02/01/2006"} {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}
+}
+
+// EventDate returns the date the achievement happened: field eventDate, lalu tanggal di details (data lama),
+// dan terakhir CreatedAt
+func (a *Achievement) EventDate() time.Time {
+	if a.EventAt != nil {
+		return *a.EventAt
+	}
+	if t, ok := parseEventDate(detailValue(a, eventDateDetailKeys)); ok {
+		return t
+	}
+	return a.CreatedAt
+}
+
+// EffectiveOrganizer returns the organizer field, falling back to the organizer stored in details
+func (a *Achievement) EffectiveOrganizer() string {
+	if o := strings.TrimSpace(a.Organizer); o != "" {
+		return o
+	}
+	if v := detailValue(a, organizerDetailKeys); v != nil {
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+	return ""
+}
+
+// CertificateKey normalizes the certificate number for comparison (tanpa spasi, huruf besar)
+func CertificateKey(ach *Achievement) string {
+	return strings.ToUpper(strings.Join(strings.Fields(ach.CertificateNumber), ""))
+}
+
+func organizerKey(ach *Achievement) string {
+	return NormalizeTitle(ach.EffectiveOrganizer())
+}
+
+// AttachmentHashes returns the non-empty content hashes of an achievement's attachments
+func (a *Achievement) AttachmentHashes() []string {
+	hashes := []string{}
+	for _, att := range a.Attachments {
+		if att.ContentHash != "" {
+			hashes = append(hashes, att.ContentHash)
+		}
+	}
+	return hashes
+}
@@ -0,0 +1,207 @@
+// File: BACKEND-UAS/pgmongo/model/tag.go
+package model
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Tag adalah satu entri vocabulary; Achievement.Tags menyimpan Slug kanonik
+type Tag struct {
+	ID        uuid.UUID  `json:"id"`
+	Slug      string     `json:"slug"`
+	Name      string     `json:"name"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
+	Synonyms  []string   `json:"synonyms"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TagRequest is the body for creating or updating a tag
+type TagRequest struct {
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	Synonyms []string   `json:"synonyms"`
+}
+
+// TagSuggestion is one autocomplete result
+type TagSuggestion struct {
+	Slug           string   `json:"slug"`
+	Name           string   `json:"name"`
+	Path           []string `json:"path"`                      // nama dari root sampai tag ini
+	MatchedSynonym string   `json:"matched_synonym,omitempty"` // diisi jika yang cocok adalah sinonim
+}
+
+// NormalizeTag mengubah "Computer  Science" / "computer_science" menjadi "computer-science"
+func NormalizeTag(tag string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(tag)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// TagTaxonomy is an in-memory index of the vocabulary for resolving, autocompleting and expanding tags
+type TagTaxonomy struct {
+	byID     map[uuid.UUID]*Tag
+	byKey    map[string]*Tag // slug, nama dan sinonim yang sudah dinormalisasi
+	children map[uuid.UUID][]*Tag
+	tags     []*Tag
+}
+
+func NewTagTaxonomy(tags []Tag) *TagTaxonomy {
+	t := &TagTaxonomy{
+		byID:     map[uuid.UUID]*Tag{},
+		byKey:    map[string]*Tag{},
+		children: map[uuid.UUID][]*Tag{},
+	}
+	for i := range tags {
+		tag := &tags[i]
+		t.tags = append(t.tags, tag)
+		t.byID[tag.ID] = tag
+		for _, key := range tag.keys() {
+			if _, exists := t.byKey[key]; !exists {
+				t.byKey[key] = tag
+			}
+		}
+		if tag.ParentID != nil {
+			t.children[*tag.ParentID] = append(t.children[*tag.ParentID], tag)
+		}
+	}
+	return t
+}
+
+func (tag *Tag) keys() []string {
+	keys := []string{tag.Slug, NormalizeTag(tag.Name)}
+	for _, syn := range tag.Synonyms {
+		keys = append(keys, NormalizeTag(syn))
+	}
+	return keys
+}
+
+// Resolve returns the tag whose slug, name or synonym matches the raw input
+func (t *TagTaxonomy) Resolve(raw string) *Tag {
+	return t.byKey[NormalizeTag(raw)]
+}
+
+func (t *TagTaxonomy) ByID(id uuid.UUID) *Tag {
+	return t.byID[id]
+}
+
+// Path returns the tag names from the root down to the tag
+func (t *TagTaxonomy) Path(tag *Tag) []string {
+	path := []string{}
+	seen := map[uuid.UUID]bool{}
+	for cur := tag; cur != nil && !seen[cur.ID]; {
+		seen[cur.ID] = true
+		path = append([]string{cur.Name}, path...)
+		if cur.ParentID == nil {
+			break
+		}
+		cur = t.byID[*cur.ParentID]
+	}
+	return path
+}
+
+// IsDescendant reports whether candidate is ancestor itself or lies below it in the hierarchy
+func (t *TagTaxonomy) IsDescendant(candidate, ancestor uuid.UUID) bool {
+	seen := map[uuid.UUID]bool{}
+	for cur := t.byID[candidate]; cur != nil && !seen[cur.ID]; {
+		if cur.ID == ancestor {
+			return true
+		}
+		seen[cur.ID] = true
+		if cur.ParentID == nil {
+			return false
+		}
+		cur = t.byID[*cur.ParentID]
+	}
+	return false
+}
+
+// Expand returns the slug of the tag plus all of its descendants (filter "computer-science" juga cocok dengan "ai")
+func (t *TagTaxonomy) Expand(tag *Tag) []string {
+	slugs := []string{}
+	seen := map[uuid.UUID]bool{}
+	queue := []*Tag{tag}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if seen[cur.ID] {
+			continue
+		}
+		seen[cur.ID] = true
+		slugs = append(slugs, cur.Slug)
+		queue = append(queue, t.children[cur.ID]...)
+	}
+	return slugs
+}
+
+// Suggest mencari tag yang slug, nama atau sinonimnya diawali (prioritas) atau mengandung prefix
+func (t *TagTaxonomy) Suggest(prefix string, limit int) []TagSuggestion {
+	q := NormalizeTag(prefix)
+	type scored struct {
+		s    TagSuggestion
+		rank int
+	}
+	matches := []scored{}
+	for _, tag := range t.tags {
+		rank, synonym := -1, ""
+		candidates := append([]string{tag.Slug, NormalizeTag(tag.Name)}, tag.Synonyms...)
+		for i, c := range candidates {
+			key := NormalizeTag(c)
+			r := -1
+			switch {
+			case q == "" || strings.HasPrefix(key, q):
+				r = 0
+			case strings.Contains(key, q):
+				r = 2
+			default:
+				continue
+			}
+			if i >= 2 {
+				r++ // sinonim sedikit di bawah slug/nama
+			}
+			if rank < 0 || r < rank {
+				rank = r
+				synonym = ""
+				if i >= 2 {
+					synonym = c
+				}
+			}
+		}
+		if rank < 0 {
+			continue
+		}
+		matches = append(matches, scored{
+			s:    TagSuggestion{Slug: tag.Slug, Name: tag.Name, Path: t.Path(tag), MatchedSynonym: synonym},
+			rank: rank,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].rank != matches[j].rank {
+			return matches[i].rank < matches[j].rank
+		}
+		return matches[i].s.Name < matches[j].s.Name
+	})
+
+	out := []TagSuggestion{}
+	for i := 0; i < len(matches) && i < limit; i++ {
+		out = append(out, matches[i].s)
+	}
+	return out
+}
@@ -0,0 +1,106 @@
+// File: BACKEND-UAS/pgmongo/model/team.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Team member roles
+const (
+	TeamRoleLeader = "leader"
+	TeamRoleMember = "member"
+)
+
+// Team member statuses; hanya anggota confirmed yang mendapat reference & poin
+const (
+	TeamMemberPending   = "pending"
+	TeamMemberConfirmed = "confirmed"
+	TeamMemberDeclined  = "declined"
+)
+
+// Points policies for team achievements
+const (
+	PointsPolicyFull           = "full"            // setiap anggota mendapat poin penuh
+	PointsPolicySplit          = "split"           // poin dibagi rata, sisa pembagian ke ketua
+	PointsPolicyLeaderWeighted = "leader_weighted" // ketua dihitung dua bagian
+)
+
+// Team is stored on the shared achievement document; the leader is the student who created it
+type Team struct {
+	Name         string       `bson:"name" json:"name"`
+	PointsPolicy string       `bson:"pointsPolicy" json:"pointsPolicy"`
+	Members      []TeamMember `bson:"members" json:"members"`
+}
+
+type TeamMember struct {
+	StudentID   uuid.UUID  `bson:"studentId" json:"studentId"`
+	Role        string     `bson:"role" json:"role"`
+	Status      string     `bson:"status" json:"status"`
+	RespondedAt *time.Time `bson:"respondedAt,omitempty" json:"respondedAt,omitempty"`
+}
+
+func IsValidPointsPolicy(policy string) bool {
+	return policy == PointsPolicyFull || policy == PointsPolicySplit || policy == PointsPolicyLeaderWeighted
+}
+
+// Member returns the team member entry for a student, or nil
+func (t *Team) Member(studentID uuid.UUID) *TeamMember {
+	for i := range t.Members {
+		if t.Members[i].StudentID == studentID {
+			return &t.Members[i]
+		}
+	}
+	return nil
+}
+
+// MemberPoints membagi total poin ke anggota yang sudah confirmed sesuai PointsPolicy
+func (t *Team) MemberPoints(total int) map[uuid.UUID]int {
+	result := map[uuid.UUID]int{}
+	var leader uuid.UUID
+	shares := map[uuid.UUID]int{}
+	sumShares := 0
+	for _, m := range t.Members {
+		if m.Status != TeamMemberConfirmed {
+			continue
+		}
+		share := 1
+		if m.Role == TeamRoleLeader {
+			leader = m.StudentID
+			if t.PointsPolicy == PointsPolicyLeaderWeighted {
+				share = 2
+			}
+		}
+		shares[m.StudentID] = share
+		sumShares += share
+	}
+	if sumShares == 0 {
+		return result
+	}
+
+	if t.PointsPolicy == PointsPolicyFull {
+		for id := range shares {
+			result[id] = total
+		}
+		return result
+	}
+
+	distributed := 0
+	for id, share := range shares {
+		result[id] = total * share / sumShares
+		distributed += result[id]
+	}
+	if _, ok := result[leader]; ok {
+		result[leader] += total - distributed
+	}
+	return result
+}
+
+// PointsFor returns the points a student earns from this achievement
+func (a *Achievement) PointsFor(studentID uuid.UUID) int {
+	if a.Team == nil {
+		return a.Points
+	}
+	return a.Team.MemberPoints(a.Points)[studentID]
+}
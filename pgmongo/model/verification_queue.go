@@ -0,0 +1,71 @@
+// File: BACKEND-UAS/pgmongo/model/verification_queue.go
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLA indicator values for VerificationQueueItem.SLAStatus
+const (
+	SLAOnTrack = "on_track"
+	SLADueSoon = "due_soon"
+	SLAOverdue = "overdue"
+)
+
+// VerificationQueueItem is one submitted achievement waiting for review
+type VerificationQueueItem struct {
+	ID              uuid.UUID          `json:"id"`
+	Student         Student            `json:"student"`
+	Title           string             `json:"title"`
+	AchievementType string             `json:"achievement_type"`
+	Level           string             `json:"level"`
+	Points          int                `json:"points"`
+	SubmittedAt     *time.Time         `json:"submitted_at"`
+	WaitingHours    int64              `json:"waiting_hours"`
+	DueAt           *time.Time         `json:"due_at"`
+	SLAStatus       string             `json:"sla_status"`
+	ClaimedBy       *uuid.UUID         `json:"claimed_by,omitempty"`
+	ClaimedAt       *time.Time         `json:"claimed_at,omitempty"`
+	Duplicates      []DuplicateMatch   `json:"duplicates,omitempty"`         // kemungkinan duplikat, untuk dicek reviewer
+	Hints           []VerificationHint `json:"verification_hints,omitempty"` // nomor sertifikat & URL verifikasi dari attachment
+	Version         int64              `json:"version"`
+}
+
+// VerificationQueueFilter holds the optional queue filters
+type VerificationQueueFilter struct {
+	AchievementType string
+	Level           string
+	StudentID       *uuid.UUID
+}
+
+// SubmittedReferenceFilter membatasi query queue di Postgres
+type SubmittedReferenceFilter struct {
+	StudentIDs   []uuid.UUID // nil berarti semua mahasiswa (admin)
+	ClaimedBy    *uuid.UUID  // item yang claim-nya masih aktif milik user ini ikut tampil (mis. hasil AssignReviewer)
+	ClaimedSince time.Time   // claim lebih lama dari ini dianggap basi
+	MongoIDs     []string    // hasil filter type/level di Mongo; nil berarti tanpa batasan
+}
+
+// BatchReviewItem is one achievement in a batch verify/reject, with the version (ETag) the reviewer saw in the queue
+type BatchReviewItem struct {
+	ID      uuid.UUID `json:"id"`
+	Version int64     `json:"version"`
+}
+
+// BatchReviewResult is the per-item outcome of a batch verify/reject; Code is the HTTP status of a failed item
+// (412 when the achievement changed after the reviewer loaded it)
+type BatchReviewResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Code   int    `json:"code,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchReviewResponse wraps batch verify/reject results
+type BatchReviewResponse struct {
+	Results   []BatchReviewResult `json:"results"`
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+}
@@ -0,0 +1,120 @@
+// File: BACKEND-UAS/pgmongo/model/verification_receipt.go
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ReceiptAttachment adalah attachment yang ikut ditandatangani di receipt verifikasi
+type ReceiptAttachment struct {
+	ID          string `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentHash string `json:"sha256"`
+}
+
+// receiptContent adalah bagian dokumen prestasi yang dinilai reviewer. Field yang berubah tanpa mengubah isi
+// (status history, notifikasi, hasil scan, preview, timestamp) tidak ikut di-hash.
+type receiptContent struct {
+	StudentID         uuid.UUID    `json:"studentId"`
+	AchievementType   string       `json:"achievementType"`
+	Title             string       `json:"title"`
+	Description       string       `json:"description"`
+	Details           bson.M       `json:"details"`
+	Tags              []string     `json:"tags"`
+	Points            int          `json:"points"`
+	Level             string       `json:"level"`
+	EventDate         *time.Time   `json:"eventDate"`
+	Organizer         string       `json:"organizer"`
+	Location          string       `json:"location"`
+	CertificateNumber string       `json:"certificateNumber"`
+	VerificationURL   string       `json:"verificationUrl"`
+	Team              *receiptTeam `json:"team"`
+}
+
+type receiptTeam struct {
+	Name         string   `json:"name"`
+	PointsPolicy string   `json:"pointsPolicy"`
+	Members      []string `json:"members"` // anggota confirmed, "<studentId>:<role>" terurut
+}
+
+// ContentHash returns the sha256 (hex) of the reviewed content: JSON kanonik (key map terurut) dari field
+// konten dan anggota tim yang sudah konfirmasi. Attachment ditandatangani terpisah lewat ReceiptAttachments.
+func (a *Achievement) ContentHash() (string, error) {
+	content := receiptContent{
+		StudentID:         a.StudentID,
+		AchievementType:   a.AchievementType,
+		Title:             a.Title,
+		Description:       a.Description,
+		Details:           a.Details,
+		Tags:              a.Tags,
+		Points:            a.Points,
+		Level:             a.Level,
+		Organizer:         a.Organizer,
+		Location:          a.Location,
+		CertificateNumber: a.CertificateNumber,
+		VerificationURL:   a.VerificationURL,
+	}
+	if a.EventAt != nil {
+		eventDate := a.EventAt.UTC()
+		content.EventDate = &eventDate
+	}
+	if a.Team != nil {
+		team := &receiptTeam{Name: a.Team.Name, PointsPolicy: a.Team.PointsPolicy, Members: []string{}}
+		for _, m := range a.Team.Members {
+			if m.Status == TeamMemberConfirmed {
+				team.Members = append(team.Members, m.StudentID.String()+":"+m.Role)
+			}
+		}
+		sort.Strings(team.Members)
+		content.Team = team
+	}
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ReceiptAttachments mendaftar attachment beserta hash isinya, terurut berdasarkan AttachmentID
+func (a *Achievement) ReceiptAttachments() []ReceiptAttachment {
+	attachments := make([]ReceiptAttachment, 0, len(a.Attachments))
+	for _, att := range a.Attachments {
+		attachments = append(attachments, ReceiptAttachment{ID: att.AttachmentID(), FileName: att.FileName, ContentHash: att.ContentHash})
+	}
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].ID < attachments[j].ID })
+	return attachments
+}
+
+// Masalah yang bisa ditemukan saat memeriksa receipt
+const (
+	ReceiptInvalidSignature   = "invalid_signature" // bukan JWS yang ditandatangani server ini
+	ReceiptNotFound           = "achievement_not_found"
+	ReceiptStatusChanged      = "status_changed"      // prestasi sudah tidak berstatus verified (mis. dicabut)
+	ReceiptSuperseded         = "superseded"          // prestasi diverifikasi ulang dengan receipt baru
+	ReceiptContentChanged     = "content_changed"     // isi dokumen berubah sejak diverifikasi
+	ReceiptAttachmentsChanged = "attachments_changed" // attachment ditambah/dihapus/diganti sejak diverifikasi
+)
+
+// ReceiptVerification adalah hasil pemeriksaan receipt oleh endpoint publik. Genuine berarti tanda tangan sah;
+// Unchanged berarti prestasi masih verified dan isinya sama persis dengan saat ditandatangani.
+type ReceiptVerification struct {
+	Genuine       bool                `json:"genuine"`
+	Unchanged     bool                `json:"unchanged"`
+	KeyID         string              `json:"key_id,omitempty"`
+	AchievementID string              `json:"achievement_id,omitempty"`
+	Title         string              `json:"title,omitempty"`
+	Status        string              `json:"status,omitempty"` // status prestasi saat ini
+	VerifiedAt    *time.Time          `json:"verified_at,omitempty"`
+	VerifiedBy    string              `json:"verified_by,omitempty"`
+	ContentHash   string              `json:"content_sha256,omitempty"`
+	Attachments   []ReceiptAttachment `json:"attachments,omitempty"`
+	Problems      []string            `json:"problems,omitempty"`
+}
@@ -0,0 +1,65 @@
+// File: BACKEND-UAS/pgmongo/preview/builtin.go
+package preview
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Builtin adalah renderer pure-Go. JPEG dan PNG di-decode langsung. PDF tidak dirender (tidak ada
+// rasterizer PDF di standard library); sebagai gantinya dipakai gambar JPEG terbesar yang dipakai halaman
+// pertama, yang untuk sertifikat hasil scan adalah halaman itu sendiri. Halaman pertama tanpa gambar JPEG
+// (PDF vektor) atau yang tidak bisa ditemukan (mis. di dalam object stream) menghasilkan ErrUnsupported
+// sehingga attachment tampil dengan placeholder; pakai driver pdftoppm untuk merendernya.
+type Builtin struct{}
+
+var _ Renderer = Builtin{}
+
+// minEmbeddedSize: gambar tersisip yang lebih kecil dari ini (logo, ikon) dilewati
+const minEmbeddedSize = 200
+
+func (Builtin) Render(ctx context.Context, data []byte, mimeType string) (image.Image, error) {
+	switch mimeType {
+	case "image/jpeg":
+		return decodeLimited(data, jpeg.DecodeConfig, jpeg.Decode)
+	case "image/png":
+		return decodeLimited(data, png.DecodeConfig, png.Decode)
+	case "application/pdf":
+		images, _ := parsePDF(data).firstPageImages()
+		var page []byte
+		largest := 0
+		for _, stream := range images {
+			cfg, err := jpeg.DecodeConfig(bytes.NewReader(stream))
+			if err != nil || cfg.Width < minEmbeddedSize || cfg.Height < minEmbeddedSize {
+				continue
+			}
+			if cfg.Width*cfg.Height > largest {
+				page, largest = stream, cfg.Width*cfg.Height
+			}
+		}
+		if page != nil {
+			return decodeLimited(page, jpeg.DecodeConfig, jpeg.Decode)
+		}
+	}
+	return nil, ErrUnsupported
+}
+
+// decodeLimited memeriksa dimensi dari header sebelum men-decode seluruh gambar
+func decodeLimited(data []byte, decodeConfig func(r io.Reader) (image.Config, error), decode func(r io.Reader) (image.Image, error)) (image.Image, error) {
+	cfg, err := decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrUnsupported
+	}
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+	return img, nil
+}
@@ -0,0 +1,368 @@
+// File: BACKEND-UAS/pgmongo/preview/pdf.go
+package preview
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// maxPDFDepth membatasi nesting dict/array dan kedalaman page tree (PDF rusak atau siklik)
+const maxPDFDepth = 32
+
+// pdfFile adalah indeks minimal objek PDF yang tidak terkompresi, cukup untuk menemukan halaman pertama
+// dan gambar yang dipakainya. Objek di dalam object stream (PDF 1.5+) tidak diindeks; halaman seperti itu
+// tidak bisa di-resolve dan tidak mendapat preview.
+type pdfFile struct {
+	data    []byte
+	offsets map[int]int // nomor objek -> posisi setelah keyword obj; definisi terakhir menang (incremental update)
+}
+
+func parsePDF(data []byte) *pdfFile {
+	f := &pdfFile{data: data, offsets: map[int]int{}}
+	for pos := 0; pos < len(data); {
+		i := bytes.Index(data[pos:], []byte("obj"))
+		if i < 0 {
+			break
+		}
+		at := pos + i
+		pos = at + len("obj")
+		num, ok := objectNumber(data, at)
+		if !ok || (pos < len(data) && !isDelimiter(data[pos])) {
+			continue
+		}
+		f.offsets[num] = pos
+		// lewati isi stream agar data biner (mis. JPEG) tidak dibaca sebagai objek
+		dict, rest := nextValue(data[pos:], 0)
+		if bytes.HasPrefix(dict, []byte("<<")) {
+			if _, after, ok := streamData(rest, f.length(dict)); ok {
+				pos = len(data) - len(after)
+			}
+		}
+	}
+	return f
+}
+
+// objectNumber membaca "<num> <gen> " tepat sebelum keyword obj di posisi at
+func objectNumber(data []byte, at int) (int, bool) {
+	var nums [2]int
+	end := at
+	for k := 1; k >= 0; k-- {
+		start := end
+		for start > 0 && isSpace(data[start-1]) {
+			start--
+		}
+		if start == end {
+			return 0, false
+		}
+		end = start
+		for start > 0 && data[start-1] >= '0' && data[start-1] <= '9' {
+			start--
+		}
+		if start == end || end-start > 10 {
+			return 0, false
+		}
+		nums[k], _ = strconv.Atoi(string(data[start:end]))
+		end = start
+	}
+	if end > 0 && !isDelimiter(data[end-1]) {
+		return 0, false
+	}
+	return nums[0], true
+}
+
+// firstPageImages mengembalikan isi image XObject /DCTDecode yang dipakai halaman pertama, sesuai urutan di
+// resource halaman. ok false jika halaman pertama tidak bisa ditemukan.
+func (f *pdfFile) firstPageImages() (images [][]byte, ok bool) {
+	resources, ok := f.firstPageResources()
+	if !ok {
+		return nil, false
+	}
+	xobject := f.resolve(resources["XObject"])
+	xobjects := dictEntries(xobject)
+	for _, key := range orderedKeys(xobject) {
+		num, isRef := refNumber(xobjects[key])
+		if !isRef {
+			continue
+		}
+		off, found := f.offsets[num]
+		if !found {
+			continue
+		}
+		dict, rest := nextValue(f.data[off:], 0)
+		entries := dictEntries(dict)
+		if string(entries["Subtype"]) != "/Image" || !bytes.Contains(f.resolve(entries["Filter"]), []byte("/DCTDecode")) {
+			continue
+		}
+		if stream, _, ok := streamData(rest, f.length(dict)); ok && bytes.HasPrefix(stream, []byte{0xFF, 0xD8}) {
+			images = append(images, stream)
+		}
+	}
+	return images, true
+}
+
+// firstPageResources menelusuri page tree dari catalog lewat /Kids pertama sampai ke /Page dan mengembalikan
+// resource halaman itu, termasuk yang diwariskan dari node /Pages di atasnya
+func (f *pdfFile) firstPageResources() (resources map[string][]byte, ok bool) {
+	node := f.resolve(dictEntries(f.catalog())["Pages"])
+	for depth := 0; depth < maxPDFDepth; depth++ {
+		entries := dictEntries(node)
+		if entries == nil {
+			return nil, false
+		}
+		if r := dictEntries(f.resolve(entries["Resources"])); r != nil {
+			resources = r
+		}
+		switch string(entries["Type"]) {
+		case "/Page":
+			return resources, true
+		case "/Pages":
+			kids := arrayValues(f.resolve(entries["Kids"]))
+			if len(kids) == 0 {
+				return nil, false
+			}
+			node = f.resolve(kids[0])
+		default:
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// catalog mengembalikan dict /Type /Catalog (yang terakhir jika ada incremental update)
+func (f *pdfFile) catalog() []byte {
+	var catalog []byte
+	last := -1
+	for _, off := range f.offsets {
+		dict, _ := nextValue(f.data[off:], 0)
+		if off > last && string(dictEntries(dict)["Type"]) == "/Catalog" {
+			catalog, last = dict, off
+		}
+	}
+	return catalog
+}
+
+// resolve mengikuti referensi "N G R"; nilai langsung dikembalikan apa adanya
+func (f *pdfFile) resolve(val []byte) []byte {
+	num, ok := refNumber(val)
+	if !ok {
+		return val
+	}
+	off, found := f.offsets[num]
+	if !found {
+		return nil
+	}
+	v, _ := nextValue(f.data[off:], 0)
+	return v
+}
+
+// length membaca /Length stream; -1 jika tidak ada atau bukan angka
+func (f *pdfFile) length(dict []byte) int {
+	n, err := strconv.Atoi(string(f.resolve(dictEntries(dict)["Length"])))
+	if err != nil || n < 0 {
+		return -1
+	}
+	return n
+}
+
+// streamData mengambil isi stream yang mengikuti dict. length dipakai jika cocok dengan posisi endstream,
+// jika tidak isi dipotong di endstream berikutnya.
+func streamData(rest []byte, length int) (stream, after []byte, ok bool) {
+	rest = skipSpace(rest)
+	if !bytes.HasPrefix(rest, []byte("stream")) {
+		return nil, rest, false
+	}
+	rest = rest[len("stream"):]
+	// keyword stream diikuti CRLF atau LF
+	rest = bytes.TrimPrefix(rest, []byte("\r"))
+	rest = bytes.TrimPrefix(rest, []byte("\n"))
+	if length >= 0 && length <= len(rest) && bytes.HasPrefix(skipSpace(rest[length:]), []byte("endstream")) {
+		return rest[:length], rest[length:], true
+	}
+	end := bytes.Index(rest, []byte("endstream"))
+	if end < 0 {
+		return nil, nil, false
+	}
+	return bytes.TrimRight(rest[:end], "\r\n"), rest[end:], true
+}
+
+// nextValue membaca satu nilai PDF (dict, array, string, name, angka, atau referensi "N G R") dari awal b.
+// val nil jika b tidak diawali nilai yang valid.
+func nextValue(b []byte, depth int) (val, rest []byte) {
+	b = skipSpace(b)
+	if len(b) == 0 || depth > maxPDFDepth {
+		return nil, b
+	}
+	start := b
+	switch {
+	case bytes.HasPrefix(b, []byte("<<")):
+		rest = b[2:]
+		for {
+			rest = skipSpace(rest)
+			if bytes.HasPrefix(rest, []byte(">>")) {
+				rest = rest[2:]
+				return start[:len(start)-len(rest)], rest
+			}
+			var v []byte
+			if v, rest = nextValue(rest, depth+1); v == nil {
+				return nil, b
+			}
+		}
+	case b[0] == '[':
+		rest = b[1:]
+		for {
+			rest = skipSpace(rest)
+			if len(rest) > 0 && rest[0] == ']' {
+				rest = rest[1:]
+				return start[:len(start)-len(rest)], rest
+			}
+			var v []byte
+			if v, rest = nextValue(rest, depth+1); v == nil {
+				return nil, b
+			}
+		}
+	case b[0] == '<':
+		end := bytes.IndexByte(b, '>')
+		if end < 0 {
+			return nil, b
+		}
+		return b[:end+1], b[end+1:]
+	case b[0] == '(':
+		nesting := 0
+		for i := 0; i < len(b); i++ {
+			switch b[i] {
+			case '\\':
+				i++
+			case '(':
+				nesting++
+			case ')':
+				if nesting--; nesting == 0 {
+					return b[:i+1], b[i+1:]
+				}
+			}
+		}
+		return nil, b
+	case b[0] == '/':
+		i := 1
+		for i < len(b) && !isDelimiter(b[i]) {
+			i++
+		}
+		return b[:i], b[i:]
+	case b[0] == ']' || b[0] == '>' || b[0] == ')':
+		return nil, b
+	}
+	token, rest := nextToken(b)
+	if len(token) == 0 {
+		return nil, b
+	}
+	// referensi tidak langsung: "<num> <gen> R"
+	if _, err := strconv.Atoi(string(token)); err == nil {
+		gen, afterGen := nextToken(skipSpace(rest))
+		r, afterR := nextToken(skipSpace(afterGen))
+		if _, err := strconv.Atoi(string(gen)); err == nil && string(r) == "R" {
+			return start[:len(start)-len(afterR)], afterR
+		}
+	}
+	return token, rest
+}
+
+func nextToken(b []byte) (token, rest []byte) {
+	i := 0
+	for i < len(b) && !isDelimiter(b[i]) {
+		i++
+	}
+	return b[:i], b[i:]
+}
+
+// dictEntries memecah dict menjadi key (tanpa "/") dan nilai mentahnya; nil jika val bukan dict
+func dictEntries(val []byte) map[string][]byte {
+	keys, values := dictPairs(val)
+	if keys == nil {
+		return nil
+	}
+	entries := make(map[string][]byte, len(keys))
+	for i, key := range keys {
+		entries[key] = values[i]
+	}
+	return entries
+}
+
+// orderedKeys mengembalikan key dict sesuai urutan di file
+func orderedKeys(val []byte) []string {
+	keys, _ := dictPairs(val)
+	return keys
+}
+
+func dictPairs(val []byte) (keys []string, values [][]byte) {
+	if !bytes.HasPrefix(val, []byte("<<")) {
+		return nil, nil
+	}
+	keys = []string{}
+	rest := val[2 : len(val)-2]
+	for {
+		key, r := nextValue(rest, 1)
+		if key == nil || key[0] != '/' {
+			return keys, values
+		}
+		v, r := nextValue(r, 1)
+		if v == nil {
+			return keys, values
+		}
+		keys = append(keys, string(key[1:]))
+		values = append(values, v)
+		rest = r
+	}
+}
+
+// arrayValues memecah array menjadi elemennya; nil jika val bukan array
+func arrayValues(val []byte) [][]byte {
+	if len(val) < 2 || val[0] != '[' {
+		return nil
+	}
+	var values [][]byte
+	rest := val[1 : len(val)-1]
+	for {
+		v, r := nextValue(rest, 1)
+		if v == nil {
+			return values
+		}
+		values = append(values, v)
+		rest = r
+	}
+}
+
+// refNumber membaca nomor objek dari referensi "N G R"
+func refNumber(val []byte) (int, bool) {
+	fields := bytes.Fields(val)
+	if len(fields) != 3 || string(fields[2]) != "R" {
+		return 0, false
+	}
+	num, err := strconv.Atoi(string(fields[0]))
+	return num, err == nil
+}
+
+func skipSpace(b []byte) []byte {
+	for len(b) > 0 {
+		switch {
+		case isSpace(b[0]):
+			b = b[1:]
+		case b[0] == '%':
+			// komentar sampai akhir baris
+			i := bytes.IndexAny(b, "\r\n")
+			if i < 0 {
+				return nil
+			}
+			b = b[i:]
+		default:
+			return b
+		}
+	}
+	return b
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isDelimiter(c byte) bool {
+	return isSpace(c) || bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
@@ -0,0 +1,67 @@
+// File: BACKEND-UAS/pgmongo/preview/pdftoppm.go
+package preview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Pdftoppm merender halaman pertama PDF dengan pdftoppm (poppler-utils) sebagai proses terpisah,
+// sehingga parser PDF tidak berjalan di dalam proses aplikasi. Gambar (JPEG/PNG) tetap lewat Builtin.
+type Pdftoppm struct {
+	path    string
+	timeout time.Duration
+}
+
+var _ Renderer = (*Pdftoppm)(nil)
+
+func NewPdftoppm(path string) (*Pdftoppm, error) {
+	if path == "" {
+		path = "pdftoppm"
+	}
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("preview: %w", err)
+	}
+	return &Pdftoppm{path: resolved, timeout: 30 * time.Second}, nil
+}
+
+func (p *Pdftoppm) Render(ctx context.Context, data []byte, mimeType string) (image.Image, error) {
+	if mimeType != "application/pdf" {
+		return Builtin{}.Render(ctx, data, mimeType)
+	}
+	dir, err := os.MkdirTemp("", "preview-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	in := filepath.Join(dir, "in.pdf")
+	if err := os.WriteFile(in, data, 0600); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	out := filepath.Join(dir, "page")
+	// hanya halaman 1, langsung diskalakan ke ukuran preview agar PDF berukuran halaman besar tetap ringan
+	cmd := exec.CommandContext(ctx, p.path, "-f", "1", "-l", "1", "-singlefile", "-png",
+		"-scale-to", strconv.Itoa(PreviewSize), in, out)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("preview: pdftoppm: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	rendered, err := os.ReadFile(out + ".png")
+	if err != nil {
+		return nil, err
+	}
+	return decodeLimited(rendered, png.DecodeConfig, png.Decode)
+}
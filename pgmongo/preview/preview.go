@@ -0,0 +1,94 @@
+// File: BACKEND-UAS/pgmongo/preview/preview.go
+package preview
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"strings"
+)
+
+// ErrUnsupported dikembalikan renderer saat jenis atau isi file tidak bisa dijadikan gambar
+var ErrUnsupported = errors.New("preview: unsupported content")
+
+// Renderer mengubah isi file menjadi gambar; untuk PDF yang dirender adalah halaman pertama
+type Renderer interface {
+	Render(ctx context.Context, data []byte, mimeType string) (image.Image, error)
+}
+
+// Ukuran sisi terpanjang (pixel) untuk thumbnail di daftar/antrian dan preview di halaman detail
+const (
+	ThumbnailSize = 256
+	PreviewSize   = 1024
+)
+
+// MaxPixels membatasi ukuran gambar yang mau di-decode (melindungi dari decompression bomb)
+const MaxPixels = 50_000_000
+
+// Config memilih renderer; Driver "builtin" (default), "pdftoppm" atau "none" untuk mematikan preview
+type Config struct {
+	Driver       string
+	PdftoppmPath string // default: dicari di PATH
+}
+
+// New membuat renderer sesuai cfg.Driver; "none" mengembalikan nil (preview dimatikan)
+func New(cfg Config) (Renderer, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "builtin":
+		return Builtin{}, nil
+	case "pdftoppm":
+		return NewPdftoppm(cfg.PdftoppmPath)
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("preview: unknown renderer %q", cfg.Driver)
+	}
+}
+
+// Scale memperkecil img (box filter) sehingga sisi terpanjangnya maxDim; gambar kecil tidak diperbesar
+func Scale(img image.Image, maxDim int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxDim && h <= maxDim {
+		return img
+	}
+	dw, dh := maxDim, h*maxDim/w
+	if h > w {
+		dw, dh = w*maxDim/h, maxDim
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		sy0, sy1 := b.Min.Y+y*h/dh, b.Min.Y+max((y+1)*h/dh, y*h/dh+1)
+		for x := 0; x < dw; x++ {
+			sx0, sx1 := b.Min.X+x*w/dw, b.Min.X+max((x+1)*w/dw, x*w/dw+1)
+			var r, g, bl, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n >> 8), G: uint8(g / n >> 8), B: uint8(bl / n >> 8), A: uint8(a / n >> 8)})
+		}
+	}
+	return dst
+}
+
+// EncodeJPEG meng-encode img sebagai JPEG; bagian transparan (PNG) diberi latar putih
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	flat := image.NewRGBA(img.Bounds())
+	draw.Draw(flat, flat.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,190 @@
+// File: BACKEND-UAS/pgmongo/receipt/receipt.go
+package receipt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	jwtpkg "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+)
+
+// Issuer adalah nilai klaim iss pada setiap receipt
+const Issuer = "BACKEND-UAS"
+
+// ErrInvalid dikembalikan Verify untuk receipt yang rusak, diubah, atau tidak ditandatangani kunci server ini
+var ErrInvalid = errors.New("receipt: invalid signature")
+
+// Claims adalah isi receipt verifikasi: reference prestasi, hash konten dokumen Mongo, hash setiap attachment,
+// verifikator dan waktu verifikasi
+type Claims struct {
+	AchievementID      string                    `json:"achievement_id"`
+	StudentID          string                    `json:"student_id"`
+	MongoAchievementID string                    `json:"mongo_achievement_id"`
+	ContentHash        string                    `json:"content_sha256"`
+	Attachments        []model.ReceiptAttachment `json:"attachments"`
+	VerifiedBy         string                    `json:"verified_by"`
+	VerifiedAt         time.Time                 `json:"verified_at"`
+	Version            int64                     `json:"version"`
+	KeyID              string                    `json:"-"` // kid kunci yang menandatangani, diisi Verify
+	jwtpkg.RegisteredClaims
+}
+
+// Signer menandatangani receipt dengan kunci aktif dan memeriksa receipt dari kunci aktif maupun kunci lama
+// (setelah rotasi) (JWS compact, EdDSA/Ed25519)
+type Signer struct {
+	key     ed25519.PrivateKey
+	keyID   string
+	retired []ed25519.PublicKey // hanya untuk Verify dan JWK Set
+}
+
+// NewSigner membuat signer dari seed Ed25519 32 byte; retired adalah kunci publik Ed25519 (32 byte) dari kunci
+// yang sudah dirotasi agar receipt lama tetap bisa diverifikasi
+func NewSigner(seed []byte, retired ...[]byte) (*Signer, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("receipt: signing key must be a %d byte Ed25519 seed, got %d bytes", ed25519.SeedSize, len(seed))
+	}
+	key := ed25519.NewKeyFromSeed(seed)
+	s := &Signer{key: key, keyID: keyID(key.Public().(ed25519.PublicKey))}
+	for _, pub := range retired {
+		if len(pub) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("receipt: retired key must be a %d byte Ed25519 public key, got %d bytes", ed25519.PublicKeySize, len(pub))
+		}
+		if keyID(pub) != s.keyID {
+			s.retired = append(s.retired, ed25519.PublicKey(pub))
+		}
+	}
+	return s, nil
+}
+
+func keyID(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
+
+// LoadOrCreateSeed membaca seed (base64) dari path. Jika file belum ada, seed acak dibuat dan disimpan dengan
+// izin 0600 sehingga kunci receipt tetap sama setelah restart tanpa diturunkan dari secret lain.
+func LoadOrCreateSeed(path string) (seed []byte, created bool, err error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		seed, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			return nil, false, fmt.Errorf("receipt: %s: %w", path, err)
+		}
+		return seed, false, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, false, err
+	}
+	seed = make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, false, err
+	}
+	// O_EXCL: instance lain yang start bersamaan dan sudah menulis file lebih dulu yang menang
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, fs.ErrExist) {
+		return LoadOrCreateSeed(path)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := f.WriteString(base64.StdEncoding.EncodeToString(seed) + "\n"); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, false, err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return nil, false, err
+	}
+	return seed, true, nil
+}
+
+// KeyID adalah sidik jari kunci publik aktif (header kid), untuk membedakan receipt setelah rotasi kunci
+func (s *Signer) KeyID() string { return s.keyID }
+
+func (s *Signer) PublicKey() ed25519.PublicKey { return s.key.Public().(ed25519.PublicKey) }
+
+// Sign menandatangani claims; iss, sub, iat dan jti diisi di sini
+func (s *Signer) Sign(claims Claims) (string, error) {
+	claims.Issuer = Issuer
+	claims.Subject = claims.AchievementID
+	claims.IssuedAt = jwtpkg.NewNumericDate(claims.VerifiedAt)
+	claims.ID = uuid.New().String()
+	token := jwtpkg.NewWithClaims(jwtpkg.SigningMethodEdDSA, claims)
+	token.Header["kid"] = s.keyID
+	return token.SignedString(s.key)
+}
+
+// Verify memeriksa tanda tangan (kunci aktif atau kunci lama) dan issuer lalu mengembalikan claims;
+// receipt tidak kedaluwarsa
+func (s *Signer) Verify(receipt string) (*Claims, error) {
+	claims := &Claims{}
+	var kid string
+	_, err := jwtpkg.ParseWithClaims(receipt, claims, func(token *jwtpkg.Token) (interface{}, error) {
+		kid, _ = token.Header["kid"].(string)
+		if pub := s.publicKey(kid); pub != nil {
+			return pub, nil
+		}
+		return nil, ErrInvalid
+	}, jwtpkg.WithValidMethods([]string{jwtpkg.SigningMethodEdDSA.Alg()}), jwtpkg.WithIssuer(Issuer))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	claims.KeyID = kid
+	return claims, nil
+}
+
+// publicKey mencari kunci publik untuk kid; nil jika kid bukan milik server ini
+func (s *Signer) publicKey(kid string) ed25519.PublicKey {
+	if kid == s.keyID {
+		return s.PublicKey()
+	}
+	for _, pub := range s.retired {
+		if keyID(pub) == kid {
+			return pub
+		}
+	}
+	return nil
+}
+
+// JWK adalah kunci publik dalam format JSON Web Key (RFC 8037) agar receipt bisa diperiksa offline
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKs mengembalikan kunci aktif diikuti kunci lama, agar receipt sebelum rotasi tetap bisa diperiksa offline
+func (s *Signer) JWKs() []JWK {
+	keys := []JWK{newJWK(s.PublicKey())}
+	for _, pub := range s.retired {
+		keys = append(keys, newJWK(pub))
+	}
+	return keys
+}
+
+func newJWK(pub ed25519.PublicKey) JWK {
+	return JWK{
+		Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(pub),
+		Kid: keyID(pub), Use: "sig", Alg: jwtpkg.SigningMethodEdDSA.Alg(),
+	}
+}
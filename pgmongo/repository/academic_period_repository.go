@@ -0,0 +1,65 @@
+// File: BACKEND-UAS/pgmongo/repository/academic_period_repository.go
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+)
+
+type AcademicPeriodRepository interface {
+	ListPeriods() ([]model.AcademicPeriod, error)
+	CreatePeriod(period *model.AcademicPeriod) error
+	DeletePeriod(id uuid.UUID) error
+}
+
+type AcademicPeriodRepositoryImpl struct {
+	db *sql.DB
+}
+
+var _ AcademicPeriodRepository = (*AcademicPeriodRepositoryImpl)(nil)
+
+func NewAcademicPeriodRepository(db *sql.DB) AcademicPeriodRepository {
+	return &AcademicPeriodRepositoryImpl{db: db}
+}
+
+// ListPeriods returns the whole calendar ordered by start date
+func (r *AcademicPeriodRepositoryImpl) ListPeriods() ([]model.AcademicPeriod, error) {
+	rows, err := r.db.Query(`SELECT id, code, academic_year, semester, start_date, end_date, created_at FROM academic_periods ORDER BY start_date`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := []model.AcademicPeriod{}
+	for rows.Next() {
+		var (
+			p  model.AcademicPeriod
+			id string
+		)
+		if err := rows.Scan(&id, &p.Code, &p.AcademicYear, &p.Semester, &p.StartDate, &p.EndDate, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		p.ID = parseUUID(id)
+		periods = append(periods, p)
+	}
+	return periods, rows.Err()
+}
+
+func (r *AcademicPeriodRepositoryImpl) CreatePeriod(period *model.AcademicPeriod) error {
+	_, err := r.db.Exec(
+		`INSERT INTO academic_periods (id, code, academic_year, semester, start_date, end_date, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		period.ID.String(), period.Code, period.AcademicYear, period.Semester, period.StartDate, period.EndDate, period.CreatedAt,
+	)
+	return err
+}
+
+func (r *AcademicPeriodRepositoryImpl) DeletePeriod(id uuid.UUID) error {
+	res, err := r.db.Exec(`DELETE FROM academic_periods WHERE id = $1`, id.String())
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, sql.ErrNoRows)
+}
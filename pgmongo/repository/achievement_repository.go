@@ -3,7 +3,9 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,10 +13,59 @@ import (
 	"BACKEND-UAS/pgmongo/model"
 )
 
+// AchievementPostgresRepository adalah kontrak achievement_references yang dipakai AchievementService
+type AchievementPostgresRepository interface {
+	GetAchievementReferencesByStudentIDs(studentIDs []uuid.UUID, status *string, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
+	GetAllAchievementReferences(status *string, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
+	GetAchievementReferenceByID(id uuid.UUID) (*model.AchievementReference, error)
+	CreateAchievementReference(ref *model.AchievementReference) error
+	SoftDeleteAchievementReference(id uuid.UUID, untouchedSince time.Time) error
+	SubmitAchievement(id uuid.UUID, expectedStatus string, version int64) error
+	VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUID, rejectionNote *string, version int64) error
+	BumpVersion(id uuid.UUID, expectedStatus string, version int64) error
+	RevokeAchievement(id uuid.UUID, reason string, version int64) error
+	WithdrawAchievement(id uuid.UUID, version int64) error
+	ListSubmittedAchievementReferences(filter model.SubmittedReferenceFilter, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
+	GetClaimedStudentIDs(reviewerID uuid.UUID, claimedSince time.Time) ([]uuid.UUID, error)
+	ClaimAchievement(id, reviewerID uuid.UUID, staleBefore time.Time) error
+	AssignReviewer(id, reviewerID uuid.UUID) error
+	ReleaseClaim(id uuid.UUID) error
+	GetStudentByUserID(userID uuid.UUID) (*model.Student, error)
+	GetLecturerByUserID(userID uuid.UUID) (*model.Lecturer, error)
+	GetStudentIDsByAdvisor(advisorID uuid.UUID) ([]uuid.UUID, error)
+	CountStudentsByIDs(ids []uuid.UUID) (int, error)
+	CreateTeamMemberReference(primaryID, studentID uuid.UUID) (*model.AchievementReference, error)
+	GetAchievementReferencesByMongoIDs(mongoIDs []string) ([]model.AchievementReference, error)
+	FindAchievementReferences(filter model.ReferenceFilter) ([]model.AchievementReference, error)
+	CountAchievementReferences(filter model.ReferenceFilter) (int64, error)
+	ListAchievementReferences(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
+	ListAchievementReferencesByCursor(filter model.ReferenceFilter, page model.CursorPage) (*model.CursorResponse[model.AchievementReference], error)
+	TouchAchievementReference(id uuid.UUID) error
+	FindStaleDrafts(untouchedSince time.Time, unremindedOnly bool) ([]model.AchievementReference, error)
+	FindAbandonedDrafts(untouchedSince, remindedBefore time.Time) ([]model.AchievementReference, error)
+	MarkDraftReminded(id uuid.UUID) error
+	SaveVerificationReceipt(id uuid.UUID, receipt string) error
+	GetVerificationReceipt(id uuid.UUID) (string, error)
+}
+
+// ErrClaimConflict dikembalikan saat achievement sedang di-claim reviewer lain
+// atau sudah tidak berstatus submitted.
+var ErrClaimConflict = errors.New("achievement is claimed by another reviewer or no longer submitted")
+
+// ErrVersionConflict dikembalikan saat update bersyarat (status + version) tidak mengenai baris apa pun,
+// artinya data sudah diubah pihak lain sejak dibaca.
+var ErrVersionConflict = errors.New("achievement was modified concurrently")
+
+// ErrDraftChanged dikembalikan saat soft delete draft tidak mengenai baris apa pun:
+// achievement sudah bukan draft atau diubah setelah batas waktu yang diberikan.
+var ErrDraftChanged = errors.New("achievement is no longer an untouched draft")
+
 type AchievementRepository struct {
 	db *sql.DB
 }
 
+var _ AchievementPostgresRepository = (*AchievementRepository)(nil)
+
 func NewAchievementRepository(db *sql.DB) *AchievementRepository {
 	return &AchievementRepository{db: db}
 }
@@ -27,60 +78,289 @@ func parseUUID(s string) uuid.UUID {
 	return id
 }
 
+// achievementReferenceSelect dipakai semua query list/detail agar kolom & urutan scan selalu sama
+const achievementReferenceSelect = `
+		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, 
+		       ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at, ar.claimed_by, ar.claimed_at, ar.version,
+		       ar.primary_reference_id,
+		       s.id, s.user_id, s.student_id, s.program_study, s.academic_year, s.created_at,
+		       u.id, u.username, u.email, u.full_name, u.role_id, u.is_active, u.created_at, u.updated_at
+		FROM achievement_references ar
+		JOIN students s ON ar.student_id = s.id
+		JOIN users u ON s.user_id = u.id
+`
+
+// rowScanner covers both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // ===================== SCAN ROWS =====================
+func scanAchievementReference(row rowScanner) (*model.AchievementReference, error) {
+	var ref model.AchievementReference
+	var s model.Student
+
+	var (
+		arID          string
+		arStudentID   string
+		verifiedByStr sql.NullString
+		rejectionNote sql.NullString
+		submittedAt   sql.NullTime
+		verifiedAt    sql.NullTime
+		claimedByStr  sql.NullString
+		claimedAt     sql.NullTime
+		primaryRefStr sql.NullString
+		sID           string
+		sUserID       string
+	)
+
+	err := row.Scan(
+		&arID, &arStudentID, &ref.MongoAchievementID, &ref.Status, &submittedAt, &verifiedAt,
+		&verifiedByStr, &rejectionNote, &ref.CreatedAt, &ref.UpdatedAt, &claimedByStr, &claimedAt, &ref.Version,
+		&primaryRefStr,
+		&sID, &sUserID, &s.StudentID, &s.ProgramStudy, &s.AcademicYear, &s.CreatedAt,
+		&s.User.ID, &s.User.Username, &s.User.Email, &s.User.FullName, &s.User.RoleID,
+		&s.User.IsActive, &s.User.CreatedAt, &s.User.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ref.ID = parseUUID(arID)
+	ref.StudentID = parseUUID(arStudentID)
+	s.ID = parseUUID(sID)
+	s.UserID = parseUUID(sUserID)
+	ref.Student = s
+
+	if submittedAt.Valid {
+		ref.SubmittedAt = &submittedAt.Time
+	}
+	if verifiedAt.Valid {
+		ref.VerifiedAt = &verifiedAt.Time
+	}
+	if verifiedByStr.Valid {
+		vb := parseUUID(verifiedByStr.String)
+		if vb != uuid.Nil {
+			ref.VerifiedBy = &vb
+		}
+	}
+	if rejectionNote.Valid {
+		ref.RejectionNote = rejectionNote.String
+	}
+	if claimedByStr.Valid {
+		cb := parseUUID(claimedByStr.String)
+		if cb != uuid.Nil {
+			ref.ClaimedBy = &cb
+		}
+	}
+	if claimedAt.Valid {
+		ref.ClaimedAt = &claimedAt.Time
+	}
+	if primaryRefStr.Valid {
+		pr := parseUUID(primaryRefStr.String)
+		if pr != uuid.Nil {
+			ref.PrimaryReferenceID = &pr
+		}
+	}
+
+	return &ref, nil
+}
+
 func (r *AchievementRepository) scanAchievementRows(rows *sql.Rows) ([]model.AchievementReference, error) {
-	var refs []model.AchievementReference
+	refs := []model.AchievementReference{}
 	for rows.Next() {
-		var ref model.AchievementReference
-		var s model.Student
-
-		var (
-			arID             string
-			arStudentID      string
-			verifiedByStr    sql.NullString
-			rejectionNote    sql.NullString
-			submittedAt      sql.NullTime
-			verifiedAt       sql.NullTime
-			sID              string
-			sUserID          string
-		)
-
-		err := rows.Scan(
-			&arID, &arStudentID, &ref.MongoAchievementID, &ref.Status, &submittedAt, &verifiedAt,
-			&verifiedByStr, &rejectionNote, &ref.CreatedAt, &ref.UpdatedAt,
-			&sID, &sUserID, &s.StudentID, &s.ProgramStudy, &s.AcademicYear, &s.CreatedAt,
-			&s.User.ID, &s.User.Username, &s.User.Email, &s.User.FullName, &s.User.RoleID,
-			&s.User.IsActive, &s.User.CreatedAt, &s.User.UpdatedAt,
-		)
+		ref, err := scanAchievementReference(rows)
 		if err != nil {
 			return nil, err
 		}
+		refs = append(refs, *ref)
+	}
+	return refs, rows.Err()
+}
+
+// ===================== FILTERED LIST =====================
 
-		ref.ID = parseUUID(arID)
-		ref.StudentID = parseUUID(arStudentID)
-		s.ID = parseUUID(sID)
-		s.UserID = parseUUID(sUserID)
-		ref.Student = s
+// buildReferenceWhere menyusun klausa WHERE (tanpa kata WHERE) beserta argumennya dari ReferenceFilter
+func buildReferenceWhere(f model.ReferenceFilter) (string, []interface{}) {
+	conds := []string{"ar.status != 'deleted'"}
+	args := []interface{}{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
 
-		if submittedAt.Valid {
-			ref.SubmittedAt = &submittedAt.Time
+	if f.StudentIDs != nil {
+		ids := make([]string, len(f.StudentIDs))
+		for i, id := range f.StudentIDs {
+			ids[i] = id.String()
 		}
-		if verifiedAt.Valid {
-			ref.VerifiedAt = &verifiedAt.Time
+		add("ar.student_id = ANY($%d::uuid[])", pq.Array(ids))
+	}
+	if f.MongoIDs != nil {
+		add("ar.mongo_achievement_id = ANY($%d)", pq.Array(f.MongoIDs))
+	}
+	if f.Status != nil {
+		add("ar.status = $%d", *f.Status)
+	}
+	if f.StudentID != nil {
+		add("ar.student_id = $%d", f.StudentID.String())
+	}
+	if f.ProgramStudy != "" {
+		add("s.program_study ILIKE $%d", f.ProgramStudy)
+	}
+	if f.AcademicYear != "" {
+		add("s.academic_year = $%d", f.AcademicYear)
+	}
+	if f.AdvisorID != nil {
+		add("s.advisor_id = $%d", f.AdvisorID.String())
+	}
+	if f.CreatedFrom != nil {
+		add("ar.created_at >= $%d", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		add("ar.created_at < $%d", *f.CreatedTo)
+	}
+	if f.SubmittedFrom != nil {
+		add("ar.submitted_at >= $%d", *f.SubmittedFrom)
+	}
+	if f.SubmittedTo != nil {
+		add("ar.submitted_at < $%d", *f.SubmittedTo)
+	}
+	if f.VerifiedFrom != nil {
+		add("ar.verified_at >= $%d", *f.VerifiedFrom)
+	}
+	if f.VerifiedTo != nil {
+		add("ar.verified_at < $%d", *f.VerifiedTo)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// FindAchievementReferences returns all references matching the filter (tanpa pagination; batasi lewat MongoIDs/StudentIDs)
+func (r *AchievementRepository) FindAchievementReferences(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+	if (filter.StudentIDs != nil && len(filter.StudentIDs) == 0) || (filter.MongoIDs != nil && len(filter.MongoIDs) == 0) {
+		return []model.AchievementReference{}, nil
+	}
+	where, args := buildReferenceWhere(filter)
+	query := achievementReferenceSelect + " WHERE " + where + " ORDER BY ar.created_at DESC"
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanAchievementRows(rows)
+}
+
+// CountAchievementReferences menghitung reference yang cocok dengan filter
+func (r *AchievementRepository) CountAchievementReferences(filter model.ReferenceFilter) (int64, error) {
+	if (filter.StudentIDs != nil && len(filter.StudentIDs) == 0) || (filter.MongoIDs != nil && len(filter.MongoIDs) == 0) {
+		return 0, nil
+	}
+	where, args := buildReferenceWhere(filter)
+	var total int64
+	countQuery := `SELECT COUNT(*) FROM achievement_references ar JOIN students s ON ar.student_id = s.id WHERE ` + where
+	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// referenceSortColumns memetakan field sort ke kolom Postgres; field konten Mongo diabaikan di sini
+var referenceSortColumns = map[string]string{
+	model.SortCreatedAt:   "ar.created_at",
+	model.SortSubmittedAt: "ar.submitted_at",
+	model.SortVerifiedAt:  "ar.verified_at",
+	model.SortStatus:      "ar.status",
+}
+
+func buildReferenceOrder(sort []model.SortField) string {
+	parts := []string{}
+	for _, f := range sort {
+		col, ok := referenceSortColumns[f.Field]
+		if !ok {
+			continue
+		}
+		if f.Desc {
+			parts = append(parts, col+" DESC NULLS LAST")
+		} else {
+			parts = append(parts, col+" ASC NULLS LAST")
 		}
-		if verifiedByStr.Valid {
-			vb := parseUUID(verifiedByStr.String)
-			if vb != uuid.Nil {
-				ref.VerifiedBy = &vb
-			}
+	}
+	// tie-breaker agar urutan stabil antar halaman
+	parts = append(parts, "ar.created_at DESC", "ar.id")
+	return strings.Join(parts, ", ")
+}
+
+// ListAchievementReferences returns one page of references matching the filter, sorted by reference columns
+func (r *AchievementRepository) ListAchievementReferences(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+	resp := &model.PaginatedResponse[model.AchievementReference]{Data: []model.AchievementReference{}, Page: page, Limit: limit}
+	if (filter.StudentIDs != nil && len(filter.StudentIDs) == 0) || (filter.MongoIDs != nil && len(filter.MongoIDs) == 0) {
+		return resp, nil
+	}
+	total, err := r.CountAchievementReferences(filter)
+	if err != nil {
+		return nil, err
+	}
+	resp.Total = total
+
+	where, args := buildReferenceWhere(filter)
+
+	query := achievementReferenceSelect + " WHERE " + where + " ORDER BY " + buildReferenceOrder(sort) +
+		fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rows, err := r.db.Query(query, append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if resp.Data, err = r.scanAchievementRows(rows); err != nil {
+		return nil, err
+	}
+	resp.TotalPages = int((resp.Total + int64(limit) - 1) / int64(limit))
+	return resp, nil
+}
+
+// ListAchievementReferencesByCursor is the keyset variant of ListAchievementReferences, ordered by (created_at, id) DESC
+func (r *AchievementRepository) ListAchievementReferencesByCursor(filter model.ReferenceFilter, page model.CursorPage) (*model.CursorResponse[model.AchievementReference], error) {
+	resp := &model.CursorResponse[model.AchievementReference]{Data: []model.AchievementReference{}, Limit: page.Limit}
+	if (filter.StudentIDs != nil && len(filter.StudentIDs) == 0) || (filter.MongoIDs != nil && len(filter.MongoIDs) == 0) {
+		if page.WithTotal {
+			resp.Total = new(int64)
 		}
-		if rejectionNote.Valid {
-			ref.RejectionNote = rejectionNote.String
+		return resp, nil
+	}
+	where, args := buildReferenceWhere(filter)
+
+	if page.WithTotal {
+		var total int64
+		countQuery := `SELECT COUNT(*) FROM achievement_references ar JOIN students s ON ar.student_id = s.id WHERE ` + where
+		if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
+			return nil, err
 		}
+		resp.Total = &total
+	}
 
-		refs = append(refs, ref)
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID.String())
+		where += fmt.Sprintf(" AND (ar.created_at, ar.id) < ($%d, $%d::uuid)", len(args)-1, len(args))
 	}
-	return refs, nil
+	// ambil satu baris lebih untuk mengetahui apakah masih ada halaman berikutnya
+	query := achievementReferenceSelect + " WHERE " + where +
+		fmt.Sprintf(" ORDER BY ar.created_at DESC, ar.id DESC LIMIT $%d", len(args)+1)
+	rows, err := r.db.Query(query, append(args, page.Limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	data, err := r.scanAchievementRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > page.Limit {
+		data = data[:page.Limit]
+		last := data[len(data)-1]
+		resp.NextCursor = model.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	resp.Data = data
+	return resp, nil
 }
 
 // ===================== LIST BY STUDENT IDS =====================
@@ -110,14 +390,7 @@ func (r *AchievementRepository) GetAchievementReferencesByStudentIDs(studentIDs
 
 	// SELECT
 	offset := (page - 1) * limit
-	query := `
-		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, 
-		       ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at,
-		       s.id, s.user_id, s.student_id, s.program_study, s.academic_year, s.created_at,
-		       u.id, u.username, u.email, u.full_name, u.role_id, u.is_active, u.created_at, u.updated_at
-		FROM achievement_references ar
-		JOIN students s ON ar.student_id = s.id
-		JOIN users u ON s.user_id = u.id
+	query := achievementReferenceSelect + `
 		WHERE ar.student_id = ANY($1::uuid[]) AND ar.status != 'deleted'
 	`
 	args := []interface{}{pq.Array(ids)}
@@ -172,14 +445,7 @@ func (r *AchievementRepository) GetAllAchievementReferences(status *string, page
 	}
 
 	offset := (page - 1) * limit
-	query := `
-		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, 
-		       ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at,
-		       s.id, s.user_id, s.student_id, s.program_study, s.academic_year, s.created_at,
-		       u.id, u.username, u.email, u.full_name, u.role_id, u.is_active, u.created_at, u.updated_at
-		FROM achievement_references ar
-		JOIN students s ON ar.student_id = s.id
-		JOIN users u ON s.user_id = u.id
+	query := achievementReferenceSelect + `
 		WHERE ar.status != 'deleted'
 	`
 	args := []interface{}{}
@@ -221,104 +487,384 @@ func (r *AchievementRepository) GetAllAchievementReferences(status *string, page
 
 // ===================== DETAIL =====================
 func (r *AchievementRepository) GetAchievementReferenceByID(id uuid.UUID) (*model.AchievementReference, error) {
-	query := `
-		SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status, ar.submitted_at, ar.verified_at, 
-		       ar.verified_by, ar.rejection_note, ar.created_at, ar.updated_at,
-		       s.id, s.user_id, s.student_id, s.program_study, s.academic_year, s.created_at,
-		       u.id, u.username, u.email, u.full_name, u.role_id, u.is_active, u.created_at, u.updated_at
-		FROM achievement_references ar
-		JOIN students s ON ar.student_id = s.id
-		JOIN users u ON s.user_id = u.id
+	query := achievementReferenceSelect + `
 		WHERE ar.id = $1
 	`
-	row := r.db.QueryRow(query, id.String())
+	return scanAchievementReference(r.db.QueryRow(query, id.String()))
+}
 
-	var ref model.AchievementReference
-	var s model.Student
+// ===================== CRUD =====================
+func (r *AchievementRepository) CreateAchievementReference(ref *model.AchievementReference) error {
+	if ref.Version == 0 {
+		ref.Version = 1
+	}
+	_, err := r.db.Exec(`
+		INSERT INTO achievement_references (id, student_id, mongo_achievement_id, status, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		ref.ID.String(), ref.StudentID.String(), ref.MongoAchievementID, ref.Status, ref.CreatedAt, ref.UpdatedAt, ref.Version)
+	return err
+}
+
+// SoftDeleteAchievementReference menghapus draft yang tidak diubah setelah untouchedSince;
+// ErrDraftChanged jika statusnya sudah berubah atau draft disentuh sejak itu.
+func (r *AchievementRepository) SoftDeleteAchievementReference(id uuid.UUID, untouchedSince time.Time) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references SET status = 'deleted', updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND status = 'draft' AND updated_at <= $2`, id.String(), untouchedSince)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(res, ErrDraftChanged); err != nil {
+		return err
+	}
+	return r.syncTeamReferences(id)
+}
+
+// GetAchievementReferencesByMongoIDs returns the primary (non-deleted) references of the given documents
+func (r *AchievementRepository) GetAchievementReferencesByMongoIDs(mongoIDs []string) ([]model.AchievementReference, error) {
+	if len(mongoIDs) == 0 {
+		return []model.AchievementReference{}, nil
+	}
+	query := achievementReferenceSelect + `
+		WHERE ar.mongo_achievement_id = ANY($1) AND ar.primary_reference_id IS NULL AND ar.status != 'deleted'
+	`
+	rows, err := r.db.Query(query, pq.Array(mongoIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return r.scanAchievementRows(rows)
+}
+
+// ===================== TEAM =====================
+
+// syncTeamReferences menyalin status reference utama (ketua) ke reference anggota tim,
+// sehingga list & statistik tiap anggota mengikuti workflow verifikasi yang sama
+func (r *AchievementRepository) syncTeamReferences(primaryID uuid.UUID) error {
+	_, err := r.db.Exec(`
+		UPDATE achievement_references m
+		SET status = p.status, submitted_at = p.submitted_at, verified_at = p.verified_at,
+		    verified_by = p.verified_by, rejection_note = p.rejection_note, updated_at = p.updated_at,
+		    version = m.version + 1
+		FROM achievement_references p
+		WHERE p.id = $1 AND m.primary_reference_id = p.id`,
+		primaryID.String())
+	return err
+}
+
+// CreateTeamMemberReference membuat reference anggota yang menunjuk ke reference ketua, dengan status yang sama
+func (r *AchievementRepository) CreateTeamMemberReference(primaryID, studentID uuid.UUID) (*model.AchievementReference, error) {
+	id := uuid.New()
+	_, err := r.db.Exec(`
+		INSERT INTO achievement_references
+		    (id, student_id, mongo_achievement_id, status, submitted_at, verified_at, verified_by, rejection_note,
+		     created_at, updated_at, version, primary_reference_id)
+		SELECT $1, $2, p.mongo_achievement_id, p.status, p.submitted_at, p.verified_at, p.verified_by, p.rejection_note,
+		       NOW(), NOW(), 1, p.id
+		FROM achievement_references p
+		WHERE p.id = $3 AND p.primary_reference_id IS NULL`,
+		id.String(), studentID.String(), primaryID.String())
+	if err != nil {
+		return nil, err
+	}
+	return r.GetAchievementReferenceByID(id)
+}
+
+func (r *AchievementRepository) CountStudentsByIDs(ids []uuid.UUID) (int, error) {
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		strIDs[i] = id.String()
+	}
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM students WHERE id = ANY($1::uuid[])`, pq.Array(strIDs)).Scan(&count)
+	return count, err
+}
+
+// SubmitAchievement hanya berhasil jika status & version masih sama dengan yang dibaca client
+func (r *AchievementRepository) SubmitAchievement(id uuid.UUID, expectedStatus string, version int64) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references
+		SET status = 'submitted', submitted_at = NOW(), updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND status = $2 AND version = $3`,
+		id.String(), expectedStatus, version)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(res, ErrVersionConflict); err != nil {
+		return err
+	}
+	return r.syncTeamReferences(id)
+}
+
+func (r *AchievementRepository) VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUID, rejectionNote *string, version int64) error {
+	now := time.Now()
 	var (
-		arID          string
-		arStudentID   string
-		verifiedByStr sql.NullString
-		rejectionNote sql.NullString
-		submittedAt   sql.NullTime
-		verifiedAt    sql.NullTime
-		sID           string
-		sUserID       string
+		res sql.Result
+		err error
 	)
+	if rejectionNote != nil && *rejectionNote != "" {
+		res, err = r.db.Exec(`
+			UPDATE achievement_references 
+			SET status = 'rejected', rejection_note = $1, verified_at = $2, updated_at = $3,
+			    claimed_by = NULL, claimed_at = NULL, version = version + 1
+			WHERE id = $4 AND status = 'submitted' AND version = $5`,
+			*rejectionNote, now, now, id.String(), version)
+	} else {
+		res, err = r.db.Exec(`
+			UPDATE achievement_references 
+			SET status = 'verified', verified_by = $1, verified_at = $2, updated_at = $3,
+			    claimed_by = NULL, claimed_at = NULL, version = version + 1
+			WHERE id = $4 AND status = 'submitted' AND version = $5`,
+			verifiedBy.String(), now, now, id.String(), version)
+	}
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(res, ErrVersionConflict); err != nil {
+		return err
+	}
+	return r.syncTeamReferences(id)
+}
 
-	err := row.Scan(
-		&arID, &arStudentID, &ref.MongoAchievementID, &ref.Status, &submittedAt, &verifiedAt,
-		&verifiedByStr, &rejectionNote, &ref.CreatedAt, &ref.UpdatedAt,
-		&sID, &sUserID, &s.StudentID, &s.ProgramStudy, &s.AcademicYear, &s.CreatedAt,
-		&s.User.ID, &s.User.Username, &s.User.Email, &s.User.FullName, &s.User.RoleID,
-		&s.User.IsActive, &s.User.CreatedAt, &s.User.UpdatedAt,
-	)
+// BumpVersion menaikkan version untuk perubahan konten (dokumen Mongo) tanpa mengubah status
+func (r *AchievementRepository) BumpVersion(id uuid.UUID, expectedStatus string, version int64) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references
+		SET version = version + 1, updated_at = NOW()
+		WHERE id = $1 AND status = $2 AND version = $3`,
+		id.String(), expectedStatus, version)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	return expectOneRow(res, ErrVersionConflict)
+}
 
-	ref.ID = parseUUID(arID)
-	ref.StudentID = parseUUID(arStudentID)
-	s.ID = parseUUID(sID)
-	s.UserID = parseUUID(sUserID)
-	ref.Student = s
+// RevokeAchievement mencabut verifikasi; alasan disimpan di rejection_note agar terlihat oleh mahasiswa.
+// verified_by/verified_at dikosongkan (verifikator tetap tercatat di status history Mongo).
+func (r *AchievementRepository) RevokeAchievement(id uuid.UUID, reason string, version int64) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references
+		SET status = 'revoked', rejection_note = $1, verified_by = NULL, verified_at = NULL,
+		    updated_at = NOW(), version = version + 1
+		WHERE id = $2 AND status = 'verified' AND version = $3`,
+		reason, id.String(), version)
+	if err != nil {
+		return err
+	}
+	if err := expectOneRow(res, ErrVersionConflict); err != nil {
+		return err
+	}
+	return r.syncTeamReferences(id)
+}
 
-	if submittedAt.Valid {
-		ref.SubmittedAt = &submittedAt.Time
+// WithdrawAchievement mengembalikan submission ke draft dan melepas claim reviewer
+func (r *AchievementRepository) WithdrawAchievement(id uuid.UUID, version int64) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references
+		SET status = 'draft', submitted_at = NULL, claimed_by = NULL, claimed_at = NULL,
+		    updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND status = 'submitted' AND version = $2`,
+		id.String(), version)
+	if err != nil {
+		return err
 	}
-	if verifiedAt.Valid {
-		ref.VerifiedAt = &verifiedAt.Time
+	if err := expectOneRow(res, ErrVersionConflict); err != nil {
+		return err
 	}
-	if verifiedByStr.Valid {
-		vb := parseUUID(verifiedByStr.String)
-		if vb != uuid.Nil {
-			ref.VerifiedBy = &vb
+	return r.syncTeamReferences(id)
+}
+
+// ===================== REVIEW QUEUE =====================
+
+// ListSubmittedAchievementReferences returns one page of the verification queue, oldest submission first.
+// Filter status, mahasiswa bimbingan dan claim dijalankan di Postgres; ClaimedBy menambahkan item yang di-claim user tsb.
+func (r *AchievementRepository) ListSubmittedAchievementReferences(filter model.SubmittedReferenceFilter, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+	resp := &model.PaginatedResponse[model.AchievementReference]{Data: []model.AchievementReference{}, Page: page, Limit: limit}
+	if filter.MongoIDs != nil && len(filter.MongoIDs) == 0 {
+		return resp, nil
+	}
+	where := "ar.status = 'submitted' AND ar.primary_reference_id IS NULL"
+	args := []interface{}{}
+	if filter.StudentIDs != nil {
+		ids := make([]string, len(filter.StudentIDs))
+		for i, id := range filter.StudentIDs {
+			ids[i] = id.String()
+		}
+		args = append(args, pq.Array(ids))
+		scope := fmt.Sprintf("ar.student_id = ANY($%d::uuid[])", len(args))
+		if filter.ClaimedBy != nil {
+			args = append(args, filter.ClaimedBy.String(), filter.ClaimedSince)
+			scope = fmt.Sprintf("(%s OR (ar.claimed_by = $%d AND ar.claimed_at >= $%d))", scope, len(args)-1, len(args))
 		}
+		where += " AND " + scope
 	}
-	if rejectionNote.Valid {
-		ref.RejectionNote = rejectionNote.String
+	if filter.MongoIDs != nil {
+		args = append(args, pq.Array(filter.MongoIDs))
+		where += fmt.Sprintf(" AND ar.mongo_achievement_id = ANY($%d)", len(args))
 	}
 
-	return &ref, nil
+	countQuery := `SELECT COUNT(*) FROM achievement_references ar WHERE ` + where
+	if err := r.db.QueryRow(countQuery, args...).Scan(&resp.Total); err != nil {
+		return nil, err
+	}
+	if resp.Total == 0 {
+		return resp, nil
+	}
+
+	query := achievementReferenceSelect + " WHERE " + where + " ORDER BY ar.submitted_at ASC, ar.created_at ASC" +
+		fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	rows, err := r.db.Query(query, append(args, limit, (page-1)*limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if resp.Data, err = r.scanAchievementRows(rows); err != nil {
+		return nil, err
+	}
+	resp.TotalPages = int((resp.Total + int64(limit) - 1) / int64(limit))
+	return resp, nil
 }
 
-// ===================== CRUD =====================
-func (r *AchievementRepository) CreateAchievementReference(ref *model.AchievementReference) error {
-	_, err := r.db.Exec(`
-		INSERT INTO achievement_references (id, student_id, mongo_achievement_id, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)`,
-		ref.ID.String(), ref.StudentID.String(), ref.MongoAchievementID, ref.Status, ref.CreatedAt, ref.UpdatedAt)
-	return err
+// GetClaimedStudentIDs mengembalikan mahasiswa pemilik achievement submitted yang claim-nya masih aktif dipegang reviewerID
+func (r *AchievementRepository) GetClaimedStudentIDs(reviewerID uuid.UUID, claimedSince time.Time) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(`
+		SELECT DISTINCT student_id FROM achievement_references
+		WHERE status = 'submitted' AND primary_reference_id IS NULL AND claimed_by = $1 AND claimed_at >= $2
+	`, reviewerID.String(), claimedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var idStr string
+		if err := rows.Scan(&idStr); err != nil {
+			return nil, err
+		}
+		ids = append(ids, parseUUID(idStr))
+	}
+	return ids, rows.Err()
 }
 
-func (r *AchievementRepository) SoftDeleteAchievementReference(id uuid.UUID) error {
-	_, err := r.db.Exec(`UPDATE achievement_references SET status = 'deleted', updated_at = NOW() WHERE id = $1`, id.String())
-	return err
+// TouchAchievementReference memperbarui updated_at tanpa menaikkan version (autosave tidak mengubah ETag)
+func (r *AchievementRepository) TouchAchievementReference(id uuid.UUID) error {
+	res, err := r.db.Exec(`UPDATE achievement_references SET updated_at = NOW() WHERE id = $1 AND status != 'deleted'`, id.String())
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, sql.ErrNoRows)
 }
 
-func (r *AchievementRepository) SubmitAchievement(id uuid.UUID) error {
-	_, err := r.db.Exec(`UPDATE achievement_references SET status = 'submitted', submitted_at = NOW(), updated_at = NOW() WHERE id = $1`, id.String())
+// FindStaleDrafts returns primary drafts not updated since untouchedSince, oldest first.
+// unremindedOnly melewati draft yang sudah diingatkan setelah perubahan terakhirnya.
+func (r *AchievementRepository) FindStaleDrafts(untouchedSince time.Time, unremindedOnly bool) ([]model.AchievementReference, error) {
+	query := achievementReferenceSelect + `
+		WHERE ar.status = 'draft' AND ar.primary_reference_id IS NULL AND ar.updated_at < $1
+	`
+	if unremindedOnly {
+		query += " AND (ar.draft_reminded_at IS NULL OR ar.draft_reminded_at < ar.updated_at)"
+	}
+	query += " ORDER BY ar.updated_at ASC"
+
+	rows, err := r.db.Query(query, untouchedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAchievementRows(rows)
+}
+
+// FindAbandonedDrafts returns primary drafts not updated since untouchedSince whose reminder (sent after the
+// last change) is older than remindedBefore; draft yang belum pernah diingatkan tidak ikut dihapus.
+func (r *AchievementRepository) FindAbandonedDrafts(untouchedSince, remindedBefore time.Time) ([]model.AchievementReference, error) {
+	query := achievementReferenceSelect + `
+		WHERE ar.status = 'draft' AND ar.primary_reference_id IS NULL AND ar.updated_at < $1
+		  AND ar.draft_reminded_at IS NOT NULL AND ar.draft_reminded_at >= ar.updated_at AND ar.draft_reminded_at < $2
+		ORDER BY ar.updated_at ASC`
+
+	rows, err := r.db.Query(query, untouchedSince, remindedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanAchievementRows(rows)
+}
+
+func (r *AchievementRepository) MarkDraftReminded(id uuid.UUID) error {
+	_, err := r.db.Exec(`UPDATE achievement_references SET draft_reminded_at = NOW() WHERE id = $1`, id.String())
 	return err
 }
 
-func (r *AchievementRepository) VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUID, rejectionNote *string) error {
-	now := time.Now()
-	if rejectionNote != nil && *rejectionNote != "" {
-		_, err := r.db.Exec(`
-			UPDATE achievement_references 
-			SET status = 'rejected', rejection_note = $1, verified_at = $2, updated_at = $3 
-			WHERE id = $4`,
-			*rejectionNote, now, now, id.String())
+// SaveVerificationReceipt menyimpan receipt verifikasi; receipt dari verifikasi sebelumnya (setelah revoke
+// dan submit ulang) tertimpa
+func (r *AchievementRepository) SaveVerificationReceipt(id uuid.UUID, receipt string) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references SET verification_receipt = $1
+		WHERE id = $2 AND status = 'verified'`,
+		receipt, id.String())
+	if err != nil {
 		return err
 	}
-	_, err := r.db.Exec(`
-		UPDATE achievement_references 
-		SET status = 'verified', verified_by = $1, verified_at = $2, updated_at = $3 
-		WHERE id = $4`,
-		verifiedBy.String(), now, now, id.String())
+	return expectOneRow(res, ErrVersionConflict)
+}
+
+// GetVerificationReceipt mengembalikan receipt verifikasi terakhir; "" jika belum pernah diverifikasi
+func (r *AchievementRepository) GetVerificationReceipt(id uuid.UUID) (string, error) {
+	var receipt sql.NullString
+	err := r.db.QueryRow(`SELECT verification_receipt FROM achievement_references WHERE id = $1`, id.String()).Scan(&receipt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return receipt.String, err
+}
+
+// ClaimAchievement menandai reviewer yang sedang memeriksa achievement.
+// Claim milik reviewer lain hanya bisa diambil alih jika lebih lama dari staleBefore.
+func (r *AchievementRepository) ClaimAchievement(id, reviewerID uuid.UUID, staleBefore time.Time) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references
+		SET claimed_by = $1, claimed_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND status = 'submitted'
+		  AND (claimed_by IS NULL OR claimed_by = $1 OR claimed_at < $3)`,
+		reviewerID.String(), id.String(), staleBefore)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, ErrClaimConflict)
+}
+
+// AssignReviewer memaksa claim ke reviewer tertentu (dipakai admin/dosen untuk delegasi)
+func (r *AchievementRepository) AssignReviewer(id, reviewerID uuid.UUID) error {
+	res, err := r.db.Exec(`
+		UPDATE achievement_references
+		SET claimed_by = $1, claimed_at = NOW(), updated_at = NOW()
+		WHERE id = $2 AND status = 'submitted'`,
+		reviewerID.String(), id.String())
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, ErrClaimConflict)
+}
+
+func (r *AchievementRepository) ReleaseClaim(id uuid.UUID) error {
+	_, err := r.db.Exec(`UPDATE achievement_references SET claimed_by = NULL, claimed_at = NULL, updated_at = NOW() WHERE id = $1`, id.String())
 	return err
 }
 
+func expectOneRow(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
+
 // ===================== USER LOOKUP =====================
 func (r *AchievementRepository) GetStudentByUserID(userID uuid.UUID) (*model.Student, error) {
 	query := `SELECT s.id, s.user_id, s.student_id, s.program_study, s.academic_year, s.created_at,
@@ -3,27 +3,185 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
-	"os"
-	"path/filepath"
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"BACKEND-UAS/pgmongo/model"
 )
 
+// AchievementMongoRepository adalah kontrak dokumen achievement (Mongo) yang dipakai AchievementService
+type AchievementMongoRepository interface {
+	GetAchievementByID(mongoID string) (*model.Achievement, error)
+	GetAchievementsByIDs(mongoIDs []string) (map[string]*model.Achievement, error)
+	CreateAchievement(ach *model.Achievement) error
+	UpdateAchievement(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error
+	RestoreAchievementContent(mongoID string, fields bson.M, historyID uuid.UUID) error
+	SoftDeleteAchievement(mongoID string) error
+	AddStatusHistory(mongoID string, history model.StatusHistory) error
+	AddNotification(mongoID string, notif model.Notification) error
+	AddAttachment(mongoID string, attachment model.Attachment) error
+	RemoveAttachment(mongoID string, attachment model.Attachment) error
+	ReplaceAttachment(mongoID string, old, replacement model.Attachment) error
+	FindAttachmentsPendingScan(limit int) ([]model.AttachmentRef, error)
+	ListAttachmentRefs() ([]model.AttachmentRef, error)
+	AdjustBlobRefCount(blob model.AttachmentBlob, delta int) error
+	ListBlobs() ([]model.AttachmentBlob, error)
+	DeleteUnreferencedBlob(hash string, untouchedSince time.Time) (bool, error)
+	CreateUpload(upload *model.AttachmentUpload) error
+	GetUpload(id string) (*model.AttachmentUpload, error)
+	AppendUploadChunk(id string, chunk model.UploadChunk, fileType string, expiresAt time.Time) error
+	SetUploadFinalized(id string, finalized bool) error
+	DeleteUpload(id string) error
+	FindExpiredUploads(now time.Time) ([]model.AttachmentUpload, error)
+	AddRevision(rev *model.AchievementRevision) error
+	ListRevisions(mongoID string) ([]model.AchievementRevision, error)
+	GetRevision(mongoID string, revision int64) (*model.AchievementRevision, error)
+	RespondTeamInvitation(mongoID string, studentID uuid.UUID, status string) error
+	GetTeamInvitations(studentID uuid.UUID) ([]model.Achievement, error)
+	FindDuplicateCandidates(ach *model.Achievement) ([]model.Achievement, error)
+	SearchAchievements(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error)
+	FindAchievementListKeys(filter model.AchievementContentFilter) ([]model.AchievementListKey, error)
+	SaveAutosave(autosave *model.AchievementAutosave) error
+	GetAutosave(mongoID string) (*model.AchievementAutosave, error)
+	DeleteAutosave(mongoID string) error
+}
+
 type AchievementRepositoryMongo struct {
-	coll *mongo.Collection
+	coll      *mongo.Collection
+	revisions *mongo.Collection
+	autosaves *mongo.Collection
+	blobs     *mongo.Collection
+	uploads   *mongo.Collection
 }
 
+var _ AchievementMongoRepository = (*AchievementRepositoryMongo)(nil)
+
 func NewAchievementRepositoryMongo(client *mongo.Client) *AchievementRepositoryMongo {
-	coll := client.Database("your_db").Collection("achievements")
-	return &AchievementRepositoryMongo{coll: coll}
+	db := client.Database("your_db")
+	return &AchievementRepositoryMongo{
+		coll:      db.Collection("achievements"),
+		revisions: db.Collection("achievement_revisions"),
+		autosaves: db.Collection("achievement_autosaves"),
+		blobs:     db.Collection("attachment_blobs"),
+		uploads:   db.Collection("attachment_uploads"),
+	}
+}
+
+// EnsureIndexes membuat index yang dibutuhkan repository (idempotent, dipanggil saat startup)
+func (r *AchievementRepositoryMongo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.revisions.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "mongoAchievementId", Value: 1}, {Key: "revision", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = r.uploads.Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "expiresAt", Value: 1}}})
+	if err != nil {
+		return err
+	}
+	// Mongo hanya mengizinkan satu text index; versi lama (tanpa field kejadian) dihapus jika masih ada
+	_, _ = r.coll.Indexes().DropOne(ctx, legacyTextIndexName)
+	_, err = r.coll.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "points", Value: 1}}},
+		{Keys: bson.D{{Key: "tags", Value: 1}}},
+		{Keys: bson.D{{Key: "titleKey", Value: 1}}},
+		{Keys: bson.D{{Key: "attachments.contentHash", Value: 1}}},
+		{Keys: bson.D{{Key: "attachments.scanStatus", Value: 1}}},
+		{Keys: bson.D{{Key: "eventDate", Value: 1}}},
+		{Keys: bson.D{{Key: "organizer", Value: 1}}},
+		{Keys: bson.D{{Key: "certificateKey", Value: 1}}, Options: options.Index().SetSparse(true)},
+		achievementTextIndex(),
+	})
+	return err
+}
+
+// BackfillEventFields memindahkan tanggal & penyelenggara dari details (data lama) ke field eventDate/organizer
+// dan mengisi certificateKey, agar filter dan deteksi duplikat di Mongo tidak perlu membaca details (idempotent, dipanggil saat startup)
+func (r *AchievementRepositoryMongo) BackfillEventFields(ctx context.Context) (int, error) {
+	filter := bson.M{
+		"deletedAt": bson.M{"$exists": false},
+		"$or": bson.A{
+			bson.M{"eventDate": nil, "details": bson.M{"$type": "object"}},
+			bson.M{"certificateNumber": bson.M{"$nin": bson.A{nil, ""}}, "certificateKey": nil},
+		},
+	}
+	cursor, err := r.coll.Find(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	updated := 0
+	for cursor.Next(ctx) {
+		var ach model.Achievement
+		if err := cursor.Decode(&ach); err != nil {
+			return updated, err
+		}
+		organizer := ach.Organizer
+		ach.PromoteLegacyEventFields()
+		set := bson.M{}
+		if ach.EventAt != nil {
+			set["eventDate"] = ach.EventAt
+		}
+		if ach.Organizer != organizer {
+			set["organizer"] = ach.Organizer
+		}
+		if key := model.CertificateKey(&ach); key != "" && key != ach.CertificateKey {
+			set["certificateKey"] = key
+		}
+		if len(set) == 0 {
+			continue
+		}
+		if _, err := r.coll.UpdateOne(ctx, bson.M{"_id": ach.ID}, bson.M{"$set": set}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, cursor.Err()
+}
+
+// BackfillVersions mengisi version 1 pada dokumen lama yang belum punya field version, agar edit konten
+// cukup mencocokkan version secara persis (idempotent, dipanggil saat startup)
+func (r *AchievementRepositoryMongo) BackfillVersions(ctx context.Context) (int64, error) {
+	res, err := r.coll.UpdateMany(ctx, bson.M{"version": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"version": int64(1)}})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// SearchableDetailFields adalah field details yang ikut di-index untuk full-text search
+var SearchableDetailFields = []string{"organizer", "penyelenggara", "issuer", "eventName", "competitionName"}
+
+const (
+	legacyTextIndexName = "achievement_text"
+	textIndexName       = "achievement_text_v2"
+)
+
+// achievementTextIndex: satu-satunya text index di collection; default_language none agar kata bahasa Indonesia tidak di-stem
+func achievementTextIndex() mongo.IndexModel {
+	keys := bson.D{
+		{Key: "title", Value: "text"}, {Key: "description", Value: "text"}, {Key: "tags", Value: "text"},
+		{Key: "organizer", Value: "text"}, {Key: "location", Value: "text"}, {Key: "certificateNumber", Value: "text"},
+	}
+	weights := bson.M{"title": 10, "tags": 5, "description": 2, "organizer": 3, "location": 1, "certificateNumber": 3}
+	for _, f := range SearchableDetailFields {
+		keys = append(keys, bson.E{Key: "details." + f, Value: "text"})
+		weights["details."+f] = 3
+	}
+	return mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName(textIndexName).SetWeights(weights).SetDefaultLanguage("none"),
+	}
 }
 
 // GetAchievementByID gets a single achievement from Mongo
@@ -52,6 +210,9 @@ func (r *AchievementRepositoryMongo) GetAchievementByID(mongoID string) (*model.
 func (r *AchievementRepositoryMongo) CreateAchievement(ach *model.Achievement) error {
 	ach.CreatedAt = time.Now()
 	ach.UpdatedAt = time.Now()
+	ach.Version = 1
+	ach.TitleKey = model.NormalizeTitle(ach.Title)
+	ach.CertificateKey = model.CertificateKey(ach)
 	ach.StatusHistory = []model.StatusHistory{
 		{
 			ID:        uuid.New(),
@@ -68,15 +229,55 @@ func (r *AchievementRepositoryMongo) CreateAchievement(ach *model.Achievement) e
 	return nil
 }
 
-// UpdateAchievement updates an achievement in Mongo
-func (r *AchievementRepositoryMongo) UpdateAchievement(mongoID string, ach *model.Achievement) error {
+// UpdateAchievement men-$set hanya field yang diberikan dan mencatat history dalam satu operasi,
+// asalkan version dokumen masih sama dengan expectedVersion (dokumen lama diisi version oleh BackfillVersions).
+func (r *AchievementRepositoryMongo) UpdateAchievement(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
 	objID, err := primitive.ObjectIDFromHex(mongoID)
 	if err != nil {
 		return err
 	}
-	ach.UpdatedAt = time.Now()
-	_, err = r.coll.UpdateOne(context.Background(), bson.M{"_id": objID}, bson.M{"$set": ach})
-	return err
+	now := time.Now()
+	set := bson.M{"updatedAt": now, "version": expectedVersion + 1}
+	for k, v := range fields {
+		set[k] = v
+	}
+	if history.ID == uuid.Nil {
+		history.ID = uuid.New()
+	}
+	history.ChangedAt = now
+
+	filter := bson.M{"_id": objID, "version": expectedVersion}
+	update := bson.M{"$set": set, "$push": bson.M{"statusHistory": history}}
+	res, err := r.coll.UpdateOne(context.Background(), filter, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// RestoreAchievementContent mengembalikan field konten dan menghapus entri history historyID dari edit yang
+// dibatalkan. Tidak kondisional pada version (bisa sudah dinaikkan perubahan status), version tetap naik.
+func (r *AchievementRepositoryMongo) RestoreAchievementContent(mongoID string, fields bson.M, historyID uuid.UUID) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{
+		"$set":  fields,
+		"$inc":  bson.M{"version": 1},
+		"$pull": bson.M{"statusHistory": bson.M{"id": historyID}},
+	}
+	res, err := r.coll.UpdateOne(context.Background(), bson.M{"_id": objID, "statusHistory.id": historyID}, update)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
 }
 
 // SoftDeleteAchievement soft deletes an achievement in Mongo
@@ -103,6 +304,39 @@ func (r *AchievementRepositoryMongo) GetAchievementsByStudentIDs(studentIDs []uu
 	return achievements, err
 }
 
+// GetAchievementsByIDs batch-loads achievements with a single $in query, keyed by hex ID.
+// ID yang tidak valid atau dokumen yang sudah soft-deleted dilewati.
+func (r *AchievementRepositoryMongo) GetAchievementsByIDs(mongoIDs []string) (map[string]*model.Achievement, error) {
+	result := make(map[string]*model.Achievement, len(mongoIDs))
+	objIDs := make([]primitive.ObjectID, 0, len(mongoIDs))
+	for _, id := range mongoIDs {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			continue
+		}
+		objIDs = append(objIDs, objID)
+	}
+	if len(objIDs) == 0 {
+		return result, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": objIDs}, "deletedAt": bson.M{"$exists": false}}
+	cursor, err := r.coll.Find(context.Background(), filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	var achievements []model.Achievement
+	if err := cursor.All(context.Background(), &achievements); err != nil {
+		return nil, err
+	}
+	for i := range achievements {
+		result[achievements[i].ID.Hex()] = &achievements[i]
+	}
+	return result, nil
+}
+
 // AddStatusHistory adds a status history entry to an achievement in Mongo
 func (r *AchievementRepositoryMongo) AddStatusHistory(mongoID string, history model.StatusHistory) error {
 	objID, err := primitive.ObjectIDFromHex(mongoID)
@@ -111,7 +345,11 @@ func (r *AchievementRepositoryMongo) AddStatusHistory(mongoID string, history mo
 	}
 	history.ID = uuid.New()
 	history.ChangedAt = time.Now()
-	_, err = r.coll.UpdateOne(context.Background(), bson.M{"_id": objID}, bson.M{"$push": bson.M{"statusHistory": history}})
+	// version ikut naik agar edit konten yang membaca dokumen sebelum perubahan status ini ditolak
+	_, err = r.coll.UpdateOne(context.Background(), bson.M{"_id": objID}, bson.M{
+		"$push": bson.M{"statusHistory": history},
+		"$inc":  bson.M{"version": 1},
+	})
 	return err
 }
 
@@ -127,40 +365,529 @@ func (r *AchievementRepositoryMongo) AddNotification(mongoID string, notif model
 	return err
 }
 
-// UploadAttachment adds an attachment to an achievement in Mongo
-func (r *AchievementRepositoryMongo) UploadAttachment(mongoID string, file io.Reader, fileName, fileType string) (*model.Attachment, error) {
+// AddAttachment menyimpan metadata attachment; isi file sudah ditulis ke storage oleh service
+func (r *AchievementRepositoryMongo) AddAttachment(mongoID string, attachment model.Attachment) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$push": bson.M{"attachments": attachment}}
+	_, err = r.coll.UpdateOne(context.Background(), bson.M{"_id": objID}, update)
+	return err
+}
+
+// attachmentMatch mencocokkan elemen array attachments: lewat id (plus storageKey, karena id tetap saat file
+// diganti sehingga hasil scan file lama tidak menimpa file baru), atau fileName untuk upload lama tanpa id
+func attachmentMatch(a model.Attachment) bson.M {
+	if a.ID == "" {
+		return bson.M{"fileName": a.FileName}
+	}
+	m := bson.M{"id": a.ID}
+	if a.StorageKey != "" {
+		m["storageKey"] = a.StorageKey
+	}
+	return m
+}
+
+// RemoveAttachment menghapus metadata attachment ($pull); mongo.ErrNoDocuments jika attachment sudah tidak ada
+func (r *AchievementRepositoryMongo) RemoveAttachment(mongoID string, attachment model.Attachment) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	res, err := r.coll.UpdateOne(context.Background(), bson.M{"_id": objID},
+		bson.M{"$pull": bson.M{"attachments": attachmentMatch(attachment)}})
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// ReplaceAttachment mengganti satu elemen attachments di tempat (urutan tetap)
+func (r *AchievementRepositoryMongo) ReplaceAttachment(mongoID string, old, replacement model.Attachment) error {
 	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	res, err := r.coll.UpdateOne(context.Background(),
+		bson.M{"_id": objID, "attachments": bson.M{"$elemMatch": attachmentMatch(old)}},
+		bson.M{"$set": bson.M{"attachments.$": replacement}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// FindAttachmentsPendingScan mengembalikan paling banyak limit attachment yang belum di-scan
+// (status pending_scan, atau tanpa status untuk upload lama) dari achievement yang belum dihapus
+func (r *AchievementRepositoryMongo) FindAttachmentsPendingScan(limit int) ([]model.AttachmentRef, error) {
+	filter := bson.M{
+		"deletedAt":   bson.M{"$exists": false},
+		"attachments": bson.M{"$elemMatch": bson.M{"scanStatus": bson.M{"$in": bson.A{nil, model.ScanStatusPending}}}},
+	}
+	opts := options.Find().SetProjection(bson.M{"attachments": 1}).SetSort(bson.D{{Key: "updatedAt", Value: 1}})
+	cursor, err := r.coll.Find(context.Background(), filter, opts)
 	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(context.Background())
 
-	if err := os.MkdirAll("./uploads", 0755); err != nil {
+	pending := []model.AttachmentRef{}
+	for cursor.Next(context.Background()) && len(pending) < limit {
+		var ach model.Achievement
+		if err := cursor.Decode(&ach); err != nil {
+			return nil, err
+		}
+		for _, att := range ach.Attachments {
+			if att.IsScanPending() && len(pending) < limit {
+				pending = append(pending, model.AttachmentRef{MongoAchievementID: ach.ID.Hex(), Attachment: att})
+			}
+		}
+	}
+	return pending, cursor.Err()
+}
+
+// ListAttachmentRefs mengembalikan semua attachment yang masih menunjuk ke file: dari dokumen achievement
+// (termasuk yang di-soft delete, karena masih bisa dipulihkan) dan dari snapshot revisi
+func (r *AchievementRepositoryMongo) ListAttachmentRefs() ([]model.AttachmentRef, error) {
+	ctx := context.Background()
+	withAttachments := bson.M{"attachments.0": bson.M{"$exists": true}}
+	cursor, err := r.coll.Find(ctx, withAttachments, options.Find().SetProjection(bson.M{"attachments": 1}))
+	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	filePath := filepath.Join("./uploads", fileName)
-	out, err := os.Create(filePath)
+	refs := []model.AttachmentRef{}
+	for cursor.Next(ctx) {
+		var ach model.Achievement
+		if err := cursor.Decode(&ach); err != nil {
+			return nil, err
+		}
+		for _, att := range ach.Attachments {
+			refs = append(refs, model.AttachmentRef{MongoAchievementID: ach.ID.Hex(), Attachment: att})
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	revCursor, err := r.revisions.Find(ctx, bson.M{"snapshot.attachments.0": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"mongoAchievementId": 1, "revision": 1, "snapshot.attachments": 1}))
 	if err != nil {
 		return nil, err
 	}
-	defer out.Close()
-	if _, err := io.Copy(out, file); err != nil {
+	defer revCursor.Close(ctx)
+	for revCursor.Next(ctx) {
+		var rev model.AchievementRevision
+		if err := revCursor.Decode(&rev); err != nil {
+			return nil, err
+		}
+		for _, att := range rev.Snapshot.Attachments {
+			refs = append(refs, model.AttachmentRef{MongoAchievementID: rev.MongoAchievementID, Revision: rev.Revision, Attachment: att})
+		}
+	}
+	return refs, revCursor.Err()
+}
+
+// AdjustBlobRefCount menambah (delta > 0, upsert) atau mengurangi refCount blob. Pengurangan tidak pernah
+// membuat refCount negatif; blob dengan refCount 0 dibiarkan untuk dibersihkan GC.
+func (r *AchievementRepositoryMongo) AdjustBlobRefCount(blob model.AttachmentBlob, delta int) error {
+	now := time.Now()
+	if delta > 0 {
+		_, err := r.blobs.UpdateOne(context.Background(), bson.M{"_id": blob.Hash}, bson.M{
+			"$inc":         bson.M{"refCount": delta},
+			"$set":         bson.M{"updatedAt": now},
+			"$setOnInsert": bson.M{"key": blob.Key, "size": blob.Size, "contentType": blob.ContentType, "createdAt": now},
+		}, options.Update().SetUpsert(true))
+		return err
+	}
+	_, err := r.blobs.UpdateOne(context.Background(),
+		bson.M{"_id": blob.Hash, "refCount": bson.M{"$gte": -delta}},
+		bson.M{"$inc": bson.M{"refCount": delta}, "$set": bson.M{"updatedAt": now}})
+	return err
+}
+
+func (r *AchievementRepositoryMongo) ListBlobs() ([]model.AttachmentBlob, error) {
+	cursor, err := r.blobs.Find(context.Background(), bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	blobs := []model.AttachmentBlob{}
+	if err := cursor.All(context.Background(), &blobs); err != nil {
 		return nil, err
 	}
+	return blobs, nil
+}
 
-	attachment := &model.Attachment{
-		FileName:   fileName,
-		FileURL:    "/uploads/" + fileName,
-		FileType:   fileType,
-		UploadedAt: time.Now(),
+// DeleteUnreferencedBlob menghapus catatan blob hanya jika refCount-nya 0 dan blob tidak dibuat/diubah sejak
+// untouchedSince. true jika file blob boleh dihapus (catatannya terhapus atau memang tidak ada); false jika
+// blob dipakai lagi atau baru disentuh.
+func (r *AchievementRepositoryMongo) DeleteUnreferencedBlob(hash string, untouchedSince time.Time) (bool, error) {
+	res, err := r.blobs.DeleteOne(context.Background(), bson.M{
+		"_id":       hash,
+		"refCount":  0,
+		"createdAt": bson.M{"$lt": untouchedSince},
+		"updatedAt": bson.M{"$lt": untouchedSince},
+	})
+	if err != nil {
+		return false, err
+	}
+	if res.DeletedCount > 0 {
+		return true, nil
+	}
+	n, err := r.blobs.CountDocuments(context.Background(), bson.M{"_id": hash})
+	if err != nil {
+		return false, err
 	}
+	return n == 0, nil
+}
 
-	update := bson.M{"$push": bson.M{"attachments": attachment}}
-	_, err = r.coll.UpdateOne(context.Background(), bson.M{"_id": objID}, update)
+func (r *AchievementRepositoryMongo) CreateUpload(upload *model.AttachmentUpload) error {
+	_, err := r.uploads.InsertOne(context.Background(), upload)
+	return err
+}
+
+// GetUpload returns nil without error when the upload session does not exist
+func (r *AchievementRepositoryMongo) GetUpload(id string) (*model.AttachmentUpload, error) {
+	var upload model.AttachmentUpload
+	err := r.uploads.FindOne(context.Background(), bson.M{"_id": id}).Decode(&upload)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// AppendUploadChunk mencatat chunk hanya jika offset sesi masih sama dengan offset chunk;
+// mongo.ErrNoDocuments berarti PATCH lain sudah lebih dulu memajukan offset
+func (r *AchievementRepositoryMongo) AppendUploadChunk(id string, chunk model.UploadChunk, fileType string, expiresAt time.Time) error {
+	set := bson.M{"expiresAt": expiresAt}
+	if fileType != "" {
+		set["fileType"] = fileType
+	}
+	res, err := r.uploads.UpdateOne(context.Background(),
+		bson.M{"_id": id, "offset": chunk.Offset},
+		bson.M{"$inc": bson.M{"offset": chunk.Size}, "$push": bson.M{"chunks": chunk}, "$set": set})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// SetUploadFinalized mengubah flag finalized hanya jika nilainya belum sama (compare-and-set);
+// mongo.ErrNoDocuments berarti PATCH lain sudah lebih dulu mengubahnya
+func (r *AchievementRepositoryMongo) SetUploadFinalized(id string, finalized bool) error {
+	res, err := r.uploads.UpdateOne(context.Background(),
+		bson.M{"_id": id, "finalized": bson.M{"$ne": finalized}},
+		bson.M{"$set": bson.M{"finalized": finalized}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+func (r *AchievementRepositoryMongo) DeleteUpload(id string) error {
+	_, err := r.uploads.DeleteOne(context.Background(), bson.M{"_id": id})
+	return err
+}
+
+func (r *AchievementRepositoryMongo) FindExpiredUploads(now time.Time) ([]model.AttachmentUpload, error) {
+	cursor, err := r.uploads.Find(context.Background(), bson.M{"expiresAt": bson.M{"$lt": now}})
+	if err != nil {
+		return nil, err
+	}
+	uploads := []model.AttachmentUpload{}
+	if err := cursor.All(context.Background(), &uploads); err != nil {
+		return nil, err
+	}
+	return uploads, nil
+}
+
+// AddRevision menyimpan snapshot baru; revision lama tidak pernah diubah atau dihapus
+func (r *AchievementRepositoryMongo) AddRevision(rev *model.AchievementRevision) error {
+	rev.CreatedAt = time.Now()
+	res, err := r.revisions.InsertOne(context.Background(), rev)
+	if err != nil {
+		return err
+	}
+	rev.ID = res.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// ListRevisions returns all revisions of an achievement (tanpa snapshot), urut dari yang paling lama
+func (r *AchievementRepositoryMongo) ListRevisions(mongoID string) ([]model.AchievementRevision, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "revision", Value: 1}}).
+		SetProjection(bson.M{"snapshot": 0})
+	cursor, err := r.revisions.Find(context.Background(), bson.M{"mongoAchievementId": mongoID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	revisions := []model.AchievementRevision{}
+	if err := cursor.All(context.Background(), &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetRevision gets a single revision with its snapshot; nil jika tidak ada
+func (r *AchievementRepositoryMongo) GetRevision(mongoID string, revision int64) (*model.AchievementRevision, error) {
+	var rev model.AchievementRevision
+	err := r.revisions.FindOne(context.Background(), bson.M{"mongoAchievementId": mongoID, "revision": revision}).Decode(&rev)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// ErrNoPendingInvitation dikembalikan saat mahasiswa bukan anggota tim yang masih pending
+var ErrNoPendingInvitation = errors.New("no pending team invitation for this student")
+
+// RespondTeamInvitation mengubah status anggota tim yang masih pending menjadi confirmed/declined
+func (r *AchievementRepositoryMongo) RespondTeamInvitation(mongoID string, studentID uuid.UUID, status string) error {
+	objID, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	filter := bson.M{
+		"_id":          objID,
+		"deletedAt":    bson.M{"$exists": false},
+		"team.members": bson.M{"$elemMatch": bson.M{"studentId": studentID, "status": model.TeamMemberPending}},
+	}
+	update := bson.M{"$set": bson.M{
+		"team.members.$[m].status":      status,
+		"team.members.$[m].respondedAt": now,
+		"updatedAt":                     now,
+	}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{
+		Filters: []interface{}{bson.M{"m.studentId": studentID, "m.status": model.TeamMemberPending}},
+	})
+	res, err := r.coll.UpdateOne(context.Background(), filter, update, opts)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNoPendingInvitation
+	}
+	return nil
+}
+
+// GetTeamInvitations returns team achievements where the student is still a pending member
+func (r *AchievementRepositoryMongo) GetTeamInvitations(studentID uuid.UUID) ([]model.Achievement, error) {
+	filter := bson.M{
+		"deletedAt":    bson.M{"$exists": false},
+		"team.members": bson.M{"$elemMatch": bson.M{"studentId": studentID, "status": model.TeamMemberPending}},
+	}
+	cursor, err := r.coll.Find(context.Background(), filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	achievements := []model.Achievement{}
+	if err := cursor.All(context.Background(), &achievements); err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
+// FindDuplicateCandidates mencari dokumen lain dengan judul ternormalisasi, hash lampiran atau nomor sertifikat yang sama
+func (r *AchievementRepositoryMongo) FindDuplicateCandidates(ach *model.Achievement) ([]model.Achievement, error) {
+	or := bson.A{}
+	if key := model.NormalizeTitle(ach.Title); key != "" {
+		or = append(or, bson.M{"titleKey": key})
+	}
+	if hashes := ach.AttachmentHashes(); len(hashes) > 0 {
+		or = append(or, bson.M{"attachments.contentHash": bson.M{"$in": hashes}})
+	}
+	if key := model.CertificateKey(ach); key != "" {
+		or = append(or, bson.M{"certificateKey": key})
+	}
+	if len(or) == 0 {
+		return []model.Achievement{}, nil
+	}
+
+	filter := bson.M{"$or": or, "_id": bson.M{"$ne": ach.ID}, "deletedAt": bson.M{"$exists": false}}
+	cursor, err := r.coll.Find(context.Background(), filter, options.Find().SetLimit(50))
 	if err != nil {
-		os.Remove(filePath)
 		return nil, err
 	}
+	defer cursor.Close(context.Background())
 
-	return attachment, nil
+	candidates := []model.Achievement{}
+	if err := cursor.All(context.Background(), &candidates); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+// SearchAchievements runs a $text query and returns at most limit matches ordered by text score.
+// studentIDs non-nil membatasi ke prestasi milik (atau tim yang beranggotakan) mahasiswa tersebut.
+// Hanya field untuk filter tanggal kejadian yang diambil; isi dokumen dimuat per halaman oleh service.
+func (r *AchievementRepositoryMongo) SearchAchievements(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error) {
+	filter := bson.M{"$text": bson.M{"$search": query}, "deletedAt": bson.M{"$exists": false}}
+	if studentIDs != nil {
+		filter["$or"] = bson.A{
+			bson.M{"studentId": bson.M{"$in": studentIDs}},
+			bson.M{"team.members.studentId": bson.M{"$in": studentIDs}},
+		}
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"eventDate": 1, "details": 1, "createdAt": 1, "score": bson.M{"$meta": "textScore"}}).
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}}).
+		SetLimit(int64(limit))
+	cursor, err := r.coll.Find(context.Background(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	hits := []model.AchievementSearchHit{}
+	for cursor.Next(context.Background()) {
+		var doc struct {
+			model.Achievement `bson:",inline"`
+			Score             float64 `bson:"score"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		hits = append(hits, model.AchievementSearchHit{Achievement: doc.Achievement, Score: doc.Score})
+	}
+	return hits, cursor.Err()
+}
+
+// FindAchievementListKeys mengembalikan field sort/filter (tanpa isi dokumen) untuk achievement yang cocok dengan filter
+func (r *AchievementRepositoryMongo) FindAchievementListKeys(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+	query := bson.M{"deletedAt": bson.M{"$exists": false}}
+	if filter.MongoIDs != nil {
+		objIDs := make([]primitive.ObjectID, 0, len(filter.MongoIDs))
+		for _, id := range filter.MongoIDs {
+			if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+				objIDs = append(objIDs, objID)
+			}
+		}
+		if len(objIDs) == 0 {
+			return []model.AchievementListKey{}, nil
+		}
+		query["_id"] = bson.M{"$in": objIDs}
+	}
+	if filter.AchievementType != "" {
+		query["achievementType"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.AchievementType) + "$", Options: "i"}
+	}
+	if filter.Level != "" {
+		query["level"] = primitive.Regex{Pattern: "^" + regexp.QuoteMeta(filter.Level) + "$", Options: "i"}
+	}
+	if len(filter.Tags) > 0 {
+		query["tags"] = bson.M{"$all": filter.Tags}
+	}
+	and := bson.A{}
+	if filter.StudentIDs != nil {
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"studentId": bson.M{"$in": filter.StudentIDs}},
+			bson.M{"team.members.studentId": bson.M{"$in": filter.StudentIDs}},
+		}})
+	}
+	for _, g := range filter.TagGroups {
+		and = append(and, bson.M{"tags": bson.M{"$in": g}})
+	}
+	points := bson.M{}
+	if filter.MinPoints != nil {
+		points["$gte"] = *filter.MinPoints
+	}
+	if filter.MaxPoints != nil {
+		points["$lte"] = *filter.MaxPoints
+	}
+	if len(points) > 0 {
+		query["points"] = points
+	}
+	eventDate := bson.M{}
+	if filter.EventFrom != nil {
+		eventDate["$gte"] = *filter.EventFrom
+	}
+	if filter.EventTo != nil {
+		eventDate["$lt"] = *filter.EventTo
+	}
+	if len(eventDate) > 0 {
+		// sama dengan Achievement.EventDate(): tanpa eventDate (tanggal details sudah di-backfill) dipakai createdAt
+		and = append(and, bson.M{"$or": bson.A{
+			bson.M{"eventDate": eventDate},
+			bson.M{"eventDate": nil, "createdAt": eventDate},
+		}})
+	}
+	if len(and) > 0 {
+		query["$and"] = and
+	}
+
+	opts := options.Find().SetProjection(bson.M{"title": 1, "achievementType": 1, "level": 1, "points": 1, "eventDate": 1, "createdAt": 1})
+	if filter.Limit > 0 {
+		opts.SetLimit(int64(filter.Limit))
+	}
+	cursor, err := r.coll.Find(context.Background(), query, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.Background())
+
+	keys := []model.AchievementListKey{}
+	for cursor.Next(context.Background()) {
+		var doc struct {
+			ID                       primitive.ObjectID `bson:"_id"`
+			model.AchievementListKey `bson:",inline"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		doc.AchievementListKey.MongoID = doc.ID.Hex()
+		if doc.EventDate == nil {
+			doc.EventDate = &doc.CreatedAt
+		}
+		keys = append(keys, doc.AchievementListKey)
+	}
+	return keys, cursor.Err()
+}
+
+// SaveAutosave menyimpan (upsert) satu autosave per dokumen; autosave sebelumnya ditimpa
+func (r *AchievementRepositoryMongo) SaveAutosave(autosave *model.AchievementAutosave) error {
+	_, err := r.autosaves.ReplaceOne(context.Background(),
+		bson.M{"_id": autosave.MongoAchievementID}, autosave, options.Replace().SetUpsert(true))
+	return err
+}
+
+// GetAutosave returns nil without error when the achievement has no autosave
+func (r *AchievementRepositoryMongo) GetAutosave(mongoID string) (*model.AchievementAutosave, error) {
+	var autosave model.AchievementAutosave
+	err := r.autosaves.FindOne(context.Background(), bson.M{"_id": mongoID}).Decode(&autosave)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &autosave, nil
+}
+
+func (r *AchievementRepositoryMongo) DeleteAutosave(mongoID string) error {
+	_, err := r.autosaves.DeleteOne(context.Background(), bson.M{"_id": mongoID})
+	return err
 }
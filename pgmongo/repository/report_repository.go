@@ -5,16 +5,15 @@ import (
 	"context"
 	"database/sql"
 	"sort"
-	"time"
 
 	"github.com/google/uuid"
 	"BACKEND-UAS/pgmongo/model"
 )
 
 type ReportRepository interface {
-	GetAchievementStatistics(ctx context.Context, userID uuid.UUID) (*model.AchievementStatistics, error)
-	GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID) (*model.StudentAchievementStatistics, error)
-	GetTotalPointsForStudent(ctx context.Context, studentID uuid.UUID) (int64, error)
+	GetAchievementStatistics(ctx context.Context, userID uuid.UUID, filter model.ReportFilter) (*model.AchievementStatistics, error)
+	GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID, filter model.ReportFilter) (*model.StudentAchievementStatistics, error)
+	GetTotalPointsForStudent(ctx context.Context, studentID uuid.UUID, filter model.ReportFilter) (int64, error)
 }
 
 type reportRepository struct {
@@ -29,18 +28,28 @@ func NewReportRepository(db *sql.DB, mongoRepo *AchievementRepositoryMongo) Repo
 	}
 }
 
-// GetAchievementStatistics fetches global stats
-func (r *reportRepository) GetAchievementStatistics(ctx context.Context, userID uuid.UUID) (*model.AchievementStatistics, error) {
+// GetAchievementStatistics fetches global stats; achievement masuk ke periode tanggal kejadiannya, bukan tanggal dibuat
+func (r *reportRepository) GetAchievementStatistics(ctx context.Context, userID uuid.UUID, filter model.ReportFilter) (*model.AchievementStatistics, error) {
 	stats := &model.AchievementStatistics{
-		TotalPerType:   make(map[string]int64),
-		TotalPerPeriod: make(map[string]int64),
-		TopStudents:    []model.TopStudent{},
-		Distribution:   make(map[string]int64),
+		TotalPerType:    make(map[string]int64),
+		TotalPerPeriod:  make(map[string]int64),
+		TopStudents:     []model.TopStudent{},
+		Distribution:    make(map[string]int64),
+		TagCounts:       make(map[string]int64),
+		OrganizerCounts: make(map[string]int64),
 	}
 
+	// Hanya status verified yang dihitung; achievement yang dicabut (revoked) dilaporkan terpisah
+	revoked, err := r.countRevoked(ctx, nil, filter)
+	if err != nil {
+		return nil, err
+	}
+	stats.TotalRevoked = revoked
+
 	// Get all verified mongo IDs from Postgres
 	var verifiedIDs []string
-	query := `SELECT mongo_achievement_id FROM achievement_references WHERE status = 'verified'`
+	// Prestasi tim dihitung sekali (reference ketua), bukan per anggota
+	query := `SELECT mongo_achievement_id FROM achievement_references WHERE status = 'verified' AND primary_reference_id IS NULL`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -75,20 +84,23 @@ func (r *reportRepository) GetAchievementStatistics(ctx context.Context, userID
 	typeCounts := make(map[string]int64)
 	periodCounts := make(map[string]int64)
 	levelCounts := make(map[string]int64)
-	now := time.Now()
-	oneYearAgo := now.AddDate(-1, 0, 0)
 	for _, ach := range achievements {
-		if ach.CreatedAt.Before(oneYearAgo) {
+		eventDate := ach.EventDate()
+		if !filter.Contains(eventDate) {
 			continue
 		}
 		typeCounts[ach.AchievementType]++
-		periodKey := ach.CreatedAt.Format("2006-01")
+		periodKey := filter.PeriodKey(eventDate)
 		periodCounts[periodKey]++
 		levelKey := ach.Level
 		if levelKey == "" {
 			levelKey = "unknown"
 		}
 		levelCounts[levelKey]++
+		countTags(stats.TagCounts, ach.Tags)
+		if organizer := ach.EffectiveOrganizer(); organizer != "" {
+			stats.OrganizerCounts[organizer]++
+		}
 	}
 
 	stats.TotalPerType = typeCounts
@@ -131,10 +143,10 @@ func (r *reportRepository) GetAchievementStatistics(ctx context.Context, userID
 		var achCount int64
 		for _, mid := range mids {
 			ach, err := r.achievementMongoRepo.GetAchievementByID(mid)
-			if err != nil || ach == nil {
+			if err != nil || ach == nil || !filter.Contains(ach.EventDate()) {
 				continue
 			}
-			totalPoints += int64(ach.Points)
+			totalPoints += int64(ach.PointsFor(sid))
 			achCount++
 		}
 		var fullName string
@@ -148,6 +160,9 @@ func (r *reportRepository) GetAchievementStatistics(ctx context.Context, userID
 	// Convert to top students
 	var topStudents []model.TopStudent
 	for sid, data := range studentMap {
+		if data.count == 0 {
+			continue // tidak ada prestasi pada rentang yang diminta
+		}
 		topStudents = append(topStudents, model.TopStudent{
 			StudentID: sid.String(),
 			FullName:  data.name,
@@ -172,12 +187,20 @@ func (r *reportRepository) GetAchievementStatistics(ctx context.Context, userID
 }
 
 // GetStudentAchievementStatistics similar logic but filter by studentID
-func (r *reportRepository) GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID) (*model.StudentAchievementStatistics, error) {
+func (r *reportRepository) GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID, filter model.ReportFilter) (*model.StudentAchievementStatistics, error) {
 	stats := &model.StudentAchievementStatistics{
-		PerType:      make(map[string]int64),
-		PerPeriod:    make(map[string]int64),
-		Distribution: make(map[string]int64),
+		PerType:         make(map[string]int64),
+		PerPeriod:       make(map[string]int64),
+		Distribution:    make(map[string]int64),
+		TagCounts:       make(map[string]int64),
+		OrganizerCounts: make(map[string]int64),
+	}
+
+	revoked, err := r.countRevoked(ctx, &studentID, filter)
+	if err != nil {
+		return nil, err
 	}
+	stats.TotalRevoked = revoked
 
 	// Get verified mongo IDs for student
 	var verifiedIDs []string
@@ -213,29 +236,65 @@ func (r *reportRepository) GetStudentAchievementStatistics(ctx context.Context,
 	}
 
 	// Aggregate
-	now := time.Now()
-	oneYearAgo := now.AddDate(-1, 0, 0)
 	for _, ach := range achievements {
-		if ach.CreatedAt.Before(oneYearAgo) {
+		eventDate := ach.EventDate()
+		if !filter.Contains(eventDate) {
 			continue
 		}
+		stats.TotalAchievements++
 		stats.PerType[ach.AchievementType]++
-		periodKey := ach.CreatedAt.Format("2006-01")
+		periodKey := filter.PeriodKey(eventDate)
 		stats.PerPeriod[periodKey]++
 		levelKey := ach.Level
 		if levelKey == "" {
 			levelKey = "unknown"
 		}
 		stats.Distribution[levelKey]++
+		countTags(stats.TagCounts, ach.Tags)
+		if organizer := ach.EffectiveOrganizer(); organizer != "" {
+			stats.OrganizerCounts[organizer]++
+		}
 	}
 
-	stats.TotalAchievements = int64(len(achievements))
-
 	return stats, nil
 }
 
-// GetTotalPointsForStudent
-func (r *reportRepository) GetTotalPointsForStudent(ctx context.Context, studentID uuid.UUID) (int64, error) {
+// countRevoked counts revoked achievements, optionally for a single student.
+// Jika filter punya rentang, dokumen Mongo dimuat untuk mengecek tanggal kejadiannya.
+func (r *reportRepository) countRevoked(ctx context.Context, studentID *uuid.UUID, filter model.ReportFilter) (int64, error) {
+	query := `SELECT mongo_achievement_id FROM achievement_references WHERE status = 'revoked' AND primary_reference_id IS NULL`
+	args := []interface{}{}
+	if studentID != nil {
+		query = `SELECT mongo_achievement_id FROM achievement_references WHERE student_id = $1 AND status = 'revoked'`
+		args = append(args, studentID.String())
+	}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var total int64
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+		if filter.From == nil && filter.To == nil {
+			total++
+			continue
+		}
+		ach, err := r.achievementMongoRepo.GetAchievementByID(id)
+		if err != nil || ach == nil || !filter.Contains(ach.EventDate()) {
+			continue
+		}
+		total++
+	}
+	return total, rows.Err()
+}
+
+// GetTotalPointsForStudent sums points of verified achievements whose event date lies within the filter
+func (r *reportRepository) GetTotalPointsForStudent(ctx context.Context, studentID uuid.UUID, filter model.ReportFilter) (int64, error) {
 	var ids []string
 	query := `SELECT mongo_achievement_id FROM achievement_references WHERE student_id = $1 AND status = 'verified'`
 	rows, err := r.db.QueryContext(ctx, query, studentID.String())
@@ -261,10 +320,22 @@ func (r *reportRepository) GetTotalPointsForStudent(ctx context.Context, student
 	var total int64
 	for _, id := range ids {
 		ach, err := r.achievementMongoRepo.GetAchievementByID(id)
-		if err != nil || ach == nil {
+		if err != nil || ach == nil || !filter.Contains(ach.EventDate()) {
 			continue
 		}
-		total += int64(ach.Points)
+		total += int64(ach.PointsFor(studentID))
 	}
 	return total, nil
-}
\ No newline at end of file
+}
+
+// countTags menambah hitungan per tag; tag duplikat dalam satu achievement dihitung sekali
+func countTags(counts map[string]int64, tags []string) {
+	seen := map[string]bool{}
+	for _, t := range tags {
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		counts[t]++
+	}
+}
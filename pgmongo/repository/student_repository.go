@@ -3,6 +3,8 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"BACKEND-UAS/pgmongo/model"
@@ -170,6 +172,81 @@ LIMIT $1 OFFSET $2
 	}, nil
 }
 
+// GetStudentsByCursor: keyset pagination (created_at DESC, id DESC) tanpa OFFSET; COUNT hanya jika diminta
+func (r *StudentRepository) GetStudentsByCursor(page model.CursorPage) (*model.CursorResponse[model.Student], error) {
+	return r.studentsByCursor(nil, page)
+}
+
+// GetAdviseesByCursor is GetStudentsByCursor restricted to the students advised by a lecturer
+func (r *StudentRepository) GetAdviseesByCursor(lecturerID uuid.UUID, page model.CursorPage) (*model.CursorResponse[model.Student], error) {
+	return r.studentsByCursor(&lecturerID, page)
+}
+
+func (r *StudentRepository) studentsByCursor(advisorID *uuid.UUID, page model.CursorPage) (*model.CursorResponse[model.Student], error) {
+	resp := &model.CursorResponse[model.Student]{Data: []model.Student{}, Limit: page.Limit}
+	if page.WithTotal {
+		var total int64
+		var err error
+		if advisorID != nil {
+			err = r.db.QueryRow(`SELECT COUNT(*) FROM students WHERE advisor_id = $1`, advisorID.String()).Scan(&total)
+		} else {
+			err = r.db.QueryRow(`SELECT COUNT(*) FROM students`).Scan(&total)
+		}
+		if err != nil {
+			return nil, err
+		}
+		resp.Total = &total
+	}
+
+	conds := []string{}
+	args := []interface{}{page.Limit + 1}
+	if advisorID != nil {
+		args = append(args, advisorID.String())
+		conds = append(conds, fmt.Sprintf("s.advisor_id = $%d", len(args)))
+	}
+	if page.After != nil {
+		args = append(args, page.After.CreatedAt, page.After.ID.String())
+		conds = append(conds, fmt.Sprintf("(s.created_at, s.id) < ($%d, $%d::uuid)", len(args)-1, len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	query := `
+SELECT 
+    s.id, s.user_id, s.student_id, s.program_study, s.academic_year, s.advisor_id, s.created_at,
+    u.id, u.username, u.email, u.full_name, u.role_id, u.is_active, u.created_at, u.updated_at,
+    l.id, l.user_id, l.lecturer_id, l.department, l.created_at,
+    lu.id, lu.username, lu.email, lu.full_name, lu.role_id, lu.is_active, lu.created_at, lu.updated_at
+FROM students s
+JOIN users u ON s.user_id = u.id
+LEFT JOIN lecturers l ON s.advisor_id = l.id
+LEFT JOIN users lu ON l.user_id = lu.id
+` + where + `
+ORDER BY s.created_at DESC, s.id DESC
+LIMIT $1
+`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data, err := r.scanStudentRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > page.Limit {
+		data = data[:page.Limit]
+		last := data[len(data)-1]
+		resp.NextCursor = model.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	if data != nil {
+		resp.Data = data
+	}
+	return resp, nil
+}
+
 // ===================== DETAIL =====================
 func (r *StudentRepository) GetStudentByID(id uuid.UUID) (*model.Student, error) {
 	query := `
@@ -0,0 +1,106 @@
+// File: BACKEND-UAS/pgmongo/repository/tag_repository.go
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"BACKEND-UAS/pgmongo/model"
+)
+
+type TagRepository interface {
+	ListTags() ([]model.Tag, error)
+	CreateTag(tag *model.Tag) error
+	UpdateTag(tag *model.Tag) error
+	DeleteTag(id uuid.UUID) error
+}
+
+// ErrTagInUse dikembalikan saat tag yang akan dihapus masih punya turunan
+var ErrTagInUse = errors.New("tag still has child tags")
+
+type TagRepositoryImpl struct {
+	db *sql.DB
+}
+
+var _ TagRepository = (*TagRepositoryImpl)(nil)
+
+func NewTagRepository(db *sql.DB) TagRepository {
+	return &TagRepositoryImpl{db: db}
+}
+
+// ListTags returns the whole vocabulary; ukurannya kecil sehingga resolusi & autocomplete dilakukan in-memory
+func (r *TagRepositoryImpl) ListTags() ([]model.Tag, error) {
+	rows, err := r.db.Query(`SELECT id, slug, name, parent_id, synonyms, created_at, updated_at FROM tags ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []model.Tag{}
+	for rows.Next() {
+		var (
+			t        model.Tag
+			id       string
+			parentID sql.NullString
+			synonyms pq.StringArray
+		)
+		if err := rows.Scan(&id, &t.Slug, &t.Name, &parentID, &synonyms, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		t.ID = parseUUID(id)
+		if parentID.Valid {
+			p := parseUUID(parentID.String)
+			t.ParentID = &p
+		}
+		t.Synonyms = []string(synonyms)
+		if t.Synonyms == nil {
+			t.Synonyms = []string{}
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+func (r *TagRepositoryImpl) CreateTag(tag *model.Tag) error {
+	_, err := r.db.Exec(
+		`INSERT INTO tags (id, slug, name, parent_id, synonyms, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		tag.ID.String(), tag.Slug, tag.Name, nullableUUID(tag.ParentID), pq.Array(tag.Synonyms), tag.CreatedAt, tag.UpdatedAt,
+	)
+	return err
+}
+
+func (r *TagRepositoryImpl) UpdateTag(tag *model.Tag) error {
+	res, err := r.db.Exec(
+		`UPDATE tags SET slug = $2, name = $3, parent_id = $4, synonyms = $5, updated_at = $6 WHERE id = $1`,
+		tag.ID.String(), tag.Slug, tag.Name, nullableUUID(tag.ParentID), pq.Array(tag.Synonyms), tag.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, sql.ErrNoRows)
+}
+
+func (r *TagRepositoryImpl) DeleteTag(id uuid.UUID) error {
+	var children int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM tags WHERE parent_id = $1`, id.String()).Scan(&children); err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrTagInUse
+	}
+	res, err := r.db.Exec(`DELETE FROM tags WHERE id = $1`, id.String())
+	if err != nil {
+		return err
+	}
+	return expectOneRow(res, sql.ErrNoRows)
+}
+
+func nullableUUID(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
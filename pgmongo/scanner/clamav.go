@@ -0,0 +1,100 @@
+// File: BACKEND-UAS/pgmongo/scanner/clamav.go
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// ClamAV berbicara dengan clamd lewat perintah INSTREAM: isi file dikirim sebagai chunk
+// [panjang uint32 big-endian][data], diakhiri chunk berpanjang nol, lalu clamd menjawab
+// "stream: OK", "stream: <signature> FOUND" atau "... ERROR".
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+var _ Scanner = (*ClamAV)(nil)
+
+// clamChunkSize harus di bawah StreamMaxLength clamd per chunk
+const clamChunkSize = 64 << 10
+
+func NewClamAV(network, address string) *ClamAV {
+	if network == "" {
+		network = "tcp"
+	}
+	if address == "" {
+		address = "localhost:3310"
+	}
+	return &ClamAV{network: network, address: address, timeout: 2 * time.Minute}
+}
+
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(c.timeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	conn.SetDeadline(deadline)
+
+	// prefix "z" = perintah dan jawaban diakhiri NUL
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+	buf := make([]byte, clamChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, rerr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return Result{}, fmt.Errorf("clamav: %w", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return Result{}, fmt.Errorf("clamav: %w", err)
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return Result{}, rerr
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return Result{}, fmt.Errorf("clamav: %w", err)
+	}
+	return parseClamReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+func parseClamReply(reply string) (Result, error) {
+	_, status, found := strings.Cut(reply, ": ")
+	if !found {
+		return Result{}, fmt.Errorf("clamav: unexpected reply %q", reply)
+	}
+	switch {
+	case status == "OK":
+		return Result{}, nil
+	case strings.HasSuffix(status, " FOUND"):
+		return Result{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+	default:
+		return Result{}, fmt.Errorf("clamav: %s", status)
+	}
+}
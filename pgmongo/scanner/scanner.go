@@ -0,0 +1,74 @@
+// File: BACKEND-UAS/pgmongo/scanner/scanner.go
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Result adalah hasil scan satu file
+type Result struct {
+	Infected  bool
+	Signature string // nama signature yang cocok, kosong jika bersih
+}
+
+// Scanner memeriksa isi file terhadap malware. Error berarti file belum bisa dinilai (mis. daemon
+// tidak bisa dihubungi) dan scan perlu diulang, bukan berarti file terinfeksi.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// Config memilih scanner; Driver "clamav" (default) atau "none" untuk mematikan scanning
+type Config struct {
+	Driver  string
+	Network string // tcp (default) atau unix
+	Address string // mis. localhost:3310 atau /var/run/clamav/clamd.ctl
+}
+
+// New membuat scanner sesuai cfg.Driver; "none" mengembalikan nil (scanning dimatikan)
+func New(cfg Config) (Scanner, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "clamav":
+		return NewClamAV(cfg.Network, cfg.Address), nil
+	case "none":
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("scanner: unknown driver %q", cfg.Driver)
+	}
+}
+
+// EICAR adalah file uji antivirus standar; dikenali oleh semua engine termasuk Fake.
+// Ditulis terpisah agar file sumber ini sendiri tidak ditandai antivirus.
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$` + `EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// Fake adalah scanner untuk test dan pengembangan offline: file terinfeksi jika mengandung salah satu
+// Signatures (default EICAR). Err, jika diisi, dikembalikan untuk mensimulasikan daemon yang mati.
+type Fake struct {
+	Signatures map[string]string // pola -> nama signature
+	Err        error
+}
+
+var _ Scanner = (*Fake)(nil)
+
+func NewFake() *Fake {
+	return &Fake{Signatures: map[string]string{EICAR: "Eicar-Test-Signature"}}
+}
+
+func (f *Fake) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	if f.Err != nil {
+		return Result{}, f.Err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Result{}, err
+	}
+	for pattern, name := range f.Signatures {
+		if bytes.Contains(data, []byte(pattern)) {
+			return Result{Infected: true, Signature: name}, nil
+		}
+	}
+	return Result{}, nil
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/repository"
+)
+
+// ==================== ACADEMIC CALENDAR ====================
+
+type AcademicPeriodService struct {
+	periodRepo repository.AcademicPeriodRepository
+}
+
+func NewAcademicPeriodService(periodRepo repository.AcademicPeriodRepository) *AcademicPeriodService {
+	return &AcademicPeriodService{periodRepo: periodRepo}
+}
+
+func (s *AcademicPeriodService) ListPeriods() ([]model.AcademicPeriod, error) {
+	return s.periodRepo.ListPeriods()
+}
+
+// CreatePeriod menambah semester ke kalender (admin); periode tidak boleh tumpang tindih
+func (s *AcademicPeriodService) CreatePeriod(role string, req model.AcademicPeriodRequest) (*model.AcademicPeriod, error) {
+	if role != "Admin" {
+		return nil, fiber.NewError(http.StatusForbidden, "only admins can manage the academic calendar")
+	}
+	period, err := req.ToPeriod()
+	if err != nil {
+		return nil, fiber.NewError(http.StatusBadRequest, err.Error())
+	}
+	existing, err := s.periodRepo.ListPeriods()
+	if err != nil {
+		return nil, err
+	}
+	for i := range existing {
+		other := &existing[i]
+		if other.Code == period.Code {
+			return nil, fiber.NewError(http.StatusConflict, "period "+period.Code+" already exists")
+		}
+		if other.Overlaps(period) {
+			return nil, fiber.NewError(http.StatusConflict, "period overlaps with "+other.Code)
+		}
+	}
+	period.ID = uuid.New()
+	period.CreatedAt = time.Now()
+	if err := s.periodRepo.CreatePeriod(period); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+func (s *AcademicPeriodService) DeletePeriod(role string, id uuid.UUID) error {
+	if role != "Admin" {
+		return fiber.NewError(http.StatusForbidden, "only admins can manage the academic calendar")
+	}
+	if err := s.periodRepo.DeletePeriod(id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fiber.NewError(http.StatusNotFound, "academic period not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// buildReportFilter menerjemahkan parameter period/from/to statistik.
+// period boleh kode semester atau tahun akademik; from/to (YYYY-MM-DD, to inklusif) mempersempit rentangnya.
+// Tanpa parameter apa pun dipakai tahun akademik yang sedang berjalan, atau seluruh data jika kalender belum mencakup hari ini.
+func buildReportFilter(calendar *model.AcademicCalendar, period, from, to string, now time.Time) (model.ReportFilter, error) {
+	filter := model.ReportFilter{Calendar: calendar}
+
+	if period == "" && from == "" && to == "" {
+		if current := calendar.PeriodFor(now); current != nil {
+			period = current.AcademicYear
+		}
+	}
+	if period != "" {
+		start, end, ok := calendar.Range(period)
+		if !ok {
+			return filter, fiber.NewError(http.StatusBadRequest, "unknown academic period "+period)
+		}
+		filter.From, filter.To = &start, &end
+	}
+
+	if from != "" {
+		t, err := parseDateParam(from, false)
+		if err != nil {
+			return filter, fiber.NewError(http.StatusBadRequest, "Invalid from date")
+		}
+		if filter.From == nil || t.After(*filter.From) {
+			filter.From = t
+		}
+	}
+	if to != "" {
+		t, err := parseDateParam(to, true)
+		if err != nil {
+			return filter, fiber.NewError(http.StatusBadRequest, "Invalid to date")
+		}
+		if filter.To == nil || t.Before(*filter.To) {
+			filter.To = t
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fiber.NewError(http.StatusBadRequest, "from must be before to")
+	}
+	return filter, nil
+}
+
+// @Summary List academic periods
+// @Description Kalender akademik (semester beserta rentang tanggal), urut tanggal mulai
+// @Tags Academic Periods
+// @Produce json
+// @Success 200 {array} model.AcademicPeriod
+// @Security ApiKeyAuth
+// @Router /academic-periods [get]
+func (s *AcademicPeriodService) ListPeriodsHandler(c *fiber.Ctx) error {
+	periods, err := s.ListPeriods()
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(periods)
+}
+
+// @Summary Create academic period
+// @Description Menambah semester ke kalender akademik (admin)
+// @Tags Academic Periods
+// @Accept json
+// @Produce json
+// @Param body body model.AcademicPeriodRequest true "Period"
+// @Success 201 {object} model.AcademicPeriod
+// @Failure 400 {object} model.ErrorResponse "Invalid period"
+// @Failure 403 {object} model.ErrorResponse "Not an admin"
+// @Failure 409 {object} model.ErrorResponse "Period exists or overlaps"
+// @Security ApiKeyAuth
+// @Router /academic-periods [post]
+func (s *AcademicPeriodService) CreatePeriodHandler(c *fiber.Ctx) error {
+	var req model.AcademicPeriodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	role, _ := c.Locals("role").(string)
+	period, err := s.CreatePeriod(role, req)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.Status(http.StatusCreated).JSON(period)
+}
+
+// @Summary Delete academic period
+// @Description Menghapus semester dari kalender akademik (admin)
+// @Tags Academic Periods
+// @Param id path string true "Period ID (UUID)"
+// @Success 204
+// @Failure 404 {object} model.ErrorResponse "Period not found"
+// @Security ApiKeyAuth
+// @Router /academic-periods/{id} [delete]
+func (s *AcademicPeriodService) DeletePeriodHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid period ID"})
+	}
+	role, _ := c.Locals("role").(string)
+	if err := s.DeletePeriod(role, id); err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,650 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/storage"
+)
+
+// ==================== ATTACHMENTS ====================
+
+// sniffLen adalah jumlah byte awal yang dibaca untuk mendeteksi jenis file
+const sniffLen = 512
+
+// readAttachment membaca dan memvalidasi isi upload: jenis file di-sniff dari isinya (Content-Type dari
+// client diabaikan), harus ada di allow-list, tidak boleh executable, ukurannya dibatasi per jenis dan
+// file polyglot ditolak. Isi file dibaca ke memori, paling banyak sebesar batas ukuran jenisnya.
+func (s *AchievementService) readAttachment(file io.Reader, size int64) ([]byte, string, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, "", err
+	}
+	head = head[:n]
+
+	if model.IsExecutable(head) {
+		return nil, "", model.NewAttachmentTypeError(model.AttachmentErrExecutable, "", "executable files are not allowed")
+	}
+	mimeType := model.SniffAttachmentType(head)
+	if mimeType == "" {
+		return nil, "", model.NewAttachmentTypeError(model.AttachmentErrUnsupported, "", "only PDF, JPEG, PNG and MP4 attachments are allowed")
+	}
+	limit := s.cfg.AttachmentMaxSize[mimeType]
+	if size > limit {
+		return nil, "", model.NewAttachmentTooLargeError(mimeType, limit)
+	}
+	data, err := io.ReadAll(io.LimitReader(io.MultiReader(bytes.NewReader(head), file), limit+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > limit {
+		return nil, "", model.NewAttachmentTooLargeError(mimeType, limit)
+	}
+	if reason := model.PolyglotReason(data, mimeType); reason != "" {
+		return nil, "", model.NewAttachmentTypeError(model.AttachmentErrPolyglot, mimeType, "file rejected: "+reason)
+	}
+	return data, mimeType, nil
+}
+
+// UploadAttachment memvalidasi file, menulisnya ke storage lalu menyimpan metadatanya di Mongo.
+// Hanya pemilik, saat draft atau rejected. Referensi ke blob dilepas lagi jika metadata gagal disimpan.
+func (s *AchievementService) UploadAttachment(id, userID uuid.UUID, file io.Reader, size int64, fileName string) (*model.Attachment, error) {
+	ref, ach, err := s.loadChangeableAchievement(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ach.Attachments) >= s.cfg.MaxAttachments {
+		return nil, &model.AttachmentError{
+			Status: http.StatusConflict, Code: model.AttachmentErrTooMany,
+			Message: "an achievement can have at most " + strconv.Itoa(s.cfg.MaxAttachments) + " attachments",
+		}
+	}
+
+	data, mimeType, err := s.readAttachment(file, size)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	attachment, err := s.storeAttachment(ctx, data, mimeType, fileName)
+	if err != nil {
+		return nil, err
+	}
+	attachment.ID = uuid.New().String()
+	if err := s.mongoRepo.AddAttachment(ref.MongoAchievementID, attachment); err != nil {
+		s.releaseBlob(attachment)
+		return nil, err
+	}
+	s.wakeScanner()
+	return &attachment, nil
+}
+
+// storeAttachment menyimpan file yang sudah divalidasi (di memori) lalu membuat preview dan metadatanya
+func (s *AchievementService) storeAttachment(ctx context.Context, data []byte, mimeType, fileName string) (model.Attachment, error) {
+	sum := sha256.Sum256(data)
+	open := func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(data)), nil }
+	att, err := s.storeBlob(ctx, hex.EncodeToString(sum[:]), int64(len(data)), mimeType, fileName, open)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	s.generatePreviews(ctx, &att, data)
+	s.extractMetadata(ctx, &att, data)
+	return att, nil
+}
+
+// storeBlob menyimpan isi file secara content-addressed (key diturunkan dari sha256 isinya), sehingga file
+// identik hanya disimpan sekali, lalu menyusun metadatanya (tanpa ID). open dipanggil hanya jika file blob
+// belum ada di storage. RefCount blob sudah dinaikkan saat fungsi ini berhasil; pemanggil wajib memanggil
+// releaseBlob jika metadata gagal disimpan. Kunci hash dipegang bersama GC (lihat deleteOrphan) sehingga
+// file tidak bisa dihapus di antara pengecekan dan kenaikan refCount.
+func (s *AchievementService) storeBlob(ctx context.Context, hash string, size int64, mimeType, fileName string, open func() (io.ReadCloser, error)) (model.Attachment, error) {
+	// ekstensi mengikuti jenis hasil sniffing, bukan nama file dari client
+	fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName)) + model.AllowedAttachmentTypes[mimeType]
+	blob := model.AttachmentBlob{Hash: hash, Key: model.BlobKey(hash, mimeType), Size: size, ContentType: mimeType}
+
+	// refCount dinaikkan sebelum file dicek/ditulis agar GC tidak menghapus blob yang sedang dipakai ulang
+	defer s.blobLocks.lock(hash)()
+	if err := s.mongoRepo.AdjustBlobRefCount(blob, 1); err != nil {
+		return model.Attachment{}, err
+	}
+	_, err := s.storage.Stat(ctx, blob.Key)
+	if errors.Is(err, storage.ErrNotFound) {
+		var body io.ReadCloser
+		if body, err = open(); err == nil {
+			err = s.storage.Put(ctx, blob.Key, body, size, mimeType)
+			body.Close()
+		}
+	}
+	if err != nil {
+		_ = s.mongoRepo.AdjustBlobRefCount(blob, -1)
+		return model.Attachment{}, err
+	}
+	att := model.Attachment{
+		FileName:    fileName,
+		FileType:    mimeType,
+		UploadedAt:  time.Now(),
+		ContentHash: hash,
+		Size:        size,
+		StorageKey:  blob.Key,
+	}
+	s.initialScanStatus(&att)
+	return att, nil
+}
+
+// loadChangeableAchievement: attachment hanya boleh diubah pemilik, lewat reference utama, dan hanya saat draft atau rejected
+func (s *AchievementService) loadChangeableAchievement(id, userID uuid.UUID) (*model.AchievementReference, *model.Achievement, error) {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref == nil || ref.Status == "deleted" {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	student, err := s.postgresRepo.GetStudentByUserID(userID)
+	if err != nil || student == nil || student.ID != ref.StudentID {
+		return nil, nil, fiber.NewError(http.StatusForbidden, "only the owner can change attachments")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return nil, nil, err
+	}
+	if ref.Status != "draft" && ref.Status != "rejected" {
+		return nil, nil, fiber.NewError(http.StatusBadRequest, "attachments can only be changed while the achievement is draft or rejected")
+	}
+	ach, err := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil || ach == nil || ach.DeletedAt != nil {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	return ref, ach, nil
+}
+
+// loadChangeableAttachment adalah loadChangeableAchievement ditambah attachment yang dituju
+func (s *AchievementService) loadChangeableAttachment(id, userID uuid.UUID, attachmentID string) (*model.AchievementReference, *model.Attachment, error) {
+	ref, ach, err := s.loadChangeableAchievement(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	att := ach.FindAttachment(attachmentID)
+	if att == nil {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "attachment not found")
+	}
+	return ref, att, nil
+}
+
+// releaseBlob menurunkan refCount blob yang dipakai attachment. File-nya tidak langsung dihapus karena bisa
+// dipakai attachment lain atau upload yang sedang berjalan; blob tanpa referensi dibersihkan oleh GC.
+func (s *AchievementService) releaseBlob(att model.Attachment) {
+	if !model.IsBlobKey(att.StorageKey) {
+		return
+	}
+	if err := s.mongoRepo.AdjustBlobRefCount(model.AttachmentBlob{Hash: att.ContentHash}, -1); err != nil {
+		log.Printf("failed to release attachment blob %s: %v", att.ContentHash, err)
+	}
+}
+
+// discardStoredFile membuang file lama dari storage. File yang sudah pernah dilihat reviewer (status rejected)
+// disalin ke karantina sebagai jejak audit; file draft langsung dilepas. Kegagalan hanya dicatat di log
+// karena metadata di Mongo sudah dilepas.
+func (s *AchievementService) discardStoredFile(ctx context.Context, att model.Attachment, quarantine bool) {
+	key := att.Key()
+	if key == "" {
+		return
+	}
+	if strings.HasPrefix(key, model.QuarantineKeyPrefix) {
+		return // file terinfeksi sudah dikarantina oleh scanner
+	}
+	if quarantine {
+		if _, err := s.moveToQuarantine(ctx, att); err != nil {
+			log.Printf("failed to quarantine attachment %s: %v", key, err)
+		}
+		return
+	}
+	if model.IsBlobKey(key) {
+		s.releaseBlob(att)
+		return
+	}
+	if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("failed to delete attachment %s: %v", key, err)
+	}
+}
+
+// moveToQuarantine menyalin file attachment ke prefix karantina lalu melepas aslinya (blob: refCount turun,
+// upload lama: file dihapus); mengembalikan key baru
+func (s *AchievementService) moveToQuarantine(ctx context.Context, att model.Attachment) (string, error) {
+	key := att.Key()
+	body, info, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	quarantined := model.QuarantineKeyPrefix + strings.TrimPrefix(key, model.AttachmentKeyPrefix)
+	if err := s.storage.Put(ctx, quarantined, body, info.Size, info.ContentType); err != nil {
+		return "", err
+	}
+	if model.IsBlobKey(key) {
+		s.releaseBlob(att)
+		return quarantined, nil
+	}
+	return quarantined, s.storage.Delete(ctx, key)
+}
+
+// DeleteAttachment melepas attachment dari prestasi ($pull) dan membuang file-nya
+func (s *AchievementService) DeleteAttachment(id, userID uuid.UUID, attachmentID string) error {
+	ref, att, err := s.loadChangeableAttachment(id, userID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.mongoRepo.RemoveAttachment(ref.MongoAchievementID, *att); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return fiber.NewError(http.StatusNotFound, "attachment not found")
+		}
+		return err
+	}
+	s.discardStoredFile(context.Background(), *att, ref.Status == "rejected")
+
+	history := model.StatusHistory{Status: ref.Status, ChangedBy: &userID, ChangedAt: time.Now(), Note: "Attachment " + att.FileName + " dihapus"}
+	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
+	return nil
+}
+
+// ReplaceAttachment mengganti file attachment dengan validasi yang sama seperti upload; ID attachment tetap
+func (s *AchievementService) ReplaceAttachment(id, userID uuid.UUID, attachmentID string, file io.Reader, size int64, fileName string) (*model.Attachment, error) {
+	ref, old, err := s.loadChangeableAttachment(id, userID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	data, mimeType, err := s.readAttachment(file, size)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	replacement, err := s.storeAttachment(ctx, data, mimeType, fileName)
+	if err != nil {
+		return nil, err
+	}
+	replacement.ID = old.ID
+	if replacement.ID == "" {
+		replacement.ID = uuid.New().String()
+	}
+	if err := s.mongoRepo.ReplaceAttachment(ref.MongoAchievementID, *old, replacement); err != nil {
+		s.releaseBlob(replacement)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fiber.NewError(http.StatusNotFound, "attachment not found")
+		}
+		return nil, err
+	}
+	s.discardStoredFile(ctx, *old, ref.Status == "rejected")
+	s.wakeScanner()
+
+	history := model.StatusHistory{Status: ref.Status, ChangedBy: &userID, ChangedAt: time.Now(), Note: "Attachment " + old.FileName + " diganti dengan " + replacement.FileName}
+	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
+	return &replacement, nil
+}
+
+// ensureCanView: mahasiswa hanya prestasinya sendiri, dosen wali milik bimbingannya (dan yang sedang
+// di-claim/ditugaskan kepadanya), admin semua
+func (s *AchievementService) ensureCanView(ref *model.AchievementReference, userID uuid.UUID, role string) error {
+	if role == "Dosen Wali" && s.holdsClaim(ref, userID) {
+		return nil
+	}
+	visible, err := s.visibleStudentIDs(userID, role)
+	if err != nil {
+		return err
+	}
+	if visible == nil {
+		return nil
+	}
+	for _, sid := range visible {
+		if sid == ref.StudentID {
+			return nil
+		}
+	}
+	return fiber.NewError(http.StatusForbidden, "you are not allowed to view this achievement")
+}
+
+// GetAttachment memeriksa hak akses lalu mengembalikan metadata attachment beserta info object di storage
+func (s *AchievementService) GetAttachment(id uuid.UUID, attachmentID string, userID uuid.UUID, role string) (*model.Attachment, *storage.ObjectInfo, error) {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref == nil || ref.Status == "deleted" {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	if err := s.ensureCanView(ref, userID, role); err != nil {
+		return nil, nil, err
+	}
+	ach, err := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil || ach == nil || ach.DeletedAt != nil {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	att := ach.FindAttachment(attachmentID)
+	if att == nil || att.Key() == "" {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "attachment not found")
+	}
+	switch {
+	case att.IsScanPending():
+		return nil, nil, fiber.NewError(http.StatusConflict, "attachment is waiting for the virus scan")
+	case att.ScanStatus == model.ScanStatusInfected:
+		return nil, nil, fiber.NewError(http.StatusForbidden, "attachment was quarantined: "+att.ScanResult)
+	case att.ScanStatus != model.ScanStatusClean:
+		return nil, nil, fiber.NewError(http.StatusConflict, "attachment could not be scanned: "+att.ScanResult)
+	}
+	info, err := s.storage.Stat(context.Background(), att.Key())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "attachment file is missing")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return att, info, nil
+}
+
+// AttachmentLink membuat signed link berumur AttachmentLinkTTL (hak akses dicek saat link dibuat, bukan saat dipakai)
+func (s *AchievementService) AttachmentLink(id uuid.UUID, attachmentID string, userID uuid.UUID, role string) (*model.AttachmentLink, error) {
+	att, _, err := s.GetAttachment(id, attachmentID, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(s.cfg.AttachmentLinkTTL)
+	link, err := s.storage.SignedURL(context.Background(), att.Key(), s.cfg.AttachmentLinkTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &model.AttachmentLink{URL: link, ExpiresAt: expiresAt}, nil
+}
+
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// parseByteRange parses a single "bytes=" range. Multi-range diabaikan (ok=false) dan file dikirim utuh.
+func parseByteRange(header string, size int64) (start, length int64, ok bool, err error) {
+	spec, found := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false, nil
+	}
+	first, last, found := strings.Cut(spec, "-")
+	if !found {
+		return 0, 0, false, nil
+	}
+	if first == "" {
+		// suffix range: n byte terakhir
+		n, perr := strconv.ParseInt(last, 10, 64)
+		if perr != nil || n <= 0 {
+			return 0, 0, false, errRangeNotSatisfiable
+		}
+		n = min(n, size)
+		return size - n, n, true, nil
+	}
+	start, perr := strconv.ParseInt(first, 10, 64)
+	if perr != nil || start < 0 || start >= size {
+		return 0, 0, false, errRangeNotSatisfiable
+	}
+	end := size - 1
+	if last != "" {
+		end, perr = strconv.ParseInt(last, 10, 64)
+		if perr != nil || end < start {
+			return 0, 0, false, errRangeNotSatisfiable
+		}
+		end = min(end, size-1)
+	}
+	return start, end - start + 1, true, nil
+}
+
+// sendObject men-stream object dari storage dengan dukungan Range (206/416)
+func (s *AchievementService) sendObject(c *fiber.Ctx, info *storage.ObjectInfo, fileName, contentType, disposition string) error {
+	if contentType == "" {
+		contentType = info.ContentType
+	}
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": fileName}))
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+
+	ctx := context.Background()
+	start, length, partial, err := parseByteRange(c.Get(fiber.HeaderRange), info.Size)
+	if err != nil {
+		c.Set(fiber.HeaderContentRange, "bytes */"+strconv.FormatInt(info.Size, 10))
+		return c.Status(http.StatusRequestedRangeNotSatisfiable).JSON(fiber.Map{"error": "Requested range not satisfiable"})
+	}
+	if !partial {
+		body, _, err := s.storage.Get(ctx, info.Key)
+		if err != nil {
+			return handleServiceError(c, err)
+		}
+		return c.SendStream(body, int(info.Size))
+	}
+	body, err := s.storage.GetRange(ctx, info.Key, start, length)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	c.Set(fiber.HeaderContentRange, "bytes "+strconv.FormatInt(start, 10)+"-"+strconv.FormatInt(start+length-1, 10)+"/"+strconv.FormatInt(info.Size, 10))
+	c.Status(http.StatusPartialContent)
+	return c.SendStream(body, int(length))
+}
+
+// @Summary Download attachment
+// @Description Mengunduh file attachment (mahasiswa pemilik, dosen wali pembimbing, atau admin). Hanya file yang lolos scan antivirus. Mendukung header Range; gunakan inline=true untuk ditampilkan di browser.
+// @Tags Achievements
+// @Produce octet-stream
+// @Param id path string true "Achievement ID (UUID)"
+// @Param attachmentId path string true "Attachment ID (id, atau fileName untuk upload lama)"
+// @Param inline query bool false "Content-Disposition inline alih-alih attachment"
+// @Success 200 {file} file
+// @Success 206 {file} file "Partial content"
+// @Failure 403 {object} model.ErrorResponse "Not allowed to view this achievement, or attachment quarantined"
+// @Failure 404 {object} model.ErrorResponse "Attachment not found"
+// @Failure 409 {object} model.ErrorResponse "Attachment is waiting for the virus scan"
+// @Failure 416 {object} model.ErrorResponse "Range not satisfiable"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/attachments/{attachmentId} [get]
+func (s *AchievementService) DownloadAttachmentHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	att, info, err := s.GetAttachment(id, c.Params("attachmentId"), userID, role)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	disposition := "attachment"
+	if c.QueryBool("inline") {
+		disposition = "inline"
+	}
+	return s.sendObject(c, info, att.FileName, att.FileType, disposition)
+}
+
+// @Summary Signed attachment link
+// @Description Membuat link unduhan berumur pendek (tanpa header Authorization) untuk disematkan di frontend, mis. pada img/iframe
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param attachmentId path string true "Attachment ID (id, atau fileName untuk upload lama)"
+// @Success 200 {object} model.AttachmentLink
+// @Failure 403 {object} model.ErrorResponse "Not allowed to view this achievement"
+// @Failure 404 {object} model.ErrorResponse "Attachment not found"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/attachments/{attachmentId}/link [get]
+func (s *AchievementService) AttachmentLinkHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	link, err := s.AttachmentLink(id, c.Params("attachmentId"), userID, role)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(link)
+}
+
+// @Summary Open signed file link
+// @Description Melayani signed link dari storage lokal (tanpa autentikasi; signature dan masa berlaku diverifikasi). Storage S3 memberikan presigned URL langsung sehingga route ini tidak dipakai.
+// @Tags Achievements
+// @Produce octet-stream
+// @Param key path string true "Storage key"
+// @Param expires query int true "Unix timestamp kedaluwarsa"
+// @Param signature query string true "HMAC signature"
+// @Success 200 {file} file
+// @Failure 403 {object} model.ErrorResponse "Invalid or expired signature"
+// @Router /files/{key} [get]
+func (s *AchievementService) SignedFileHandler(c *fiber.Ctx) error {
+	verifier, ok := s.storage.(storage.SignatureVerifier)
+	if !ok {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": "Not found"})
+	}
+	key := c.Params("*")
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil || verifier.VerifySignature(key, expires, c.Query("signature")) != nil {
+		return c.Status(http.StatusForbidden).JSON(fiber.Map{"error": "Invalid or expired link"})
+	}
+	info, err := s.storage.Stat(context.Background(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": "File not found"})
+	}
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return s.sendObject(c, info, filepath.Base(key), "", "inline")
+}
+
+// @Summary Upload attachment
+// @Description Upload file attachment ke prestasi (pemilik saja, hanya saat draft atau rejected). Hanya PDF, JPEG dan PNG (dideteksi dari isi file); executable dan file polyglot ditolak. File disimpan di storage backend yang dikonfigurasi (local atau S3-compatible).
+// @Tags Achievements
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param file formData file true "Attachment file"
+// @Success 200 {object} model.Attachment
+// @Failure 400 {object} model.ErrorResponse "No file, or achievement is not draft or rejected"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
+// @Failure 404 {object} model.ErrorResponse "Achievement not found"
+// @Failure 409 {object} model.AttachmentError "Too many attachments"
+// @Failure 413 {object} model.AttachmentError "File too large for its type"
+// @Failure 415 {object} model.AttachmentError "Type not allowed, executable or polyglot"
+// @Failure 500 {object} model.ErrorResponse "Failed to upload"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/attachments [post]
+func (s *AchievementService) UploadAttachmentHandler(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	defer src.Close()
+
+	ext := filepath.Ext(file.Filename)
+	fileName := uuid.New().String() + ext
+
+	attachment, err := s.UploadAttachment(id, userID, src, file.Size, fileName)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(attachment)
+}
+
+// @Summary Replace attachment
+// @Description Mengganti file attachment (pemilik saja, hanya saat draft atau rejected). Validasi sama seperti upload; ID attachment tetap. File lama dihapus, atau dikarantina jika prestasi sudah pernah direview (rejected).
+// @Tags Achievements
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param attachmentId path string true "Attachment ID (id, atau fileName untuk upload lama)"
+// @Param file formData file true "Attachment file"
+// @Success 200 {object} model.Attachment
+// @Failure 400 {object} model.ErrorResponse "Achievement is not draft or rejected"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
+// @Failure 404 {object} model.ErrorResponse "Attachment not found"
+// @Failure 413 {object} model.AttachmentError "File too large for its type"
+// @Failure 415 {object} model.AttachmentError "Type not allowed, executable or polyglot"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/attachments/{attachmentId} [put]
+func (s *AchievementService) ReplaceAttachmentHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
+	}
+	src, err := file.Open()
+	if err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	defer src.Close()
+
+	attachment, err := s.ReplaceAttachment(id, userID, c.Params("attachmentId"), src, file.Size, uuid.New().String()+filepath.Ext(file.Filename))
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(attachment)
+}
+
+// @Summary Delete attachment
+// @Description Menghapus attachment (pemilik saja, hanya saat draft atau rejected). File dihapus dari storage, atau dikarantina jika prestasi sudah pernah direview (rejected); tercatat di status history.
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param attachmentId path string true "Attachment ID (id, atau fileName untuk upload lama)"
+// @Success 200 {object} map[string]string "message"
+// @Failure 400 {object} model.ErrorResponse "Achievement is not draft or rejected"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
+// @Failure 404 {object} model.ErrorResponse "Attachment not found"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/attachments/{attachmentId} [delete]
+func (s *AchievementService) DeleteAttachmentHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	if err := s.DeleteAttachment(id, userID, c.Params("attachmentId")); err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"message": "Attachment deleted"})
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/repository"
+)
+
+// ==================== DRAFT AUTOSAVE & CLEANUP ====================
+
+// loadOwnDraft memastikan achievement masih bisa diedit dan milik mahasiswa yang login
+func (s *AchievementService) loadOwnDraft(id, userID uuid.UUID) (*model.AchievementReference, error) {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref == nil || ref.Status == "deleted" {
+		return nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	student, err := s.postgresRepo.GetStudentByUserID(userID)
+	if err != nil || student == nil || student.ID != ref.StudentID {
+		return nil, fiber.NewError(http.StatusForbidden, "only the owner can autosave this achievement")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return nil, err
+	}
+	if !isEditableStatus(ref.Status) {
+		return nil, fiber.NewError(http.StatusBadRequest, "cannot update this achievement")
+	}
+	return ref, nil
+}
+
+// AutosaveAchievement menyimpan konten yang sedang diketik tanpa validasi penuh, tanpa ETag,
+// tanpa status history dan tanpa revisi. Hanya updated_at reference yang disentuh agar draft tidak dianggap terbengkalai.
+func (s *AchievementService) AutosaveAchievement(id, userID uuid.UUID, content model.Achievement) (*model.AchievementAutosave, error) {
+	ref, err := s.loadOwnDraft(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	autosave := &model.AchievementAutosave{
+		MongoAchievementID: ref.MongoAchievementID,
+		AchievementID:      ref.ID,
+		BaseVersion:        ref.Version,
+		Content:            editableContent(content),
+		SavedBy:            userID,
+		SavedAt:            time.Now(),
+	}
+	if err := s.mongoRepo.SaveAutosave(autosave); err != nil {
+		return nil, err
+	}
+	if err := s.postgresRepo.TouchAchievementReference(ref.ID); err != nil {
+		return nil, err
+	}
+	return autosave, nil
+}
+
+func (s *AchievementService) GetAutosave(id, userID uuid.UUID) (*model.AchievementAutosave, error) {
+	ref, err := s.loadOwnDraft(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	autosave, err := s.mongoRepo.GetAutosave(ref.MongoAchievementID)
+	if err != nil {
+		return nil, err
+	}
+	if autosave == nil {
+		return nil, fiber.NewError(http.StatusNotFound, "no autosaved draft")
+	}
+	return autosave, nil
+}
+
+// editableContent hanya menyalin field yang boleh diedit mahasiswa (lihat editableAchievementFields)
+func editableContent(a model.Achievement) model.Achievement {
+	return model.Achievement{
+		AchievementType:   a.AchievementType,
+		Title:             a.Title,
+		Description:       a.Description,
+		Details:           a.Details,
+		Tags:              a.Tags,
+		Level:             a.Level,
+		EventAt:           a.EventAt,
+		Organizer:         a.Organizer,
+		Location:          a.Location,
+		CertificateNumber: a.CertificateNumber,
+		VerificationURL:   a.VerificationURL,
+	}
+}
+
+// CleanupDrafts mengirim satu pengingat untuk draft yang tidak disentuh melebihi DraftReminderAfter, lalu menghapus
+// (soft delete) draft yang tidak disentuh melebihi DraftAbandonAfter dan sudah diingatkan minimal
+// DraftAbandonAfter-DraftReminderAfter sebelumnya.
+func (s *AchievementService) CleanupDrafts(now time.Time) (*model.DraftCleanupResult, error) {
+	result := &model.DraftCleanupResult{}
+	grace := s.cfg.DraftAbandonAfter - s.cfg.DraftReminderAfter
+	untouchedSince := now.Add(-s.cfg.DraftAbandonAfter)
+
+	abandoned, err := s.postgresRepo.FindAbandonedDrafts(untouchedSince, now.Add(-grace))
+	if err != nil {
+		return result, err
+	}
+	abandonDays := strconv.Itoa(int(s.cfg.DraftAbandonAfter.Hours() / 24))
+	for _, ref := range abandoned {
+		// bersyarat: draft yang disentuh atau disubmit sejak dibaca dilewati
+		if err := s.postgresRepo.SoftDeleteAchievementReference(ref.ID, untouchedSince); err != nil {
+			if errors.Is(err, repository.ErrDraftChanged) {
+				continue
+			}
+			return result, err
+		}
+		if err := s.mongoRepo.SoftDeleteAchievement(ref.MongoAchievementID); err != nil {
+			return result, err
+		}
+		history := model.StatusHistory{Status: "deleted", ChangedAt: now, Note: "Dihapus otomatis: draft tidak diubah selama " + abandonDays + " hari"}
+		_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
+		_ = s.mongoRepo.DeleteAutosave(ref.MongoAchievementID)
+		result.Deleted++
+	}
+
+	stale, err := s.postgresRepo.FindStaleDrafts(now.Add(-s.cfg.DraftReminderAfter), true)
+	if err != nil {
+		return result, err
+	}
+	for _, ref := range stale {
+		// penghapusan tidak pernah lebih cepat dari grace period sejak pengingat ini
+		deleteAt := ref.UpdatedAt.Add(s.cfg.DraftAbandonAfter)
+		if earliest := now.Add(grace); deleteAt.Before(earliest) {
+			deleteAt = earliest
+		}
+		deleteOn := deleteAt.Format("2006-01-02")
+		notif := model.Notification{
+			ID:        uuid.New(),
+			Type:      "draft_reminder",
+			Title:     "Draft belum diselesaikan",
+			Message:   "Draft prestasi Anda belum diubah sejak " + ref.UpdatedAt.Format("2006-01-02") + " dan akan dihapus otomatis pada " + deleteOn,
+			CreatedAt: now,
+		}
+		if err := s.mongoRepo.AddNotification(ref.MongoAchievementID, notif); err != nil {
+			return result, err
+		}
+		if err := s.postgresRepo.MarkDraftReminded(ref.ID); err != nil {
+			return result, err
+		}
+		result.Reminded++
+	}
+	return result, nil
+}
+
+// StartDraftCleanup menjalankan CleanupDrafts setiap interval sampai ctx dibatalkan
+func (s *AchievementService) StartDraftCleanup(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			result, err := s.CleanupDrafts(time.Now())
+			if err != nil {
+				log.Printf("draft cleanup failed: %v", err)
+			} else if result.Deleted > 0 || result.Reminded > 0 {
+				log.Printf("draft cleanup: %d reminded, %d deleted", result.Reminded, result.Deleted)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// @Summary Autosave draft
+// @Description Menyimpan konten draft yang sedang dikerjakan (pemilik saja). Tidak butuh If-Match, tidak menaikkan version, tidak menambah status history maupun revisi; autosave dihapus saat konten disimpan lewat PUT/PATCH atau disubmit.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param achievement body model.Achievement true "Konten draft"
+// @Success 200 {object} model.AchievementAutosave
+// @Failure 400 {object} model.ErrorResponse "Achievement is not editable"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/autosave [put]
+func (s *AchievementService) AutosaveHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	var content model.Achievement
+	if err := c.BodyParser(&content); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	autosave, err := s.AutosaveAchievement(id, userID, content)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(autosave)
+}
+
+// @Summary Get autosaved draft
+// @Description Mengambil autosave terakhir (pemilik saja); base_version yang berbeda dari ETag sekarang berarti konten sudah disimpan ulang sejak autosave
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Success 200 {object} model.AchievementAutosave
+// @Failure 404 {object} model.ErrorResponse "No autosaved draft"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/autosave [get]
+func (s *AchievementService) GetAutosaveHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	autosave, err := s.GetAutosave(id, userID)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(autosave)
+}
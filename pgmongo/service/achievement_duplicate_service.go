@@ -0,0 +1,81 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+)
+
+// ==================== DUPLICATE DETECTION ====================
+
+const achievementLinkPrefix = "/api/v1/achievements/"
+
+// FindDuplicates mencari achievement lain yang kemungkinan besar entri yang sama (sertifikat/kompetisi yang sama)
+func (s *AchievementService) FindDuplicates(id uuid.UUID) ([]model.DuplicateMatch, error) {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil {
+		return nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	ach, err := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil || ach == nil {
+		return nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	return s.findDuplicates(ach)
+}
+
+// DuplicateWarnings adalah hasil FindDuplicates untuk pemilik prestasi (create/submit): hanya id dan alasan
+func (s *AchievementService) DuplicateWarnings(id uuid.UUID) ([]model.DuplicateWarning, error) {
+	matches, err := s.FindDuplicates(id)
+	if err != nil {
+		return nil, err
+	}
+	warnings := make([]model.DuplicateWarning, 0, len(matches))
+	for _, m := range matches {
+		warnings = append(warnings, m.Warning())
+	}
+	return warnings, nil
+}
+
+func (s *AchievementService) findDuplicates(ach *model.Achievement) ([]model.DuplicateMatch, error) {
+	candidates, err := s.mongoRepo.FindDuplicateCandidates(ach)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := map[string][]string{}
+	titles := map[string]string{}
+	mongoIDs := []string{}
+	for i := range candidates {
+		r := model.DuplicateReasons(ach, &candidates[i])
+		if len(r) == 0 {
+			continue
+		}
+		mid := candidates[i].ID.Hex()
+		reasons[mid] = r
+		titles[mid] = candidates[i].Title
+		mongoIDs = append(mongoIDs, mid)
+	}
+
+	matches := []model.DuplicateMatch{}
+	if len(mongoIDs) == 0 {
+		return matches, nil
+	}
+	refs, err := s.postgresRepo.GetAchievementReferencesByMongoIDs(mongoIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range refs {
+		matches = append(matches, model.DuplicateMatch{
+			AchievementID: ref.ID,
+			StudentID:     ref.StudentID,
+			Title:         titles[ref.MongoAchievementID],
+			Status:        ref.Status,
+			Reasons:       reasons[ref.MongoAchievementID],
+			Link:          achievementLinkPrefix + ref.ID.String(),
+		})
+	}
+	return matches, nil
+}
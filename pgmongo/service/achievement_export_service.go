@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+)
+
+// ==================== EXPORT ====================
+
+// maxExportRows membatasi ukuran satu export; filter perlu dipersempit jika terlampaui
+const maxExportRows = 5000
+
+var achievementCSVHeader = []string{
+	"id", "student_id", "status", "title", "achievement_type", "level", "points", "tags",
+	"event_date", "organizer", "location", "certificate_number", "verification_url",
+	"created_at", "submitted_at", "verified_at",
+}
+
+// ExportAchievements mengembalikan seluruh achievement yang cocok dengan filter list (tanpa pagination) beserta kontennya
+func (s *AchievementService) ExportAchievements(userID uuid.UUID, role string, filter model.AchievementListFilter) ([]model.AchievementReference, error) {
+	refFilter, _, err := s.resolveListFilter(userID, role, filter)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.postgresRepo.FindAchievementReferences(refFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(refs) > maxExportRows {
+		return nil, fiber.NewError(http.StatusBadRequest, "export exceeds "+strconv.Itoa(maxExportRows)+" rows, narrow the filter")
+	}
+	if err := includeAchievements(s.mongoRepo, refs); err != nil {
+		return nil, err
+	}
+
+	kept := refs[:0]
+	keys := map[string]model.AchievementListKey{}
+	for _, ref := range refs {
+		if ref.Achievement == nil {
+			continue
+		}
+		ach := ref.Achievement
+		eventDate := ach.EventDate()
+		keys[ref.MongoAchievementID] = model.AchievementListKey{
+			MongoID: ref.MongoAchievementID, Title: ach.Title, AchievementType: ach.AchievementType,
+			Level: ach.Level, Points: ach.Points, EventDate: &eventDate, CreatedAt: ach.CreatedAt,
+		}
+		kept = append(kept, ref)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return compareListItems(kept[i], kept[j], keys, filter.Sort) < 0
+	})
+	return kept, nil
+}
+
+// writeAchievementsCSV menulis satu baris per achievement; tanggal kejadian memakai EventDate (YYYY-MM-DD)
+func writeAchievementsCSV(w io.Writer, refs []model.AchievementReference) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(achievementCSVHeader); err != nil {
+		return err
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	for _, ref := range refs {
+		ach := ref.Achievement
+		row := []string{
+			ref.ID.String(), ref.StudentID.String(), ref.Status, ach.Title, ach.AchievementType, ach.Level,
+			strconv.Itoa(ach.Points), strings.Join(ach.Tags, ";"),
+			ach.EventDate().Format("2006-01-02"), ach.EffectiveOrganizer(), ach.Location, ach.CertificateNumber, ach.VerificationURL,
+			ref.CreatedAt.Format(time.RFC3339), formatTime(ref.SubmittedAt), formatTime(ref.VerifiedAt),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// @Summary Export achievements
+// @Description Export daftar prestasi (CSV) dengan filter dan sort yang sama seperti list, mengikuti visibilitas role. Termasuk tanggal kejadian, penyelenggara, lokasi, nomor sertifikat dan URL verifikasi.
+// @Tags Achievements
+// @Produce text/csv
+// @Param format query string false "Format export (saat ini hanya csv)"
+// @Param status query string false "Filter status"
+// @Param type query string false "Filter achievement type"
+// @Param event_from query string false "Tanggal kejadian dari"
+// @Param event_to query string false "Tanggal kejadian sampai, inklusif"
+// @Param sort query string false "Sort fields, sama seperti list"
+// @Success 200 {file} file
+// @Failure 400 {object} model.ErrorResponse "Invalid filter, unsupported format or too many rows"
+// @Failure 403 {object} model.ErrorResponse "Forbidden"
+// @Security ApiKeyAuth
+// @Router /achievements/export [get]
+func (s *AchievementService) ExportHandler(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+	if format := c.Query("format", "csv"); format != "csv" {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "unsupported export format: " + format})
+	}
+
+	filter, err := parseListFilter(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	refs, err := s.ExportAchievements(userID, role, filter)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeAchievementsCSV(&buf, refs); err != nil {
+		return handleServiceError(c, err)
+	}
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="achievements-`+time.Now().Format("20060102")+`.csv"`)
+	return c.Send(buf.Bytes())
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/storage"
+)
+
+// ==================== ATTACHMENT GC ====================
+
+// CollectAttachmentGarbage membandingkan isi storage dengan attachment yang tercatat di Mongo (dokumen
+// achievement dan snapshot revisi) dan melaporkan file yatim, attachment yang file-nya hilang, serta blob
+// yang refCount-nya melenceng. Dengan repair=true file yatim dihapus, attachment yang file-nya hilang
+// diarahkan ke blob dengan hash yang sama (atau ditandai scan_failed agar diganti pemiliknya) dan refCount
+// dikoreksi. File dan blob yang disentuh dalam AttachmentGCGrace terakhir tidak pernah dihapus atau
+// dikoreksi, dan prefix karantina tidak pernah disentuh.
+func (s *AchievementService) CollectAttachmentGarbage(ctx context.Context, repair bool, now time.Time) (*model.AttachmentGCReport, error) {
+	report := &model.AttachmentGCReport{
+		Repair:        repair,
+		OrphanFiles:   []model.OrphanFile{},
+		MissingFiles:  []model.MissingFile{},
+		RefCountFixes: []model.RefCountFix{},
+	}
+	cutoff := now.Add(-s.cfg.AttachmentGCGrace)
+
+	// urutan baca: blob, referensi, lalu storage; upload yang terjadi di antaranya selalu lebih muda dari cutoff
+	blobList, err := s.mongoRepo.ListBlobs()
+	if err != nil {
+		return report, err
+	}
+	blobs := make(map[string]*model.AttachmentBlob, len(blobList))
+	for i := range blobList {
+		blobs[blobList[i].Hash] = &blobList[i]
+	}
+	refs, err := s.mongoRepo.ListAttachmentRefs()
+	if err != nil {
+		return report, err
+	}
+	objects, err := s.storage.List(ctx, "")
+	if err != nil {
+		return report, err
+	}
+	stored := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		stored[obj.Key] = true
+	}
+	report.ObjectsScanned = len(objects)
+	report.References = len(refs)
+
+	referenced := map[string]bool{}
+	actual := map[string]int{}                  // hash -> jumlah attachment (dokumen achievement) yang memakai blob
+	blobOf := map[string]model.AttachmentBlob{} // hash -> metadata blob, untuk blob yang catatannya hilang
+	for _, ref := range refs {
+		att := ref.Attachment
+		key := att.Key()
+		if key == "" {
+			continue
+		}
+		if !stored[key] {
+			missing := model.MissingFile{
+				MongoAchievementID: ref.MongoAchievementID, Revision: ref.Revision,
+				AttachmentID: att.AttachmentID(), FileName: att.FileName, Key: key,
+			}
+			if repair && ref.Revision == 0 {
+				var err error
+				att, missing.Action, err = s.repairMissingFile(ref, stored)
+				if err != nil {
+					return report, err
+				}
+				key = att.Key()
+			}
+			report.MissingFiles = append(report.MissingFiles, missing)
+		}
+		referenced[key] = true
+		referenced[att.ThumbnailKey] = true
+		referenced[att.PreviewKey] = true
+		if ref.Revision == 0 && model.IsBlobKey(key) && stored[key] {
+			actual[att.ContentHash]++
+			blobOf[att.ContentHash] = model.AttachmentBlob{Hash: att.ContentHash, Key: key, Size: att.Size, ContentType: att.FileType}
+		}
+	}
+
+	for hash := range actual {
+		if _, ok := blobs[hash]; !ok {
+			blob := blobOf[hash]
+			blobs[hash] = &blob
+		}
+	}
+	for hash, blob := range blobs {
+		if blob.RefCount == actual[hash] {
+			continue
+		}
+		fix := model.RefCountFix{Hash: hash, Stored: blob.RefCount, Actual: actual[hash]}
+		// blob yang baru diubah bisa sedang dipakai upload yang metadatanya belum tersimpan
+		if repair && blob.UpdatedAt.Before(cutoff) {
+			if err := s.mongoRepo.AdjustBlobRefCount(*blob, fix.Actual-fix.Stored); err != nil {
+				return report, err
+			}
+			blob.RefCount = fix.Actual
+			fix.Fixed = true
+		}
+		report.RefCountFixes = append(report.RefCountFixes, fix)
+	}
+
+	for _, obj := range objects {
+		if referenced[obj.Key] || strings.HasPrefix(obj.Key, model.QuarantineKeyPrefix) || strings.HasPrefix(obj.Key, model.UploadKeyPrefix) {
+			continue // karantina disimpan sebagai jejak audit; chunk resumable upload punya cleanup sendiri
+		}
+		var blob *model.AttachmentBlob
+		if model.IsBlobKey(obj.Key) {
+			blob = blobs[blobHash(obj.Key)]
+			if blob != nil && blob.RefCount > 0 {
+				continue // upload yang sedang berjalan: refCount sudah naik, metadata belum tersimpan
+			}
+		}
+		orphan := model.OrphanFile{Key: obj.Key, Size: obj.Size, ModTime: obj.ModTime}
+		report.OrphanBytes += obj.Size
+		if repair && obj.ModTime.Before(cutoff) && (blob == nil || blob.UpdatedAt.Before(cutoff)) {
+			deleted, err := s.deleteOrphan(ctx, obj.Key, cutoff)
+			if err != nil {
+				return report, err
+			}
+			orphan.Deleted = deleted
+		}
+		report.OrphanFiles = append(report.OrphanFiles, orphan)
+	}
+	return report, nil
+}
+
+// repairMissingFile mengarahkan attachment ke blob dengan hash yang sama jika blob itu ada di storage
+// (mis. upload lama yang file-nya hilang; refCount-nya ikut dikoreksi di langkah rekonsiliasi), atau
+// menandainya scan_failed agar submit terblokir dan pemilik mengganti file-nya
+func (s *AchievementService) repairMissingFile(ref model.AttachmentRef, stored map[string]bool) (model.Attachment, string, error) {
+	old := ref.Attachment
+	updated := old
+	action := model.GCActionMarkedFailed
+	if _, ok := model.AllowedAttachmentTypes[old.FileType]; ok && len(old.ContentHash) == 64 && stored[model.BlobKey(old.ContentHash, old.FileType)] {
+		updated.StorageKey = model.BlobKey(old.ContentHash, old.FileType)
+		action = model.GCActionRelinked
+	} else if old.ScanStatus == model.ScanStatusFailed {
+		return old, action, nil
+	} else {
+		now := time.Now()
+		updated.ScanStatus = model.ScanStatusFailed
+		updated.ScanResult = "file is missing from storage"
+		updated.ScannedAt = &now
+	}
+	err := s.mongoRepo.ReplaceAttachment(ref.MongoAchievementID, old, updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return old, "", nil // attachment sudah dihapus/diganti sejak dibaca
+	}
+	if err != nil {
+		return old, "", err
+	}
+	return updated, action, nil
+}
+
+// deleteOrphan menghapus file yatim. Untuk blob, catatannya dihapus dulu dengan syarat refCount saat itu 0
+// dan tidak disentuh sejak cutoff, sambil memegang kunci hash yang sama dengan storeBlob, sehingga blob yang
+// baru dipakai lagi tidak ikut terhapus meskipun daftar blob yang dibaca GC sudah basi.
+func (s *AchievementService) deleteOrphan(ctx context.Context, key string, cutoff time.Time) (bool, error) {
+	if model.IsBlobKey(key) {
+		hash := blobHash(key)
+		defer s.blobLocks.lock(hash)()
+		ok, err := s.mongoRepo.DeleteUnreferencedBlob(hash, cutoff)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return false, err
+	}
+	return true, nil
+}
+
+// blobHash mengambil sha256 dari key blob attachments/sha256/<xx>/<hash><ext> (juga .thumb.jpg/.preview.jpg)
+func blobHash(key string) string {
+	name := key[strings.LastIndex(key, "/")+1:]
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// blobLocks menyerialkan storeBlob dan penghapusan blob oleh GC untuk hash yang sama di dalam satu proses
+type blobLocks struct {
+	mu    sync.Mutex
+	locks map[string]*blobLock
+}
+
+type blobLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// lock mengunci hash dan mengembalikan fungsi untuk melepasnya
+func (l *blobLocks) lock(hash string) func() {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*blobLock{}
+	}
+	bl := l.locks[hash]
+	if bl == nil {
+		bl = &blobLock{}
+		l.locks[hash] = bl
+	}
+	bl.waiters++
+	l.mu.Unlock()
+
+	bl.Lock()
+	return func() {
+		bl.Unlock()
+		l.mu.Lock()
+		if bl.waiters--; bl.waiters == 0 {
+			delete(l.locks, hash)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// StartAttachmentGC menjalankan CollectAttachmentGarbage setiap interval sampai ctx dibatalkan; temuan dicatat di log
+func (s *AchievementService) StartAttachmentGC(ctx context.Context, interval time.Duration, repair bool) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			report, err := s.CollectAttachmentGarbage(ctx, repair, time.Now())
+			if err != nil {
+				log.Printf("attachment gc failed: %v", err)
+			} else if len(report.OrphanFiles) > 0 || len(report.MissingFiles) > 0 || len(report.RefCountFixes) > 0 {
+				log.Printf("attachment gc (repair=%t): %d orphan files (%d bytes), %d missing files, %d ref count mismatches",
+					repair, len(report.OrphanFiles), report.OrphanBytes, len(report.MissingFiles), len(report.RefCountFixes))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// @Summary Attachment storage garbage collection
+// @Description Admin: membandingkan storage dengan attachment di Mongo. GET hanya melaporkan file yatim, attachment yang file-nya hilang dan refCount blob yang melenceng; POST juga memperbaikinya (file yatim yang lebih tua dari grace period dihapus, attachment yang file-nya hilang diarahkan ke blob dengan hash sama atau ditandai scan_failed, refCount dikoreksi).
+// @Tags Achievements
+// @Produce json
+// @Success 200 {object} model.AttachmentGCReport
+// @Failure 403 {object} model.ErrorResponse "Admin only"
+// @Security ApiKeyAuth
+// @Router /achievements/attachments/gc [get]
+// @Router /achievements/attachments/gc [post]
+func (s *AchievementService) AttachmentGCHandler(c *fiber.Ctx) error {
+	if role, _ := c.Locals("role").(string); role != "Admin" {
+		return c.Status(http.StatusForbidden).JSON(fiber.Map{"error": "Only admins can run attachment garbage collection"})
+	}
+	report, err := s.CollectAttachmentGarbage(context.Background(), c.Method() == fiber.MethodPost, time.Now())
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/repository"
+)
+
+// ==================== LIST WITH FILTERS & SORTING ====================
+
+const (
+	// maxContentSortItems membatasi jumlah reference yang boleh diurutkan in-memory berdasarkan field konten
+	maxContentSortItems = 2000
+	// maxContentFilterItems membatasi jumlah dokumen hasil filter konten yang diteruskan ke Postgres sebagai batasan ID
+	maxContentFilterItems = 2000
+)
+
+// ListAchievements menggabungkan filter Postgres (reference) dan Mongo (konten).
+// Filter konten dijalankan dulu di Mongo (hanya projection field kunci) lalu dipakai sebagai batasan ID di Postgres;
+// jika sort memakai field konten, pengurutan & pagination dilakukan in-memory atas kunci tersebut,
+// sehingga hasil di atas maxContentSortItems ditolak.
+func (s *AchievementService) ListAchievements(userID uuid.UUID, role string, filter model.AchievementListFilter, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+	if filter.IsSimple() {
+		return s.GetUserAchievements(userID, role, filter.Status, page, limit)
+	}
+
+	refFilter, keys, err := s.resolveListFilter(userID, role, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasContentSort(filter.Sort) {
+		return s.postgresRepo.ListAchievementReferences(refFilter, filter.Sort, page, limit)
+	}
+
+	total, err := s.postgresRepo.CountAchievementReferences(refFilter)
+	if err != nil {
+		return nil, err
+	}
+	if total > maxContentSortItems {
+		return nil, fiber.NewError(http.StatusBadRequest, fmt.Sprintf(
+			"sorting by title, type, level, points or event_date is limited to %d achievements; narrow the filter or sort by created_at, submitted_at, verified_at or status", maxContentSortItems))
+	}
+	refs, err := s.postgresRepo.FindAchievementReferences(refFilter)
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		mongoIDs := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			mongoIDs = append(mongoIDs, ref.MongoAchievementID)
+		}
+		list, err := s.mongoRepo.FindAchievementListKeys(model.AchievementContentFilter{MongoIDs: mongoIDs})
+		if err != nil {
+			return nil, err
+		}
+		keys = listKeysByID(list)
+	}
+
+	// reference yang dokumen Mongo-nya sudah hilang tidak ditampilkan
+	kept := refs[:0]
+	for _, ref := range refs {
+		if _, ok := keys[ref.MongoAchievementID]; ok {
+			kept = append(kept, ref)
+		}
+	}
+	// refs sudah urut created_at DESC dari repository, sehingga stable sort menjadikannya tie-breaker
+	sort.SliceStable(kept, func(i, j int) bool {
+		return compareListItems(kept[i], kept[j], keys, filter.Sort) < 0
+	})
+	return paginate(kept, page, limit), nil
+}
+
+// ListAchievementsByCursor: keyset pagination untuk arsip besar; urutan tetap created_at DESC sehingga sort tidak didukung
+func (s *AchievementService) ListAchievementsByCursor(userID uuid.UUID, role string, filter model.AchievementListFilter, page model.CursorPage) (*model.CursorResponse[model.AchievementReference], error) {
+	if len(filter.Sort) > 0 {
+		return nil, fiber.NewError(http.StatusBadRequest, "sort is not supported with cursor pagination")
+	}
+	refFilter, _, err := s.resolveListFilter(userID, role, filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.postgresRepo.ListAchievementReferencesByCursor(refFilter, page)
+}
+
+// resolveListFilter menerapkan visibilitas role dan menjalankan filter konten di Mongo, dibatasi ke mahasiswa yang
+// terlihat dan maxContentFilterItems dokumen. keys nil jika filter konten kosong (tidak ada batasan ID dari Mongo).
+func (s *AchievementService) resolveListFilter(userID uuid.UUID, role string, filter model.AchievementListFilter) (model.ReferenceFilter, map[string]model.AchievementListKey, error) {
+	refFilter := filter.ReferenceFilter
+	visible, err := s.visibleStudentIDs(userID, role)
+	if err != nil {
+		return refFilter, nil, err
+	}
+	refFilter.StudentIDs = visible
+	if err := s.expandTagFilter(&filter.Content); err != nil {
+		return refFilter, nil, err
+	}
+	if filter.Content.IsEmpty() {
+		return refFilter, nil, nil
+	}
+
+	content := filter.Content
+	content.StudentIDs = visible
+	if refFilter.StudentID != nil {
+		content.StudentIDs = []uuid.UUID{*refFilter.StudentID}
+	}
+	content.Limit = maxContentFilterItems + 1
+	list, err := s.mongoRepo.FindAchievementListKeys(content)
+	if err != nil {
+		return refFilter, nil, err
+	}
+	if len(list) > maxContentFilterItems {
+		return refFilter, nil, fiber.NewError(http.StatusBadRequest, fmt.Sprintf(
+			"type, level, tag, points and event date filters are limited to %d matching achievements; narrow the filter by student, status or date", maxContentFilterItems))
+	}
+	refFilter.MongoIDs = make([]string, 0, len(list))
+	for _, k := range list {
+		refFilter.MongoIDs = append(refFilter.MongoIDs, k.MongoID)
+	}
+	return refFilter, listKeysByID(list), nil
+}
+
+func listKeysByID(list []model.AchievementListKey) map[string]model.AchievementListKey {
+	keys := make(map[string]model.AchievementListKey, len(list))
+	for _, k := range list {
+		keys[k.MongoID] = k
+	}
+	return keys
+}
+
+func hasContentSort(fields []model.SortField) bool {
+	for _, f := range fields {
+		if f.IsContentField() {
+			return true
+		}
+	}
+	return false
+}
+
+// compareListItems membandingkan dua item sesuai urutan sort; nilai kosong (NULL) selalu di akhir
+func compareListItems(a, b model.AchievementReference, keys map[string]model.AchievementListKey, fields []model.SortField) int {
+	ka, kb := keys[a.MongoAchievementID], keys[b.MongoAchievementID]
+	for _, f := range fields {
+		var c int
+		switch f.Field {
+		case model.SortPoints:
+			c = compareInts(ka.Points, kb.Points)
+		case model.SortTitle:
+			c = strings.Compare(strings.ToLower(ka.Title), strings.ToLower(kb.Title))
+		case model.SortType:
+			c = strings.Compare(strings.ToLower(ka.AchievementType), strings.ToLower(kb.AchievementType))
+		case model.SortLevel:
+			c = strings.Compare(strings.ToLower(ka.Level), strings.ToLower(kb.Level))
+		case model.SortEventDate:
+			switch {
+			case ka.EventDate == nil && kb.EventDate == nil:
+				continue
+			case ka.EventDate == nil:
+				return 1
+			case kb.EventDate == nil:
+				return -1
+			}
+			c = ka.EventDate.Compare(*kb.EventDate)
+		case model.SortStatus:
+			c = strings.Compare(a.Status, b.Status)
+		case model.SortCreatedAt:
+			c = a.CreatedAt.Compare(b.CreatedAt)
+		case model.SortSubmittedAt, model.SortVerifiedAt:
+			ta, tb := a.SubmittedAt, b.SubmittedAt
+			if f.Field == model.SortVerifiedAt {
+				ta, tb = a.VerifiedAt, b.VerifiedAt
+			}
+			switch {
+			case ta == nil && tb == nil:
+				continue
+			case ta == nil:
+				return 1
+			case tb == nil:
+				return -1
+			}
+			c = ta.Compare(*tb)
+		}
+		if c != 0 {
+			if f.Desc {
+				return -c
+			}
+			return c
+		}
+	}
+	return 0
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// includeAchievements mengisi ref.Achievement untuk satu halaman dengan satu query $in (bukan satu query per baris)
+func includeAchievements(mongoRepo repository.AchievementMongoRepository, refs []model.AchievementReference) error {
+	if len(refs) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	mongoIDs := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if !seen[ref.MongoAchievementID] {
+			seen[ref.MongoAchievementID] = true
+			mongoIDs = append(mongoIDs, ref.MongoAchievementID)
+		}
+	}
+	docs, err := mongoRepo.GetAchievementsByIDs(mongoIDs)
+	if err != nil {
+		return err
+	}
+	for i := range refs {
+		refs[i].Achievement = docs[refs[i].MongoAchievementID]
+	}
+	return nil
+}
+
+// hasInclude checks the comma-separated include query parameter, e.g. ?include=achievement
+func hasInclude(c *fiber.Ctx, name string) bool {
+	for _, v := range strings.Split(c.Query("include"), ",") {
+		if strings.TrimSpace(v) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCursorPage mengembalikan nil jika request memakai pagination page/limit biasa.
+// Mode cursor aktif dengan ?cursor=<token> atau ?pagination=cursor untuk halaman pertama.
+func parseCursorPage(c *fiber.Ctx, limit int) (*model.CursorPage, error) {
+	token := c.Query("cursor")
+	if token == "" && c.Query("pagination") != "cursor" {
+		return nil, nil
+	}
+	page := &model.CursorPage{Limit: limit, WithTotal: c.QueryBool("with_total")}
+	if token != "" {
+		after, err := model.DecodeCursor(token)
+		if err != nil {
+			return nil, fiber.NewError(http.StatusBadRequest, "Invalid cursor")
+		}
+		page.After = after
+	}
+	return page, nil
+}
+
+// parseListFilter membaca query parameter list achievement
+func parseListFilter(c *fiber.Ctx) (model.AchievementListFilter, error) {
+	var filter model.AchievementListFilter
+	var err error
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	filter.ProgramStudy = c.Query("program_study")
+	filter.AcademicYear = c.Query("academic_year")
+	if sid := c.Query("student_id"); sid != "" {
+		studentID, err := uuid.Parse(sid)
+		if err != nil {
+			return filter, fiber.NewError(http.StatusBadRequest, "Invalid student ID")
+		}
+		filter.StudentID = &studentID
+	}
+
+	dates := []struct {
+		param    string
+		endOfDay bool
+		target   **time.Time
+	}{
+		{"created_from", false, &filter.CreatedFrom},
+		{"created_to", true, &filter.CreatedTo},
+		{"submitted_from", false, &filter.SubmittedFrom},
+		{"submitted_to", true, &filter.SubmittedTo},
+		{"verified_from", false, &filter.VerifiedFrom},
+		{"verified_to", true, &filter.VerifiedTo},
+		{"event_from", false, &filter.Content.EventFrom},
+		{"event_to", true, &filter.Content.EventTo},
+	}
+	for _, d := range dates {
+		if v := c.Query(d.param); v != "" {
+			if *d.target, err = parseDateParam(v, d.endOfDay); err != nil {
+				return filter, fiber.NewError(http.StatusBadRequest, "Invalid "+d.param)
+			}
+		}
+	}
+
+	filter.Content.AchievementType = c.Query("type")
+	filter.Content.Level = c.Query("level")
+	for _, tag := range strings.Split(c.Query("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.Content.Tags = append(filter.Content.Tags, tag)
+		}
+	}
+	for _, p := range []struct {
+		param  string
+		target **int
+	}{{"min_points", &filter.Content.MinPoints}, {"max_points", &filter.Content.MaxPoints}} {
+		if v := c.Query(p.param); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return filter, fiber.NewError(http.StatusBadRequest, "Invalid "+p.param)
+			}
+			*p.target = &n
+		}
+	}
+	if lo, hi := filter.Content.MinPoints, filter.Content.MaxPoints; lo != nil && hi != nil && *lo > *hi {
+		return filter, fiber.NewError(http.StatusBadRequest, "min_points must not exceed max_points")
+	}
+
+	if filter.Sort, err = model.ParseSortFields(c.Query("sort")); err != nil {
+		return filter, fiber.NewError(http.StatusBadRequest, err.Error())
+	}
+	return filter, nil
+}
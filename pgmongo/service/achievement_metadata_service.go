@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"BACKEND-UAS/pgmongo/extract"
+	"BACKEND-UAS/pgmongo/model"
+)
+
+// ==================== ATTACHMENT METADATA ====================
+
+// extractMetadata membaca teks dan kode QR dari file saat upload lalu menyimpannya di attachment. Seperti
+// preview, kegagalan ekstraksi tidak menggagalkan upload.
+func (s *AchievementService) extractMetadata(ctx context.Context, att *model.Attachment, data []byte) {
+	if s.extractor == nil {
+		return
+	}
+	res, err := s.extractor.Extract(ctx, data, att.FileType)
+	if err != nil {
+		if !errors.Is(err, extract.ErrUnsupported) {
+			log.Printf("attachment metadata: cannot extract %s: %v", att.FileName, err)
+		}
+		return
+	}
+	att.Metadata = model.NewAttachmentMetadata(res.Text, res.Links, res.QRCodes, time.Now())
+}
+
+// verificationHints mengumpulkan petunjuk verifikasi dari attachment; file yang terinfeksi dilewati
+func verificationHints(ach *model.Achievement) []model.VerificationHint {
+	var hints []model.VerificationHint
+	for _, att := range ach.Attachments {
+		if att.ScanStatus == model.ScanStatusInfected {
+			continue
+		}
+		if hint := att.VerificationHint(); hint != nil {
+			hints = append(hints, *hint)
+		}
+	}
+	return hints
+}
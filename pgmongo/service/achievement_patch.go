@@ -0,0 +1,542 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"BACKEND-UAS/pgmongo/model"
+)
+
+// ==================== PARTIAL UPDATE (MERGE PATCH / JSON PATCH) ====================
+
+const (
+	contentTypeMergePatch = "application/merge-patch+json"
+	contentTypeJSONPatch  = "application/json-patch+json"
+)
+
+// editableAchievementFields adalah field dokumen yang boleh diubah mahasiswa.
+// Field lain (statusHistory, attachments, points, timestamp, studentId, ...) dikelola server.
+var editableAchievementFields = map[string]bool{
+	"achievementType":   true,
+	"title":             true,
+	"description":       true,
+	"details":           true,
+	"tags":              true,
+	"level":             true,
+	"eventDate":         true,
+	"organizer":         true,
+	"location":          true,
+	"certificateNumber": true,
+	"verificationUrl":   true,
+}
+
+type jsonPatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// PatchAchievement menerapkan merge patch (RFC 7386) atau JSON patch (RFC 6902) ke konten achievement
+func (s *AchievementService) PatchAchievement(id, userID uuid.UUID, contentType string, body []byte, version int64) ([]model.FieldChange, error) {
+	ref, current, err := s.loadEditableAchievement(id, userID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// doc boleh dimutasi oleh JSON patch; original dipakai untuk cek field milik server
+	original, err := toGenericDocument(current)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := toGenericDocument(current)
+	if err != nil {
+		return nil, err
+	}
+
+	var patched interface{}
+	switch contentType {
+	case contentTypeMergePatch, fiber.MIMEApplicationJSON:
+		var patch interface{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			return nil, fiber.NewError(http.StatusBadRequest, "invalid merge patch document")
+		}
+		if _, ok := patch.(map[string]interface{}); !ok {
+			return nil, fiber.NewError(http.StatusBadRequest, "merge patch must be a JSON object")
+		}
+		patched = applyMergePatch(doc, patch)
+	case contentTypeJSONPatch:
+		var ops []jsonPatchOp
+		if err := json.Unmarshal(body, &ops); err != nil {
+			return nil, fiber.NewError(http.StatusBadRequest, "invalid JSON patch document")
+		}
+		if patched, err = applyJSONPatch(doc, ops); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fiber.NewError(http.StatusUnsupportedMediaType, "use application/merge-patch+json or application/json-patch+json")
+	}
+
+	patchedDoc, ok := patched.(map[string]interface{})
+	if !ok {
+		return nil, fiber.NewError(http.StatusUnprocessableEntity, "patch must leave the document an object")
+	}
+	if err := checkProtectedFields(original, patchedDoc); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(patchedDoc)
+	if err != nil {
+		return nil, err
+	}
+	var updated model.Achievement
+	if err := json.Unmarshal(raw, &updated); err != nil {
+		return nil, fiber.NewError(http.StatusUnprocessableEntity, "patched document is invalid: "+err.Error())
+	}
+
+	return s.saveAchievementContent(ref, current, updated, userID, version)
+}
+
+// loadEditableAchievement memuat reference + dokumen dan memastikan pemanggil adalah pemiliknya dan statusnya masih bisa diedit
+func (s *AchievementService) loadEditableAchievement(id, userID uuid.UUID, version int64) (*model.AchievementReference, *model.Achievement, error) {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ref == nil || ref.Status == "deleted" {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	student, err := s.postgresRepo.GetStudentByUserID(userID)
+	if err != nil || student == nil || student.ID != ref.StudentID {
+		return nil, nil, fiber.NewError(http.StatusForbidden, "only the owner can edit this achievement")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return nil, nil, err
+	}
+	if !isEditableStatus(ref.Status) {
+		return nil, nil, fiber.NewError(http.StatusBadRequest, "cannot update this achievement")
+	}
+	if ref.Version != version {
+		return nil, nil, errPreconditionFailed
+	}
+	current, err := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil || current == nil {
+		return nil, nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	return ref, current, nil
+}
+
+// saveAchievementContent menyimpan field yang bisa diedit dan mencatat diff-nya di status history.
+// Tidak ada perubahan berarti tidak ada penulisan dan version tidak naik.
+func (s *AchievementService) saveAchievementContent(ref *model.AchievementReference, current *model.Achievement, updated model.Achievement, userID uuid.UUID, version int64) ([]model.FieldChange, error) {
+	var err error
+	if updated.Tags, err = s.normalizeTags(updated.Tags, current.Tags); err != nil {
+		return nil, err
+	}
+	updated.NormalizeEventFields()
+	if err := updated.ValidateEventFields(time.Now()); err != nil {
+		return nil, fiber.NewError(http.StatusBadRequest, err.Error())
+	}
+	before, err := toGenericDocument(current)
+	if err != nil {
+		return nil, err
+	}
+	after, err := toGenericDocument(&updated)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []model.FieldChange{}
+	for _, field := range sortedKeys(editableAchievementFields) {
+		changes = append(changes, diffValues(field, before[field], after[field])...)
+	}
+	if len(changes) == 0 {
+		_ = s.mongoRepo.DeleteAutosave(ref.MongoAchievementID) // isi autosave sudah sama dengan yang tersimpan
+		return changes, nil
+	}
+
+	history := model.StatusHistory{
+		ID:        uuid.New(),
+		Status:    ref.Status,
+		ChangedBy: &userID,
+		ChangedAt: time.Now(),
+		Note:      "Konten diperbarui",
+		Changes:   changes,
+	}
+	// dokumen Mongo ditulis dulu (kondisional pada versinya, yang juga naik di setiap perubahan status);
+	// ETag baru naik setelah konten benar-benar tersimpan
+	if err := s.mongoRepo.UpdateAchievement(ref.MongoAchievementID, contentFields(&updated), history, current.Version); err != nil {
+		return nil, mapVersionConflict(err)
+	}
+	if err := s.postgresRepo.BumpVersion(ref.ID, ref.Status, version); err != nil {
+		// status atau version berubah di antara kedua penulisan (mis. disubmit bersamaan): isi lama dikembalikan
+		// agar edit yang dijawab 412 tidak tertinggal di dokumen yang sudah disubmit/diverifikasi
+		if rerr := s.mongoRepo.RestoreAchievementContent(ref.MongoAchievementID, contentFields(current), history.ID); rerr != nil {
+			log.Printf("achievement %s: cannot restore content after version conflict: %v", ref.ID, rerr)
+		}
+		return nil, mapVersionConflict(err)
+	}
+	_ = s.mongoRepo.DeleteAutosave(ref.MongoAchievementID)
+
+	snapshot := *current
+	snapshot.AchievementType = updated.AchievementType
+	snapshot.Title = updated.Title
+	snapshot.Description = updated.Description
+	snapshot.Details = updated.Details
+	snapshot.Tags = updated.Tags
+	snapshot.Level = updated.Level
+	snapshot.EventAt = updated.EventAt
+	snapshot.Organizer = updated.Organizer
+	snapshot.Location = updated.Location
+	snapshot.CertificateNumber = updated.CertificateNumber
+	snapshot.VerificationURL = updated.VerificationURL
+	snapshot.Version = current.Version + 1
+	snapshot.UpdatedAt = time.Now()
+	s.recordRevision(ref, &snapshot, version+1, model.RevisionUpdated, ref.Status, &userID)
+	return changes, nil
+}
+
+// contentFields adalah field dokumen Mongo yang ditulis oleh edit konten
+func contentFields(a *model.Achievement) bson.M {
+	return bson.M{
+		"achievementType":   a.AchievementType,
+		"title":             a.Title,
+		"description":       a.Description,
+		"details":           a.Details,
+		"tags":              a.Tags,
+		"level":             a.Level,
+		"titleKey":          model.NormalizeTitle(a.Title),
+		"eventDate":         a.EventAt,
+		"organizer":         a.Organizer,
+		"location":          a.Location,
+		"certificateNumber": a.CertificateNumber,
+		"certificateKey":    model.CertificateKey(a),
+		"verificationUrl":   a.VerificationURL,
+	}
+}
+
+func checkProtectedFields(before, after map[string]interface{}) error {
+	keys := map[string]bool{}
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	for _, k := range sortedKeys(keys) {
+		if editableAchievementFields[k] {
+			continue
+		}
+		oldVal, existed := before[k]
+		newVal, exists := after[k]
+		if !existed {
+			return fiber.NewError(http.StatusBadRequest, "unknown field: "+k)
+		}
+		if !exists || !reflect.DeepEqual(oldVal, newVal) {
+			return fiber.NewError(http.StatusUnprocessableEntity, "field is read-only: "+k)
+		}
+	}
+	return nil
+}
+
+// toGenericDocument mengubah struct ke map JSON sehingga patch & diff bekerja dengan nama field API
+func toGenericDocument(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// diffValues membandingkan dua nilai JSON; object dibandingkan per key, nilai lain secara utuh
+func diffValues(path string, oldVal, newVal interface{}) []model.FieldChange {
+	oldMap, oldIsMap := oldVal.(map[string]interface{})
+	newMap, newIsMap := newVal.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := map[string]bool{}
+		for k := range oldMap {
+			keys[k] = true
+		}
+		for k := range newMap {
+			keys[k] = true
+		}
+		var changes []model.FieldChange
+		for _, k := range sortedKeys(keys) {
+			changes = append(changes, diffValues(path+"."+k, oldMap[k], newMap[k])...)
+		}
+		return changes
+	}
+	if reflect.DeepEqual(oldVal, newVal) {
+		return nil
+	}
+	return []model.FieldChange{{Field: path, Old: oldVal, New: newVal}}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyMergePatch mengikuti RFC 7386: null menghapus key, object digabung rekursif, nilai lain mengganti
+func applyMergePatch(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = map[string]interface{}{}
+	}
+	result := make(map[string]interface{}, len(targetMap))
+	for k, v := range targetMap {
+		result[k] = v
+	}
+	for k, v := range patchMap {
+		if v == nil {
+			delete(result, k)
+			continue
+		}
+		result[k] = applyMergePatch(result[k], v)
+	}
+	return result
+}
+
+// applyJSONPatch menjalankan operasi RFC 6902 secara berurutan; satu operasi gagal membatalkan semuanya
+func applyJSONPatch(doc interface{}, ops []jsonPatchOp) (interface{}, error) {
+	for i, op := range ops {
+		path, err := parsePointer(op.Path)
+		if err != nil {
+			return nil, patchOpError(i, err.Error())
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			var value interface{}
+			if len(op.Value) == 0 {
+				return nil, patchOpError(i, "value is required")
+			}
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return nil, patchOpError(i, "invalid value")
+			}
+			if op.Op == "test" {
+				current, err := getPointer(doc, path)
+				if err != nil {
+					return nil, patchOpError(i, err.Error())
+				}
+				if !reflect.DeepEqual(current, value) {
+					return nil, fiber.NewError(http.StatusConflict, "json patch test failed at "+op.Path)
+				}
+				continue
+			}
+			if doc, _, err = modifyPointer(doc, path, op.Op, value); err != nil {
+				return nil, patchOpError(i, err.Error())
+			}
+		case "remove":
+			if doc, _, err = modifyPointer(doc, path, "remove", nil); err != nil {
+				return nil, patchOpError(i, err.Error())
+			}
+		case "move", "copy":
+			from, err := parsePointer(op.From)
+			if err != nil {
+				return nil, patchOpError(i, err.Error())
+			}
+			var value interface{}
+			if op.Op == "move" {
+				doc, value, err = modifyPointer(doc, from, "remove", nil)
+			} else {
+				value, err = getPointer(doc, from)
+				if err == nil {
+					value, err = deepCopyJSON(value)
+				}
+			}
+			if err != nil {
+				return nil, patchOpError(i, err.Error())
+			}
+			if doc, _, err = modifyPointer(doc, path, "add", value); err != nil {
+				return nil, patchOpError(i, err.Error())
+			}
+		default:
+			return nil, patchOpError(i, "unsupported op "+strconv.Quote(op.Op))
+		}
+	}
+	return doc, nil
+}
+
+func patchOpError(index int, msg string) error {
+	return fiber.NewError(http.StatusUnprocessableEntity, "json patch operation "+strconv.Itoa(index)+": "+msg)
+}
+
+// parsePointer memecah JSON pointer (RFC 6901) menjadi token
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, fiber.NewError(http.StatusUnprocessableEntity, "path must not target the document root")
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fiber.NewError(http.StatusUnprocessableEntity, "invalid path "+strconv.Quote(pointer))
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func getPointer(node interface{}, tokens []string) (interface{}, error) {
+	for _, t := range tokens {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			v, ok := n[t]
+			if !ok {
+				return nil, fiber.NewError(http.StatusUnprocessableEntity, "path not found: "+t)
+			}
+			node = v
+		case []interface{}:
+			idx, err := strconv.Atoi(t)
+			if err != nil || idx < 0 || idx >= len(n) {
+				return nil, fiber.NewError(http.StatusUnprocessableEntity, "array index out of range: "+t)
+			}
+			node = n[idx]
+		default:
+			return nil, fiber.NewError(http.StatusUnprocessableEntity, "path not found: "+t)
+		}
+	}
+	return node, nil
+}
+
+// modifyPointer menjalankan add/replace/remove pada token terakhir dan mengembalikan node baru
+// (slice bisa berubah panjang, jadi hasilnya selalu ditulis ulang ke parent) beserta nilai yang dihapus.
+func modifyPointer(node interface{}, tokens []string, op string, value interface{}) (interface{}, interface{}, error) {
+	key := tokens[0]
+	if len(tokens) > 1 {
+		child, err := getPointer(node, tokens[:1])
+		if err != nil {
+			return nil, nil, err
+		}
+		newChild, removed, err := modifyPointer(child, tokens[1:], op, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch n := node.(type) {
+		case map[string]interface{}:
+			n[key] = newChild
+		case []interface{}:
+			idx, _ := strconv.Atoi(key)
+			n[idx] = newChild
+		}
+		return node, removed, nil
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		old, exists := n[key]
+		if op != "add" && !exists {
+			return nil, nil, fiber.NewError(http.StatusUnprocessableEntity, "path not found: "+key)
+		}
+		if op == "remove" {
+			delete(n, key)
+		} else {
+			n[key] = value
+		}
+		return n, old, nil
+	case []interface{}:
+		if op == "add" && key == "-" {
+			return append(n, value), nil, nil
+		}
+		idx, err := strconv.Atoi(key)
+		limit := len(n)
+		if op == "add" {
+			limit++
+		}
+		if err != nil || idx < 0 || idx >= limit {
+			return nil, nil, fiber.NewError(http.StatusUnprocessableEntity, "array index out of range: "+key)
+		}
+		switch op {
+		case "add":
+			n = append(n, nil)
+			copy(n[idx+1:], n[idx:])
+			n[idx] = value
+			return n, nil, nil
+		case "replace":
+			old := n[idx]
+			n[idx] = value
+			return n, old, nil
+		default:
+			old := n[idx]
+			return append(n[:idx], n[idx+1:]...), old, nil
+		}
+	default:
+		return nil, nil, fiber.NewError(http.StatusUnprocessableEntity, "path not found: "+key)
+	}
+}
+
+func deepCopyJSON(v interface{}) (interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	err = json.Unmarshal(raw, &out)
+	return out, err
+}
+
+// @Summary Partially update achievement
+// @Description Mengubah sebagian field prestasi (draft/rejected) dengan JSON Merge Patch (application/merge-patch+json) atau JSON Patch (application/json-patch+json). Field milik server (statusHistory, attachments, points, timestamps, studentId) tidak bisa diubah.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param patch body object true "Merge patch object atau array operasi JSON patch"
+// @Param If-Match header string true "ETag (version) dari GET /achievements/{id}"
+// @Success 200 {object} map[string]interface{} "message + daftar perubahan field"
+// @Failure 400 {object} model.ErrorResponse "Invalid patch / unknown field"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
+// @Failure 409 {object} model.ErrorResponse "JSON patch test operation failed"
+// @Failure 412 {object} model.ErrorResponse "Version mismatch (ETag out of date)"
+// @Failure 415 {object} model.ErrorResponse "Unsupported patch content type"
+// @Failure 422 {object} model.ErrorResponse "Patch cannot be applied or touches a read-only field"
+// @Failure 428 {object} model.ErrorResponse "If-Match header required"
+// @Security ApiKeyAuth
+// @Router /achievements/{id} [patch]
+func (s *AchievementService) PatchHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	contentType := strings.TrimSpace(strings.SplitN(c.Get(fiber.HeaderContentType), ";", 2)[0])
+	changes, err := s.PatchAchievement(id, userID, strings.ToLower(contentType), c.Body(), version)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if len(changes) > 0 {
+		version++
+	}
+	setETag(c, version)
+	return c.JSON(fiber.Map{"message": "Patched successfully", "changes": changes})
+}
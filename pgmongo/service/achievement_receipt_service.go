@@ -0,0 +1,217 @@
+package service
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/receipt"
+)
+
+// ==================== VERIFICATION RECEIPTS ====================
+
+// issueReceipt menandatangani receipt untuk prestasi yang diverifikasi lalu menyimpannya di reference
+func (s *AchievementService) issueReceipt(ref *model.AchievementReference, ach *model.Achievement, verifiedBy uuid.UUID, verifiedAt time.Time, version int64) (string, error) {
+	contentHash, err := ach.ContentHash()
+	if err != nil {
+		return "", err
+	}
+	token, err := s.receipts.Sign(receipt.Claims{
+		AchievementID:      ref.ID.String(),
+		StudentID:          ref.StudentID.String(),
+		MongoAchievementID: ref.MongoAchievementID,
+		ContentHash:        contentHash,
+		Attachments:        ach.ReceiptAttachments(),
+		VerifiedBy:         verifiedBy.String(),
+		VerifiedAt:         verifiedAt.UTC().Truncate(time.Second),
+		Version:            version,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := s.postgresRepo.SaveVerificationReceipt(ref.ID, token); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ReissueReceipt menerbitkan ulang receipt prestasi verified yang receipt-nya hilang (gagal diterbitkan saat
+// verifikasi) atau tidak lagi sah dengan kunci server ini (mis. kunci lama tidak didaftarkan sebagai retired).
+// Receipt yang masih sah tidak diganti agar receipt yang sudah dibagikan tidak menjadi superseded.
+func (s *AchievementService) ReissueReceipt(id uuid.UUID, role string) (string, error) {
+	if role != "Admin" {
+		return "", fiber.NewError(http.StatusForbidden, "only admins can reissue verification receipts")
+	}
+	if s.receipts == nil {
+		return "", fiber.NewError(http.StatusServiceUnavailable, "verification receipts are disabled")
+	}
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref == nil || ref.Status == "deleted" {
+		return "", fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return "", err
+	}
+	if ref.Status != "verified" || ref.VerifiedBy == nil || ref.VerifiedAt == nil {
+		return "", fiber.NewError(http.StatusBadRequest, "only verified achievements have a receipt")
+	}
+	stored, err := s.postgresRepo.GetVerificationReceipt(id)
+	if err != nil {
+		return "", err
+	}
+	if stored != "" {
+		if _, err := s.receipts.Verify(stored); err == nil {
+			return "", fiber.NewError(http.StatusConflict, "achievement already has a valid receipt")
+		}
+	}
+	ach, err := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil || ach == nil || ach.DeletedAt != nil {
+		return "", fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	token, err := s.issueReceipt(ref, ach, *ref.VerifiedBy, *ref.VerifiedAt, ref.Version)
+	return token, mapVersionConflict(err)
+}
+
+// VerifyReceipt memeriksa receipt: tanda tangan (genuine), lalu apakah prestasi masih verified dengan receipt
+// yang sama dan isi serta attachment-nya belum berubah sejak ditandatangani (unchanged)
+func (s *AchievementService) VerifyReceipt(token string) (*model.ReceiptVerification, error) {
+	if s.receipts == nil {
+		return nil, fiber.NewError(http.StatusServiceUnavailable, "verification receipts are disabled")
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, fiber.NewError(http.StatusBadRequest, "receipt is required")
+	}
+	claims, err := s.receipts.Verify(token)
+	if err != nil {
+		return &model.ReceiptVerification{Problems: []string{model.ReceiptInvalidSignature}}, nil
+	}
+	verifiedAt := claims.VerifiedAt
+	res := &model.ReceiptVerification{
+		Genuine:       true,
+		KeyID:         claims.KeyID,
+		AchievementID: claims.AchievementID,
+		VerifiedAt:    &verifiedAt,
+		VerifiedBy:    claims.VerifiedBy,
+		ContentHash:   claims.ContentHash,
+		Attachments:   claims.Attachments,
+	}
+
+	id, err := uuid.Parse(claims.AchievementID)
+	if err != nil {
+		res.Problems = append(res.Problems, model.ReceiptNotFound)
+		return res, nil
+	}
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref == nil || ref.Status == "deleted" {
+		res.Problems = append(res.Problems, model.ReceiptNotFound)
+		return res, nil
+	}
+	res.Status = ref.Status
+	if ref.Status != "verified" {
+		res.Problems = append(res.Problems, model.ReceiptStatusChanged)
+	}
+	stored, err := s.postgresRepo.GetVerificationReceipt(id)
+	if err != nil {
+		return nil, err
+	}
+	if stored != token {
+		res.Problems = append(res.Problems, model.ReceiptSuperseded)
+	}
+
+	ach, err := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	if err != nil || ach == nil || ach.DeletedAt != nil {
+		res.Problems = append(res.Problems, model.ReceiptNotFound)
+		return res, nil
+	}
+	res.Title = ach.Title
+	contentHash, err := ach.ContentHash()
+	if err != nil {
+		return nil, err
+	}
+	if contentHash != claims.ContentHash {
+		res.Problems = append(res.Problems, model.ReceiptContentChanged)
+	}
+	if !slices.Equal(ach.ReceiptAttachments(), claims.Attachments) {
+		res.Problems = append(res.Problems, model.ReceiptAttachmentsChanged)
+	}
+	res.Unchanged = len(res.Problems) == 0
+	return res, nil
+}
+
+// ==================== HANDLERS WITH SWAGGER ====================
+
+// @Summary Verify a verification receipt
+// @Description Publik (tanpa login): memeriksa receipt verifikasi prestasi. genuine=true jika tanda tangan sah dari server ini; unchanged=true jika prestasi masih verified dengan receipt yang sama dan isi serta attachment-nya tidak berubah sejak diverifikasi. Receipt dikirim lewat query ?receipt= (GET) atau body {"receipt": "..."} (POST).
+// @Tags Verification Receipts
+// @Accept json
+// @Produce json
+// @Param receipt query string false "Receipt (JWS compact)"
+// @Success 200 {object} model.ReceiptVerification
+// @Failure 400 {object} model.ErrorResponse "Receipt missing"
+// @Failure 503 {object} model.ErrorResponse "Receipts disabled"
+// @Router /verification-receipts/verify [get]
+// @Router /verification-receipts/verify [post]
+func (s *AchievementService) VerifyReceiptHandler(c *fiber.Ctx) error {
+	token := c.Query("receipt")
+	if c.Method() == fiber.MethodPost {
+		var body struct {
+			Receipt string `json:"receipt"`
+		}
+		if err := c.BodyParser(&body); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+		}
+		token = body.Receipt
+	}
+	res, err := s.VerifyReceipt(token)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.JSON(res)
+}
+
+// @Summary Verification receipt public keys
+// @Description Publik: kunci publik Ed25519 (JWK Set) untuk memeriksa tanda tangan receipt secara offline
+// @Tags Verification Receipts
+// @Produce json
+// @Success 200 {object} map[string][]receipt.JWK "keys"
+// @Failure 503 {object} model.ErrorResponse "Receipts disabled"
+// @Router /verification-receipts/keys [get]
+func (s *AchievementService) ReceiptKeysHandler(c *fiber.Ctx) error {
+	if s.receipts == nil {
+		return c.Status(http.StatusServiceUnavailable).JSON(fiber.Map{"error": "verification receipts are disabled"})
+	}
+	return c.JSON(fiber.Map{"keys": s.receipts.JWKs()})
+}
+
+// @Summary Reissue a verification receipt
+// @Description Admin: menerbitkan ulang receipt prestasi verified yang receipt-nya hilang (gagal diterbitkan saat verifikasi) atau tidak lagi sah dengan kunci aktif maupun kunci retired. Receipt yang masih sah tidak diganti.
+// @Tags Verification Receipts
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Success 200 {object} map[string]string "receipt"
+// @Failure 400 {object} model.ErrorResponse "Not verified"
+// @Failure 403 {object} model.ErrorResponse "Admin only"
+// @Failure 404 {object} model.ErrorResponse "Achievement not found"
+// @Failure 409 {object} model.ErrorResponse "Receipt still valid"
+// @Failure 503 {object} model.ErrorResponse "Receipts disabled"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/receipt [post]
+func (s *AchievementService) ReissueReceiptHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	role, _ := c.Locals("role").(string)
+	token, err := s.ReissueReceipt(id, role)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"receipt": token})
+}
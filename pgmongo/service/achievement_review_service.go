@@ -0,0 +1,586 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/repository"
+)
+
+// ==================== REVIEW QUEUE (DOSEN WALI / ADMIN) ====================
+
+// EvaluateSLA menghitung batas waktu verifikasi dan indikator SLA sebuah submission.
+// Status due_soon dipakai saat sisa waktu kurang dari seperempat SLA.
+func EvaluateSLA(submittedAt, now time.Time, sla time.Duration) (time.Time, string) {
+	dueAt := submittedAt.Add(sla)
+	remaining := dueAt.Sub(now)
+	switch {
+	case remaining < 0:
+		return dueAt, model.SLAOverdue
+	case remaining <= sla/4:
+		return dueAt, model.SLADueSoon
+	default:
+		return dueAt, model.SLAOnTrack
+	}
+}
+
+// reviewableStudentIDs returns the students a reviewer may act on; nil means all (admin)
+func (s *AchievementService) reviewableStudentIDs(userID uuid.UUID, role string) ([]uuid.UUID, error) {
+	switch role {
+	case "Admin":
+		return nil, nil
+	case "Dosen Wali":
+		lecturer, err := s.postgresRepo.GetLecturerByUserID(userID)
+		if err != nil || lecturer == nil {
+			return nil, fiber.NewError(http.StatusForbidden, "no lecturer profile found")
+		}
+		ids, err := s.postgresRepo.GetStudentIDsByAdvisor(lecturer.ID)
+		if err != nil {
+			return nil, err
+		}
+		if ids == nil {
+			ids = []uuid.UUID{}
+		}
+		return ids, nil
+	default:
+		return nil, fiber.NewError(http.StatusForbidden, "only lecturers and admins can review achievements")
+	}
+}
+
+// ensureCanReview: admin, dosen wali mahasiswa pemilik prestasi, atau dosen yang sedang memegang claim aktif
+// (mis. ditugaskan lewat AssignReviewer)
+func (s *AchievementService) ensureCanReview(ref *model.AchievementReference, userID uuid.UUID, role string) error {
+	if role == "Dosen Wali" && s.holdsClaim(ref, userID) {
+		return nil
+	}
+	studentIDs, err := s.reviewableStudentIDs(userID, role)
+	if err != nil {
+		return err
+	}
+	if studentIDs == nil {
+		return nil
+	}
+	for _, sid := range studentIDs {
+		if sid == ref.StudentID {
+			return nil
+		}
+	}
+	return fiber.NewError(http.StatusForbidden, "achievement does not belong to your advisees")
+}
+
+func (s *AchievementService) ensureCanReviewID(id, userID uuid.UUID, role string) error {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil {
+		return fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	return s.ensureCanReview(ref, userID, role)
+}
+
+// holdsClaim: userID memegang claim achievement yang belum basi
+func (s *AchievementService) holdsClaim(ref *model.AchievementReference, userID uuid.UUID) bool {
+	return ref.ClaimedBy != nil && *ref.ClaimedBy == userID && ref.ClaimedAt != nil && time.Since(*ref.ClaimedAt) <= s.cfg.ReviewClaimTTL
+}
+
+// checkClaim menolak aksi reviewer jika achievement sedang di-claim reviewer lain yang masih aktif
+func (s *AchievementService) checkClaim(ref *model.AchievementReference, reviewerID uuid.UUID) error {
+	if ref.ClaimedBy == nil || *ref.ClaimedBy == reviewerID {
+		return nil
+	}
+	if ref.ClaimedAt != nil && time.Since(*ref.ClaimedAt) > s.cfg.ReviewClaimTTL {
+		return nil
+	}
+	return fiber.NewError(http.StatusConflict, "achievement is claimed by another reviewer")
+}
+
+func (s *AchievementService) GetVerificationQueue(userID uuid.UUID, role string, filter model.VerificationQueueFilter, page, limit int) (*model.PaginatedResponse[model.VerificationQueueItem], error) {
+	studentIDs, err := s.reviewableStudentIDs(userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if filter.StudentID != nil {
+		allowed := studentIDs == nil
+		for _, sid := range studentIDs {
+			if sid == *filter.StudentID {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fiber.NewError(http.StatusForbidden, "student is not your advisee")
+		}
+		studentIDs = []uuid.UUID{*filter.StudentID}
+	}
+
+	pgFilter := model.SubmittedReferenceFilter{StudentIDs: studentIDs, ClaimedSince: time.Now().Add(-s.cfg.ReviewClaimTTL)}
+	// dosen juga melihat item yang sedang ia pegang claim-nya (mis. ditugaskan lewat AssignReviewer), kecuali saat filter per mahasiswa
+	if role == "Dosen Wali" && filter.StudentID == nil {
+		pgFilter.ClaimedBy = &userID
+	}
+	if filter.AchievementType != "" || filter.Level != "" {
+		mongoIDs, err := s.queueContentMatches(filter, pgFilter)
+		if err != nil {
+			return nil, err
+		}
+		pgFilter.MongoIDs = mongoIDs
+	}
+
+	refs, err := s.postgresRepo.ListSubmittedAchievementReferences(pgFilter, page, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.PaginatedResponse[model.VerificationQueueItem]{
+		Data: []model.VerificationQueueItem{}, Page: page, Limit: limit, Total: refs.Total, TotalPages: refs.TotalPages,
+	}
+	if len(refs.Data) == 0 {
+		return resp, nil
+	}
+
+	// dokumen Mongo hanya diambil untuk halaman ini
+	mongoIDs := make([]string, len(refs.Data))
+	for i, ref := range refs.Data {
+		mongoIDs[i] = ref.MongoAchievementID
+	}
+	docs, err := s.mongoRepo.GetAchievementsByIDs(mongoIDs)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, ref := range refs.Data {
+		ach := docs[ref.MongoAchievementID]
+		if ach == nil {
+			continue
+		}
+		item := s.buildQueueItem(ref, ach, now)
+		// Gagal cek duplikat tidak menggagalkan queue
+		if dups, err := s.findDuplicates(ach); err == nil && len(dups) > 0 {
+			item.Duplicates = dups
+		}
+		resp.Data = append(resp.Data, item)
+	}
+	return resp, nil
+}
+
+// queueContentMatches menjalankan filter type/level di Mongo, dibatasi ke mahasiswa yang bisa direview dan
+// maxContentFilterItems dokumen, lalu mengembalikan Mongo ID yang cocok untuk dipakai query queue di Postgres.
+func (s *AchievementService) queueContentMatches(filter model.VerificationQueueFilter, pgFilter model.SubmittedReferenceFilter) ([]string, error) {
+	content := model.AchievementContentFilter{
+		AchievementType: filter.AchievementType,
+		Level:           filter.Level,
+		StudentIDs:      pgFilter.StudentIDs,
+		Limit:           maxContentFilterItems + 1,
+	}
+	if pgFilter.StudentIDs != nil && pgFilter.ClaimedBy != nil {
+		claimed, err := s.postgresRepo.GetClaimedStudentIDs(*pgFilter.ClaimedBy, pgFilter.ClaimedSince)
+		if err != nil {
+			return nil, err
+		}
+		content.StudentIDs = append(append([]uuid.UUID{}, pgFilter.StudentIDs...), claimed...)
+	}
+	if content.StudentIDs != nil && len(content.StudentIDs) == 0 {
+		return []string{}, nil
+	}
+	list, err := s.mongoRepo.FindAchievementListKeys(content)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) > maxContentFilterItems {
+		return nil, fiber.NewError(http.StatusBadRequest, fmt.Sprintf(
+			"type and level filters are limited to %d matching achievements; narrow the filter by student", maxContentFilterItems))
+	}
+	mongoIDs := make([]string, 0, len(list))
+	for _, k := range list {
+		mongoIDs = append(mongoIDs, k.MongoID)
+	}
+	return mongoIDs, nil
+}
+
+func (s *AchievementService) buildQueueItem(ref model.AchievementReference, ach *model.Achievement, now time.Time) model.VerificationQueueItem {
+	item := model.VerificationQueueItem{
+		ID:              ref.ID,
+		Student:         ref.Student,
+		Title:           ach.Title,
+		AchievementType: ach.AchievementType,
+		Level:           ach.Level,
+		Points:          ach.Points,
+		SubmittedAt:     ref.SubmittedAt,
+		SLAStatus:       model.SLAOnTrack,
+		Hints:           verificationHints(ach),
+		Version:         ref.Version,
+	}
+	if ref.SubmittedAt != nil {
+		dueAt, status := EvaluateSLA(*ref.SubmittedAt, now, s.cfg.ReviewSLA)
+		item.DueAt = &dueAt
+		item.SLAStatus = status
+		item.WaitingHours = int64(now.Sub(*ref.SubmittedAt).Hours())
+	}
+	// Claim yang sudah basi tidak ditampilkan agar reviewer lain tahu item bisa diambil
+	if ref.ClaimedBy != nil && ref.ClaimedAt != nil && now.Sub(*ref.ClaimedAt) <= s.cfg.ReviewClaimTTL {
+		item.ClaimedBy = ref.ClaimedBy
+		item.ClaimedAt = ref.ClaimedAt
+	}
+	return item
+}
+
+func (s *AchievementService) ClaimAchievement(id, userID uuid.UUID, role string) error {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil {
+		return fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return err
+	}
+	if err := s.ensureCanReview(ref, userID, role); err != nil {
+		return err
+	}
+	if ref.Status != "submitted" {
+		return fiber.NewError(http.StatusBadRequest, "only submitted can be claimed")
+	}
+	err = s.postgresRepo.ClaimAchievement(id, userID, time.Now().Add(-s.cfg.ReviewClaimTTL))
+	if errors.Is(err, repository.ErrClaimConflict) {
+		return fiber.NewError(http.StatusConflict, "achievement is claimed by another reviewer")
+	}
+	return err
+}
+
+// AssignReviewer menyerahkan achievement ke dosen lain (reviewerID adalah user ID dosen tujuan)
+func (s *AchievementService) AssignReviewer(id, reviewerID, userID uuid.UUID, role string) error {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil {
+		return fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return err
+	}
+	if err := s.ensureCanReview(ref, userID, role); err != nil {
+		return err
+	}
+	if err := s.checkClaim(ref, userID); err != nil && role != "Admin" {
+		return err
+	}
+	reviewer, err := s.postgresRepo.GetLecturerByUserID(reviewerID)
+	if err != nil || reviewer == nil {
+		return fiber.NewError(http.StatusBadRequest, "reviewer must be a lecturer")
+	}
+	err = s.postgresRepo.AssignReviewer(id, reviewerID)
+	if errors.Is(err, repository.ErrClaimConflict) {
+		return fiber.NewError(http.StatusBadRequest, "only submitted can be assigned")
+	}
+	return err
+}
+
+func (s *AchievementService) ReleaseClaim(id, userID uuid.UUID, role string) error {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil {
+		return fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	if ref.ClaimedBy == nil {
+		return nil
+	}
+	if *ref.ClaimedBy != userID && role != "Admin" {
+		return fiber.NewError(http.StatusForbidden, "only the claiming reviewer can release this claim")
+	}
+	return s.postgresRepo.ReleaseClaim(id)
+}
+
+// BatchReview menjalankan verify/reject untuk beberapa achievement; kegagalan satu item tidak menghentikan yang lain.
+// Setiap item membawa version dari queue, jadi item yang berubah sejak dilihat reviewer gagal dengan 412.
+func (s *AchievementService) BatchReview(items []model.BatchReviewItem, userID uuid.UUID, role string, rejectionNote *string) *model.BatchReviewResponse {
+	resp := &model.BatchReviewResponse{Results: make([]model.BatchReviewResult, 0, len(items))}
+	for _, item := range items {
+		result := model.BatchReviewResult{ID: item.ID.String()}
+		ref, err := s.postgresRepo.GetAchievementReferenceByID(item.ID)
+		if err != nil {
+			err = fiber.NewError(http.StatusNotFound, "achievement not found")
+		} else if err = s.ensureCanReview(ref, userID, role); err == nil {
+			if rejectionNote != nil {
+				err = s.RejectAchievement(item.ID, userID, *rejectionNote, item.Version)
+			} else {
+				err = s.VerifyAchievement(item.ID, userID, item.Version)
+			}
+		}
+		if err != nil {
+			result.Status = "error"
+			result.Code = http.StatusInternalServerError
+			if fe, ok := err.(*fiber.Error); ok {
+				result.Code = fe.Code
+			}
+			result.Error = err.Error()
+			resp.Failed++
+		} else if rejectionNote != nil {
+			result.Status = "rejected"
+			resp.Succeeded++
+		} else {
+			result.Status = "verified"
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp
+}
+
+// paginate memotong slice hasil filter in-memory ke halaman yang diminta
+func paginate[T any](items []T, page, limit int) *model.PaginatedResponse[T] {
+	total := len(items)
+	start := (page - 1) * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+	return &model.PaginatedResponse[T]{
+		Data:       items[start:end],
+		Page:       page,
+		Limit:      limit,
+		Total:      int64(total),
+		TotalPages: (total + limit - 1) / limit,
+	}
+}
+
+// handleServiceError maps *fiber.Error ke status code-nya, error lain jadi 500
+func handleServiceError(c *fiber.Ctx, err error) error {
+	var ae *model.AttachmentError
+	if errors.As(err, &ae) {
+		return c.Status(ae.Status).JSON(ae)
+	}
+	if fe, ok := err.(*fiber.Error); ok {
+		return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
+	}
+	return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+}
+
+// ==================== HANDLERS WITH SWAGGER ====================
+
+// @Summary Verification queue
+// @Description Daftar prestasi submitted milik mahasiswa bimbingan (admin: semua) plus item yang sedang di-claim dosen tsb, urut dari submission terlama, dengan indikator SLA.
+// @Description Filter type/level dibatasi 2000 prestasi yang cocok.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param type query string false "Filter achievement type"
+// @Param level query string false "Filter level"
+// @Param student_id query string false "Filter student ID (UUID)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 10)"
+// @Success 200 {object} model.PaginatedResponse[model.VerificationQueueItem]
+// @Failure 400 {object} model.ErrorResponse "Type/level filter matches too many achievements"
+// @Failure 403 {object} model.ErrorResponse "Not a reviewer"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /achievements/queue [get]
+func (s *AchievementService) QueueHandler(c *fiber.Ctx) error {
+	userIDStr := c.Locals("user_id").(string)
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := model.VerificationQueueFilter{
+		AchievementType: c.Query("type"),
+		Level:           c.Query("level"),
+	}
+	if sid := c.Query("student_id"); sid != "" {
+		studentID, err := uuid.Parse(sid)
+		if err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid student ID"})
+		}
+		filter.StudentID = &studentID
+	}
+
+	resp, err := s.GetVerificationQueue(userID, role, filter, page, limit)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(resp)
+}
+
+// @Summary Claim achievement for review
+// @Description Menandai prestasi sedang diperiksa reviewer agar tidak diproses dua dosen sekaligus
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Success 200 {object} map[string]string "status: claimed"
+// @Failure 403 {object} model.ErrorResponse "Not a reviewer of this achievement"
+// @Failure 409 {object} model.ErrorResponse "Claimed by another reviewer"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/claim [post]
+func (s *AchievementService) ClaimHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	if err := s.ClaimAchievement(id, userID, role); err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"status": "claimed"})
+}
+
+// @Summary Release review claim
+// @Description Melepas claim reviewer (hanya pemilik claim atau admin)
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Success 200 {object} map[string]string "status: released"
+// @Failure 403 {object} model.ErrorResponse "Not the claiming reviewer"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/claim [delete]
+func (s *AchievementService) ReleaseClaimHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	if err := s.ReleaseClaim(id, userID, role); err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"status": "released"})
+}
+
+// @Summary Assign reviewer
+// @Description Menyerahkan prestasi submitted ke dosen lain (reviewer_id adalah user ID dosen)
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param body body object true "Reviewer" schema={"type":"object","properties":{"reviewer_id":{"type":"string"}}}
+// @Success 200 {object} map[string]string "status: assigned"
+// @Failure 400 {object} model.ErrorResponse "Invalid reviewer"
+// @Failure 403 {object} model.ErrorResponse "Not a reviewer of this achievement"
+// @Failure 409 {object} model.ErrorResponse "Claimed by another reviewer"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/assign [post]
+func (s *AchievementService) AssignHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	type Req struct {
+		ReviewerID string `json:"reviewer_id"`
+	}
+	var req Req
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	reviewerID, err := uuid.Parse(req.ReviewerID)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid reviewer ID"})
+	}
+
+	if err := s.AssignReviewer(id, reviewerID, userID, role); err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"status": "assigned"})
+}
+
+type batchReviewRequest struct {
+	Items         []model.BatchReviewItem `json:"items"`
+	RejectionNote string                  `json:"rejection_note"`
+}
+
+func (s *AchievementService) parseBatchRequest(c *fiber.Ctx) (*batchReviewRequest, error) {
+	var req batchReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return nil, fiber.NewError(http.StatusBadRequest, "Invalid request body")
+	}
+	if len(req.Items) == 0 {
+		return nil, fiber.NewError(http.StatusBadRequest, "items is required")
+	}
+	if len(req.Items) > 100 {
+		return nil, fiber.NewError(http.StatusBadRequest, "at most 100 items per batch")
+	}
+	for _, item := range req.Items {
+		if item.ID == uuid.Nil {
+			return nil, fiber.NewError(http.StatusBadRequest, "each item needs an achievement id")
+		}
+		if item.Version < 1 {
+			return nil, fiber.NewError(http.StatusBadRequest, "each item needs the version from the queue: "+item.ID.String())
+		}
+	}
+	return &req, nil
+}
+
+// @Summary Batch verify achievements
+// @Description Verifikasi beberapa prestasi sekaligus, hasil per item. Setiap item membawa version dari queue; item yang berubah sejak itu gagal dengan code 412.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param body body object true "Items (id + version)" schema={"type":"object","properties":{"items":{"type":"array","items":{"type":"object","properties":{"id":{"type":"string"},"version":{"type":"integer"}}}}}}
+// @Success 200 {object} model.BatchReviewResponse
+// @Failure 400 {object} model.ErrorResponse "Invalid request"
+// @Security ApiKeyAuth
+// @Router /achievements/batch/verify [post]
+func (s *AchievementService) BatchVerifyHandler(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	req, err := s.parseBatchRequest(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(s.BatchReview(req.Items, userID, role, nil))
+}
+
+// @Summary Batch reject achievements
+// @Description Tolak beberapa prestasi sekaligus dengan satu rejection note, hasil per item. Setiap item membawa version dari queue; item yang berubah sejak itu gagal dengan code 412.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param body body object true "Items (id + version) and note" schema={"type":"object","properties":{"items":{"type":"array","items":{"type":"object","properties":{"id":{"type":"string"},"version":{"type":"integer"}}}},"rejection_note":{"type":"string"}}}
+// @Success 200 {object} model.BatchReviewResponse
+// @Failure 400 {object} model.ErrorResponse "Invalid request or note missing"
+// @Security ApiKeyAuth
+// @Router /achievements/batch/reject [post]
+func (s *AchievementService) BatchRejectHandler(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	req, err := s.parseBatchRequest(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if req.RejectionNote == "" {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Rejection note is required"})
+	}
+	return c.JSON(s.BatchReview(req.Items, userID, role, &req.RejectionNote))
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+)
+
+// ==================== REVISIONS (AUDIT TRAIL KONTEN) ====================
+
+// revisionDiffFields adalah field konten yang dibandingkan antar revisi
+var revisionDiffFields = []string{
+	"achievementType", "attachments", "certificateNumber", "description", "details", "eventDate",
+	"level", "location", "organizer", "points", "tags", "title", "verificationUrl",
+}
+
+// recordRevision menyimpan snapshot dokumen setelah perubahan; revision mengikuti version reference
+func (s *AchievementService) recordRevision(ref *model.AchievementReference, snapshot *model.Achievement, revision int64, event, status string, userID *uuid.UUID) {
+	if snapshot == nil {
+		return
+	}
+	copied := *snapshot
+	copied.StatusHistory = nil // history sudah ada di dokumen utama, tidak perlu disalin ke tiap revisi
+	rev := &model.AchievementRevision{
+		AchievementID:      ref.ID,
+		MongoAchievementID: ref.MongoAchievementID,
+		Revision:           revision,
+		Event:              event,
+		Status:             status,
+		CreatedBy:          userID,
+		Snapshot:           &copied,
+	}
+	// perubahan utama sudah tersimpan dan tidak bisa dibatalkan; revisi yang hilang dicatat agar celah di diff bisa dilacak
+	if err := s.mongoRepo.AddRevision(rev); err != nil {
+		log.Printf("revision: cannot record revision %d (%s) for %s: %v", revision, event, ref.ID, err)
+	}
+}
+
+// loadRevisionReference memuat reference dan memastikan pemanggil boleh melihat prestasinya (pemilik, dosen wali, admin)
+func (s *AchievementService) loadRevisionReference(id, userID uuid.UUID, role string) (*model.AchievementReference, error) {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref == nil || ref.Status == "deleted" {
+		return nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	if err := s.ensureCanView(ref, userID, role); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+func (s *AchievementService) GetAchievementRevisions(id, userID uuid.UUID, role string) ([]model.AchievementRevision, error) {
+	ref, err := s.loadRevisionReference(id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	return s.mongoRepo.ListRevisions(ref.MongoAchievementID)
+}
+
+// DiffAchievementRevisions membandingkan revisi a dan b; b <= 0 berarti revisi terbaru
+func (s *AchievementService) DiffAchievementRevisions(id, userID uuid.UUID, role string, a, b int64) (*model.RevisionDiff, error) {
+	ref, err := s.loadRevisionReference(id, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	if b <= 0 {
+		revisions, err := s.mongoRepo.ListRevisions(ref.MongoAchievementID)
+		if err != nil {
+			return nil, err
+		}
+		if len(revisions) == 0 {
+			return nil, fiber.NewError(http.StatusNotFound, "achievement has no revisions")
+		}
+		b = revisions[len(revisions)-1].Revision
+	}
+
+	from, err := s.loadRevision(ref.MongoAchievementID, a)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.loadRevision(ref.MongoAchievementID, b)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := toGenericDocument(from.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	after, err := toGenericDocument(to.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	changes := []model.FieldChange{}
+	for _, field := range revisionDiffFields {
+		changes = append(changes, diffValues(field, before[field], after[field])...)
+	}
+
+	from.Snapshot, to.Snapshot = nil, nil
+	return &model.RevisionDiff{AchievementID: ref.ID, From: *from, To: *to, Changes: changes}, nil
+}
+
+func (s *AchievementService) loadRevision(mongoID string, revision int64) (*model.AchievementRevision, error) {
+	rev, err := s.mongoRepo.GetRevision(mongoID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if rev == nil || rev.Snapshot == nil {
+		return nil, fiber.NewError(http.StatusNotFound, "revision "+strconv.FormatInt(revision, 10)+" not found")
+	}
+	return rev, nil
+}
+
+// @Summary List achievement revisions
+// @Description Daftar snapshot konten prestasi (dibuat saat create, update, dan submit), urut dari yang paling lama
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Success 200 {array} model.AchievementRevision
+// @Failure 403 {object} model.ErrorResponse "Not allowed to view this achievement"
+// @Failure 404 {object} model.ErrorResponse "Achievement not found"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/revisions [get]
+func (s *AchievementService) RevisionsHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	role, _ := c.Locals("role").(string)
+	revisions, err := s.GetAchievementRevisions(id, userID, role)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(revisions)
+}
+
+// @Summary Diff two achievement revisions
+// @Description Perubahan konten antara revisi a dan b (b boleh "latest"), mis. untuk melihat apa yang diubah mahasiswa sejak ditolak
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param a path int true "Revisi awal"
+// @Param b path string true "Revisi akhir atau latest"
+// @Success 200 {object} model.RevisionDiff
+// @Failure 400 {object} model.ErrorResponse "Invalid revision number"
+// @Failure 403 {object} model.ErrorResponse "Not allowed to view this achievement"
+// @Failure 404 {object} model.ErrorResponse "Revision not found"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/revisions/{a}/diff/{b} [get]
+func (s *AchievementService) RevisionDiffHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	role, _ := c.Locals("role").(string)
+	a, err := strconv.ParseInt(c.Params("a"), 10, 64)
+	if err != nil || a < 1 {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid revision number"})
+	}
+	var b int64
+	if c.Params("b") != "latest" {
+		b, err = strconv.ParseInt(c.Params("b"), 10, 64)
+		if err != nil || b < 1 {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid revision number"})
+		}
+	}
+
+	diff, err := s.DiffAchievementRevisions(id, userID, role, a, b)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(diff)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/storage"
+)
+
+// ==================== ANTIVIRUS SCAN ====================
+
+// scanBatchSize membatasi jumlah attachment per putaran scan
+const scanBatchSize = 50
+
+// initialScanStatus: upload baru menunggu scan; jika scanner dimatikan langsung dianggap bersih
+func (s *AchievementService) initialScanStatus(att *model.Attachment) {
+	if s.scanner != nil {
+		att.ScanStatus = model.ScanStatusPending
+		return
+	}
+	now := time.Now()
+	att.ScanStatus = model.ScanStatusClean
+	att.ScanResult = "not scanned: scanner disabled"
+	att.ScannedAt = &now
+}
+
+// wakeScanner membangunkan worker scan tanpa menunggu interval berikutnya (non-blocking)
+func (s *AchievementService) wakeScanner() {
+	select {
+	case s.scanWake <- struct{}{}:
+	default:
+	}
+}
+
+// ScanPendingAttachments men-scan attachment yang menunggu. File bersih dilepas (clean), file terinfeksi
+// dipindah ke karantina dan pemiliknya diberi notifikasi. Error scanner tidak mengubah status sehingga
+// attachment dicoba lagi di putaran berikutnya. Jika scanner dimatikan, attachment yang menunggu (termasuk
+// upload lama tanpa status) dilepas seperti upload baru.
+func (s *AchievementService) ScanPendingAttachments(ctx context.Context) (*model.ScanRunResult, error) {
+	result := &model.ScanRunResult{}
+	pending, err := s.mongoRepo.FindAttachmentsPendingScan(scanBatchSize)
+	if err != nil {
+		return result, err
+	}
+	for _, p := range pending {
+		updated := p.Attachment
+		if s.scanner == nil {
+			s.initialScanStatus(&updated)
+			if err := s.mongoRepo.ReplaceAttachment(p.MongoAchievementID, p.Attachment, updated); err != nil {
+				if errors.Is(err, mongo.ErrNoDocuments) {
+					continue
+				}
+				return result, err
+			}
+			result.Clean++
+			continue
+		}
+		key := p.Attachment.Key()
+
+		body, _, err := s.storage.Get(ctx, key)
+		if key == "" || errors.Is(err, storage.ErrNotFound) {
+			updated.ScanStatus = model.ScanStatusFailed
+			updated.ScanResult = "file is missing from storage"
+			result.Failed++
+		} else if err != nil {
+			log.Printf("attachment scan: cannot read %s: %v", key, err)
+			result.Retry++
+			continue
+		} else {
+			res, err := s.scanner.Scan(ctx, body)
+			body.Close()
+			if err != nil {
+				log.Printf("attachment scan: %s: %v", key, err)
+				result.Retry++
+				continue
+			}
+			if res.Infected {
+				quarantined, err := s.moveToQuarantine(ctx, p.Attachment)
+				if err != nil {
+					log.Printf("attachment scan: cannot quarantine %s: %v", key, err)
+					result.Retry++
+					continue
+				}
+				updated.StorageKey = quarantined
+				updated.ScanStatus = model.ScanStatusInfected
+				updated.ScanResult = res.Signature
+				result.Infected++
+			} else {
+				updated.ScanStatus = model.ScanStatusClean
+				result.Clean++
+			}
+		}
+		now := time.Now()
+		updated.ScannedAt = &now
+		// attachment yang dihapus/diganti selama scan tidak lagi cocok; hasilnya diabaikan
+		if err := s.mongoRepo.ReplaceAttachment(p.MongoAchievementID, p.Attachment, updated); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return result, err
+		}
+		if updated.ScanStatus == model.ScanStatusInfected {
+			notif := model.Notification{
+				ID:        uuid.New(),
+				Type:      "attachment_infected",
+				Title:     "Lampiran dikarantina",
+				Message:   "File " + updated.FileName + " terdeteksi mengandung malware (" + updated.ScanResult + ") dan dikarantina. Hapus atau ganti lampiran sebelum submit.",
+				CreatedAt: now,
+			}
+			_ = s.mongoRepo.AddNotification(p.MongoAchievementID, notif)
+		}
+	}
+	return result, nil
+}
+
+// StartAttachmentScanner menjalankan ScanPendingAttachments setiap interval, atau segera setelah ada upload,
+// sampai ctx dibatalkan
+func (s *AchievementService) StartAttachmentScanner(ctx context.Context, interval time.Duration) {
+	if s.scanner == nil {
+		log.Printf("⚠️ Antivirus scanning is disabled; attachments are accepted without scanning")
+		// lepas sekali attachment yang masih menunggu scan agar tidak menghalangi submit dan download
+		go func() {
+			released := 0
+			for ctx.Err() == nil {
+				result, err := s.ScanPendingAttachments(ctx)
+				if err != nil {
+					log.Printf("attachment scan: cannot release pending attachments: %v", err)
+					return
+				}
+				released += result.Clean
+				if result.Clean < scanBatchSize {
+					break
+				}
+			}
+			if released > 0 {
+				log.Printf("attachment scan: released %d attachments without scanning", released)
+			}
+		}()
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			result, err := s.ScanPendingAttachments(ctx)
+			if err != nil {
+				log.Printf("attachment scan failed: %v", err)
+			} else if result.Infected > 0 || result.Failed > 0 {
+				log.Printf("attachment scan: %d clean, %d infected, %d failed, %d retry", result.Clean, result.Infected, result.Failed, result.Retry)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case <-s.scanWake:
+			}
+		}
+	}()
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/repository"
+)
+
+// ==================== FULL-TEXT SEARCH ====================
+
+const (
+	maxSearchHits  = 1000 // batas hasil text search (urut skor) sebelum difilter Postgres
+	snippetRadius  = 60   // jumlah rune di kiri/kanan match pada snippet deskripsi
+	highlightStart = "<em>"
+	highlightEnd   = "</em>"
+)
+
+// visibleStudentIDs returns the students whose achievements the caller may see; nil means all (admin)
+func (s *AchievementService) visibleStudentIDs(userID uuid.UUID, role string) ([]uuid.UUID, error) {
+	if role == "Mahasiswa" {
+		student, err := s.postgresRepo.GetStudentByUserID(userID)
+		if err != nil || student == nil {
+			return nil, fiber.NewError(http.StatusForbidden, "no student profile found")
+		}
+		return []uuid.UUID{student.ID}, nil
+	}
+	return s.reviewableStudentIDs(userID, role)
+}
+
+// SearchAchievements menjalankan text search di Mongo (dibatasi ke mahasiswa yang terlihat dan maxSearchHits hasil
+// teratas) lalu menyaring hasilnya dengan filter & visibilitas di Postgres. Total dihitung dari himpunan terbatas itu.
+func (s *AchievementService) SearchAchievements(userID uuid.UUID, role string, filter model.AchievementSearchFilter, page, limit int) (*model.PaginatedResponse[model.AchievementSearchResult], error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Query == "" {
+		return nil, fiber.NewError(http.StatusBadRequest, "query parameter q is required")
+	}
+	visible, err := s.visibleStudentIDs(userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if visible != nil && len(visible) == 0 {
+		return paginate([]model.AchievementSearchResult{}, page, limit), nil
+	}
+
+	hits, err := s.mongoRepo.SearchAchievements(filter.Query, visible, maxSearchHits)
+	if err != nil {
+		return nil, err
+	}
+	byMongoID := make(map[string]model.AchievementSearchHit, len(hits))
+	mongoIDs := make([]string, 0, len(hits))
+	for _, h := range hits {
+		if !filter.MatchesEventDate(&h.Achievement) {
+			continue
+		}
+		mid := h.Achievement.ID.Hex()
+		byMongoID[mid] = h
+		mongoIDs = append(mongoIDs, mid)
+	}
+
+	pgFilter := filter.ReferenceFilter
+	pgFilter.StudentIDs = visible
+	pgFilter.MongoIDs = mongoIDs
+	refs, err := s.postgresRepo.FindAchievementReferences(pgFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	// satu hasil per dokumen; reference ketua tim diutamakan daripada reference anggota
+	chosen := map[string]model.AchievementReference{}
+	for _, ref := range refs {
+		cur, ok := chosen[ref.MongoAchievementID]
+		if !ok || (cur.PrimaryReferenceID != nil && ref.PrimaryReferenceID == nil) {
+			chosen[ref.MongoAchievementID] = ref
+		}
+	}
+
+	results := make([]model.AchievementSearchResult, 0, len(chosen))
+	for mid, ref := range chosen {
+		results = append(results, model.AchievementSearchResult{
+			AchievementReference: ref,
+			Score:                byMongoID[mid].Score,
+		})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+
+	// Total dihitung dari semua hasil terlihat dalam batas maxSearchHits; isi dokumen & highlight hanya untuk halaman ini
+	resp := paginate(results, page, limit)
+	pageIDs := make([]string, 0, len(resp.Data))
+	for _, r := range resp.Data {
+		pageIDs = append(pageIDs, r.MongoAchievementID)
+	}
+	docs, err := s.mongoRepo.GetAchievementsByIDs(pageIDs)
+	if err != nil {
+		return nil, err
+	}
+	terms := searchTerms(filter.Query)
+	for i := range resp.Data {
+		if ach, ok := docs[resp.Data[i].MongoAchievementID]; ok {
+			resp.Data[i].Achievement = ach
+			resp.Data[i].Highlights = buildHighlights(ach, terms)
+		}
+	}
+	return resp, nil
+}
+
+// searchTerms memecah query menjadi kata yang akan di-highlight (kata negasi "-x" diabaikan)
+func searchTerms(query string) []string {
+	terms := []string{}
+	for _, f := range strings.Fields(query) {
+		if strings.HasPrefix(f, "-") {
+			continue
+		}
+		f = strings.TrimFunc(f, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if f != "" {
+			terms = append(terms, strings.ToLower(f))
+		}
+	}
+	return terms
+}
+
+// buildHighlights returns highlighted fragments per field that contains at least one term
+func buildHighlights(ach *model.Achievement, terms []string) map[string]string {
+	out := map[string]string{}
+	if h, ok := highlightText(ach.Title, terms, 0); ok {
+		out["title"] = h
+	}
+	if h, ok := highlightText(ach.Description, terms, snippetRadius); ok {
+		out["description"] = h
+	}
+	if h, ok := highlightText(strings.Join(ach.Tags, ", "), terms, 0); ok {
+		out["tags"] = h
+	}
+	for field, v := range map[string]string{"organizer": ach.Organizer, "location": ach.Location, "certificateNumber": ach.CertificateNumber} {
+		if h, ok := highlightText(v, terms, 0); ok {
+			out[field] = h
+		}
+	}
+	for _, key := range repository.SearchableDetailFields {
+		v, exists := ach.Details[key]
+		if !exists || v == nil {
+			continue
+		}
+		if h, ok := highlightText(fmt.Sprint(v), terms, 0); ok {
+			out["details."+key] = h
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// highlightText membungkus setiap kemunculan term (case-insensitive) dengan <em>; hasilnya HTML yang sudah di-escape.
+// radius > 0 memotong teks menjadi snippet di sekitar match pertama.
+func highlightText(text string, terms []string, radius int) (string, bool) {
+	if text == "" || len(terms) == 0 {
+		return "", false
+	}
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	if len(lower) != len(runes) {
+		// lowercasing mengubah panjang (jarang); pakai teks asli agar indeks tetap sejajar
+		lower = runes
+	}
+
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		t := []rune(term)
+		for i := 0; i+len(t) <= len(lower); i++ {
+			if string(lower[i:i+len(t)]) != term {
+				continue
+			}
+			for j := i; j < i+len(t); j++ {
+				marked[j] = true
+			}
+			if first < 0 || i < first {
+				first = i
+			}
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	start, end := 0, len(runes)
+	if radius > 0 {
+		if first-radius > 0 {
+			start = first - radius
+		}
+		if first+radius < end {
+			end = first + radius
+		}
+		// jangan memotong di tengah match
+		for start > 0 && marked[start] && marked[start-1] {
+			start--
+		}
+		for end < len(runes) && marked[end-1] && marked[end] {
+			end++
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	// teks berasal dari input pengguna: setiap segmen di-escape agar hanya penanda <em> yang menjadi markup
+	for i := start; i < end; {
+		j := i + 1
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			segment = highlightStart + segment + highlightEnd
+		}
+		b.WriteString(segment)
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// parseDateParam menerima YYYY-MM-DD atau RFC3339; endOfDay menggeser tanggal polos ke awal hari berikutnya
+func parseDateParam(value string, endOfDay bool) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+// @Summary Search achievements
+// @Description Full-text search pada judul, deskripsi, tags, penyelenggara, lokasi, nomor sertifikat dan details, digabung dengan filter Postgres. Hasil mengikuti visibilitas role: mahasiswa hanya miliknya, dosen wali mahasiswa bimbingan, admin semua. Hanya 1000 kecocokan teratas (skor relevansi) yang dipertimbangkan; total dihitung dari batas itu. Highlight berupa HTML yang sudah di-escape dengan kecocokan dibungkus <em>.
+// @Tags Achievements
+// @Produce json
+// @Param q query string true "Search query"
+// @Param status query string false "Filter status"
+// @Param student_id query string false "Filter student ID (UUID)"
+// @Param program_study query string false "Filter program studi"
+// @Param academic_year query string false "Filter angkatan"
+// @Param advisor_id query string false "Filter dosen wali (UUID)"
+// @Param from query string false "Created from (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Created to, inklusif (YYYY-MM-DD atau RFC3339)"
+// @Param event_from query string false "Tanggal kejadian dari (YYYY-MM-DD)"
+// @Param event_to query string false "Tanggal kejadian sampai, inklusif (YYYY-MM-DD)"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Items per page (default 10)"
+// @Success 200 {object} model.PaginatedResponse[model.AchievementSearchResult]
+// @Failure 400 {object} model.ErrorResponse "Missing query or invalid filter"
+// @Failure 403 {object} model.ErrorResponse "Forbidden"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /achievements/search [get]
+func (s *AchievementService) SearchHandler(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	filter := model.AchievementSearchFilter{Query: c.Query("q")}
+	filter.ProgramStudy = c.Query("program_study")
+	filter.AcademicYear = c.Query("academic_year")
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+	if sid := c.Query("student_id"); sid != "" {
+		studentID, err := uuid.Parse(sid)
+		if err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid student ID"})
+		}
+		filter.StudentID = &studentID
+	}
+	if aid := c.Query("advisor_id"); aid != "" {
+		advisorID, err := uuid.Parse(aid)
+		if err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid advisor ID"})
+		}
+		filter.AdvisorID = &advisorID
+	}
+	if from := c.Query("from"); from != "" {
+		if filter.CreatedFrom, err = parseDateParam(from, false); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid from date"})
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.CreatedTo, err = parseDateParam(to, true); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid to date"})
+		}
+	}
+	if from := c.Query("event_from"); from != "" {
+		if filter.EventFrom, err = parseDateParam(from, false); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event_from date"})
+		}
+	}
+	if to := c.Query("event_to"); to != "" {
+		if filter.EventTo, err = parseDateParam(to, true); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event_to date"})
+		}
+	}
+
+	resp, err := s.SearchAchievements(userID, role, filter, page, limit)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(resp)
+}
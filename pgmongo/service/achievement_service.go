@@ -1,29 +1,135 @@
 package service
 
 import (
-	"io"
+	"context"
+	"errors"
+	"log"
 	"net/http"
-	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 
+	"BACKEND-UAS/pgmongo/extract"
 	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/preview"
+	"BACKEND-UAS/pgmongo/receipt"
 	"BACKEND-UAS/pgmongo/repository"
+	"BACKEND-UAS/pgmongo/scanner"
+	"BACKEND-UAS/pgmongo/storage"
 )
 
+// AchievementConfig berisi pengaturan workflow verifikasi; nilai nol memakai default
+type AchievementConfig struct {
+	ReviewSLA        time.Duration
+	ReviewClaimTTL   time.Duration
+	TeamPointsPolicy string // policy default untuk prestasi tim baru
+
+	// Draft cleanup: pengingat untuk draft yang tidak disentuh, lalu soft delete setelah DraftAbandonAfter
+	DraftReminderAfter time.Duration
+	DraftAbandonAfter  time.Duration
+
+	// AttachmentLinkTTL adalah masa berlaku signed link attachment
+	AttachmentLinkTTL time.Duration
+
+	// Validasi upload: batas ukuran per jenis file hasil sniffing dan jumlah attachment per prestasi
+	AttachmentMaxSize map[string]int64
+	MaxAttachments    int
+
+	// AttachmentGCGrace melindungi file dan blob yang baru ditulis/dilepas dari GC (upload yang sedang berjalan)
+	AttachmentGCGrace time.Duration
+
+	// Resumable upload: ukuran maksimum satu chunk dan masa berlaku sesi sejak chunk terakhir
+	UploadChunkMax int64
+	UploadExpiry   time.Duration
+}
+
+const (
+	defaultReviewSLA          = 7 * 24 * time.Hour
+	defaultReviewClaimTTL     = 2 * time.Hour
+	defaultDraftReminderAfter = 14 * 24 * time.Hour
+	defaultDraftAbandonAfter  = 60 * 24 * time.Hour
+	defaultAttachmentLinkTTL  = 5 * time.Minute
+	defaultMaxAttachments     = 10
+	defaultAttachmentGCGrace  = 24 * time.Hour
+	defaultUploadChunkMax     = 5 << 20
+	defaultUploadExpiry       = 24 * time.Hour
+)
+
+// defaultAttachmentMaxSize dipakai untuk jenis file yang batasnya tidak dikonfigurasi
+var defaultAttachmentMaxSize = map[string]int64{
+	model.MIMETypePDF:  10 << 20,
+	model.MIMETypeJPEG: 5 << 20,
+	model.MIMETypePNG:  5 << 20,
+	model.MIMETypeMP4:  500 << 20,
+}
+
 type AchievementService struct {
-	postgresRepo *repository.AchievementRepository
-	mongoRepo    *repository.AchievementRepositoryMongo
+	postgresRepo repository.AchievementPostgresRepository
+	mongoRepo    repository.AchievementMongoRepository
+	tagRepo      repository.TagRepository
+	storage      storage.Storage
+	scanner      scanner.Scanner // nil berarti scanning antivirus dimatikan
+	scanWake     chan struct{}
+	renderer     preview.Renderer  // nil berarti preview attachment dimatikan
+	extractor    extract.Extractor // nil berarti ekstraksi teks/QR attachment dimatikan
+	receipts     *receipt.Signer   // nil berarti receipt verifikasi tidak diterbitkan
+	blobLocks    blobLocks
+	cfg          AchievementConfig
 }
 
-func NewAchievementService(pgRepo *repository.AchievementRepository, mongoRepo *repository.AchievementRepositoryMongo) *AchievementService {
+func NewAchievementService(pgRepo repository.AchievementPostgresRepository, mongoRepo repository.AchievementMongoRepository, tagRepo repository.TagRepository, store storage.Storage, avScanner scanner.Scanner, renderer preview.Renderer, extractor extract.Extractor, receipts *receipt.Signer, cfg AchievementConfig) *AchievementService {
+	if cfg.ReviewSLA <= 0 {
+		cfg.ReviewSLA = defaultReviewSLA
+	}
+	if cfg.ReviewClaimTTL <= 0 {
+		cfg.ReviewClaimTTL = defaultReviewClaimTTL
+	}
+	if cfg.DraftReminderAfter <= 0 {
+		cfg.DraftReminderAfter = defaultDraftReminderAfter
+	}
+	if cfg.DraftAbandonAfter <= cfg.DraftReminderAfter {
+		cfg.DraftAbandonAfter = max(defaultDraftAbandonAfter, 2*cfg.DraftReminderAfter)
+	}
+	if cfg.AttachmentLinkTTL <= 0 {
+		cfg.AttachmentLinkTTL = defaultAttachmentLinkTTL
+	}
+	maxSize := make(map[string]int64, len(defaultAttachmentMaxSize))
+	for mimeType, def := range defaultAttachmentMaxSize {
+		maxSize[mimeType] = def
+		if v := cfg.AttachmentMaxSize[mimeType]; v > 0 {
+			maxSize[mimeType] = v
+		}
+	}
+	cfg.AttachmentMaxSize = maxSize
+	if cfg.MaxAttachments <= 0 {
+		cfg.MaxAttachments = defaultMaxAttachments
+	}
+	if cfg.AttachmentGCGrace <= 0 {
+		cfg.AttachmentGCGrace = defaultAttachmentGCGrace
+	}
+	if cfg.UploadChunkMax <= 0 {
+		cfg.UploadChunkMax = defaultUploadChunkMax
+	}
+	if cfg.UploadExpiry <= 0 {
+		cfg.UploadExpiry = defaultUploadExpiry
+	}
+	if !model.IsValidPointsPolicy(cfg.TeamPointsPolicy) {
+		cfg.TeamPointsPolicy = model.PointsPolicySplit
+	}
 	return &AchievementService{
 		postgresRepo: pgRepo,
 		mongoRepo:    mongoRepo,
+		tagRepo:      tagRepo,
+		storage:      store,
+		scanner:      avScanner,
+		scanWake:     make(chan struct{}, 1),
+		renderer:     renderer,
+		extractor:    extractor,
+		receipts:     receipts,
+		cfg:          cfg,
 	}
 }
 
@@ -64,7 +170,9 @@ func (s *AchievementService) GetUserAchievements(userID uuid.UUID, role string,
 	}
 }
 
-func (s *AchievementService) GetAchievementDetail(id uuid.UUID) (*model.AchievementDetailResponse, error) {
+// GetAchievementDetail returns the achievement; signed link preview attachment hanya disertakan
+// untuk user yang boleh melihat attachment-nya
+func (s *AchievementService) GetAchievementDetail(id, userID uuid.UUID, role string) (*model.AchievementDetailResponse, error) {
 	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
 	if err != nil {
 		return nil, err
@@ -77,6 +185,22 @@ func (s *AchievementService) GetAchievementDetail(id uuid.UUID) (*model.Achievem
 	if err != nil || ach == nil || ach.DeletedAt != nil {
 		return nil, fiber.NewError(http.StatusNotFound, "achievement details not found or deleted")
 	}
+	// FileURL lama (/uploads/...) tidak dilayani; arahkan ke route download yang memeriksa hak akses
+	for i := range ach.Attachments {
+		ach.Attachments[i].FileURL = ach.Attachments[i].DownloadPath(ref.ID.String())
+	}
+	var previews []model.AttachmentPreview
+	var hints []model.VerificationHint
+	var receiptToken string
+	if s.ensureCanView(ref, userID, role) == nil {
+		previews = s.attachmentPreviews(context.Background(), ach)
+		if role == "Dosen Wali" || role == "Admin" {
+			hints = verificationHints(ach)
+		}
+		if ref.Status == "verified" {
+			receiptToken, _ = s.postgresRepo.GetVerificationReceipt(ref.ID)
+		}
+	}
 
 	return &model.AchievementDetailResponse{
 		ID:            ref.ID.String(),
@@ -86,8 +210,12 @@ func (s *AchievementService) GetAchievementDetail(id uuid.UUID) (*model.Achievem
 		VerifiedAt:    ref.VerifiedAt,
 		VerifiedBy:    ref.VerifiedBy,
 		RejectionNote: ref.RejectionNote,
+		Version:       ref.Version,
 		Achievement:   *ach,
 		StatusHistory: ach.StatusHistory,
+		Previews:      previews,
+		Hints:         hints,
+		Receipt:       receiptToken,
 	}, nil
 }
 
@@ -98,6 +226,19 @@ func (s *AchievementService) CreateAchievement(userID uuid.UUID, ach model.Achie
 	}
 
 	ach.StudentID = student.ID
+	if ach.Tags, err = s.normalizeTags(ach.Tags, nil); err != nil {
+		return nil, err
+	}
+	ach.NormalizeEventFields()
+	ach.PromoteLegacyEventFields()
+	if err := ach.ValidateEventFields(time.Now()); err != nil {
+		return nil, fiber.NewError(http.StatusBadRequest, err.Error())
+	}
+	if ach.Team != nil {
+		if err := s.prepareTeam(ach.Team, student.ID); err != nil {
+			return nil, err
+		}
+	}
 	if err := s.mongoRepo.CreateAchievement(&ach); err != nil {
 		return nil, err
 	}
@@ -113,25 +254,24 @@ func (s *AchievementService) CreateAchievement(userID uuid.UUID, ach model.Achie
 	if err := s.postgresRepo.CreateAchievementReference(ref); err != nil {
 		return nil, err
 	}
+	s.recordRevision(ref, &ach, ref.Version, model.RevisionCreated, ref.Status, &userID)
 	return ref, nil
 }
 
-func (s *AchievementService) UpdateAchievement(id uuid.UUID, updatedAch model.Achievement) error {
-	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
-	if err != nil {
-		return err
-	}
-	if ref.Status == "deleted" || (ref.Status != "draft" && ref.Status != "rejected") {
-		return fiber.NewError(http.StatusBadRequest, "cannot update this achievement")
-	}
+// isEditableStatus: draft, rejected, dan revoked masih boleh diedit lalu disubmit
+func isEditableStatus(status string) bool {
+	return status == "draft" || status == "rejected" || status == "revoked"
+}
 
-	objID, err := primitive.ObjectIDFromHex(ref.MongoAchievementID)
+// UpdateAchievement mengganti field konten achievement; field milik server (attachments, history, points, ...) tetap dipertahankan.
+// version harus sama dengan ETag yang dibaca client.
+func (s *AchievementService) UpdateAchievement(id, userID uuid.UUID, updatedAch model.Achievement, version int64) error {
+	ref, current, err := s.loadEditableAchievement(id, userID, version)
 	if err != nil {
 		return err
 	}
-	updatedAch.ID = objID
-	updatedAch.StudentID = ref.StudentID
-	return s.mongoRepo.UpdateAchievement(ref.MongoAchievementID, &updatedAch)
+	_, err = s.saveAchievementContent(ref, current, updatedAch, userID, version)
+	return err
 }
 
 func (s *AchievementService) DeleteAchievement(id uuid.UUID, userID uuid.UUID) error {
@@ -139,48 +279,99 @@ func (s *AchievementService) DeleteAchievement(id uuid.UUID, userID uuid.UUID) e
 	if err != nil || ref.Status != "draft" {
 		return fiber.NewError(http.StatusBadRequest, "can only delete draft achievement")
 	}
+	if err := ensurePrimary(ref); err != nil {
+		return err
+	}
 
-	if err := s.mongoRepo.SoftDeleteAchievement(ref.MongoAchievementID); err != nil {
+	// reference dihapus dulu secara bersyarat agar submit yang berjalan bersamaan tidak kehilangan dokumennya
+	if err := s.postgresRepo.SoftDeleteAchievementReference(id, time.Now()); err != nil {
+		if errors.Is(err, repository.ErrDraftChanged) {
+			return fiber.NewError(http.StatusBadRequest, "can only delete draft achievement")
+		}
 		return err
 	}
-	if err := s.postgresRepo.SoftDeleteAchievementReference(id); err != nil {
+	if err := s.mongoRepo.SoftDeleteAchievement(ref.MongoAchievementID); err != nil {
 		return err
 	}
 
 	history := model.StatusHistory{Status: "deleted", ChangedBy: &userID, ChangedAt: time.Now(), Note: "Dihapus oleh mahasiswa"}
 	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history) // ignore error
+	_ = s.mongoRepo.DeleteAutosave(ref.MongoAchievementID)
 	return nil
 }
 
-func (s *AchievementService) SubmitAchievement(id uuid.UUID, userID uuid.UUID) error {
+func (s *AchievementService) SubmitAchievement(id uuid.UUID, userID uuid.UUID, version int64) error {
 	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
-	if err != nil || (ref.Status != "draft" && ref.Status != "rejected") {
-		return fiber.NewError(http.StatusBadRequest, "only draft/rejected can be submitted")
+	if err != nil || !isEditableStatus(ref.Status) {
+		return fiber.NewError(http.StatusBadRequest, "only draft/rejected/revoked can be submitted")
 	}
-
-	if err := s.postgresRepo.SubmitAchievement(id); err != nil {
+	if err := ensurePrimary(ref); err != nil {
 		return err
 	}
+	if ref.Version != version {
+		return errPreconditionFailed
+	}
+
+	ach, _ := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	if ach != nil {
+		if missing := ach.MissingEventFields(); len(missing) > 0 {
+			return fiber.NewError(http.StatusUnprocessableEntity, "missing required fields for "+ach.AchievementType+": "+strings.Join(missing, ", "))
+		}
+		if blocked := ach.UnscannedOrInfected(); len(blocked) > 0 {
+			names := make([]string, 0, len(blocked))
+			for _, att := range blocked {
+				status := att.ScanStatus
+				if att.IsScanPending() {
+					status = model.ScanStatusPending
+				}
+				names = append(names, att.FileName+" ("+status+")")
+			}
+			return fiber.NewError(http.StatusUnprocessableEntity, "attachments have not passed the virus scan: "+strings.Join(names, ", "))
+		}
+	}
+
+	if err := s.postgresRepo.SubmitAchievement(id, ref.Status, version); err != nil {
+		return mapVersionConflict(err)
+	}
 
 	history := model.StatusHistory{Status: "submitted", ChangedBy: &userID, ChangedAt: time.Now(), Note: "Disubmit untuk verifikasi"}
 	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
+	_ = s.mongoRepo.DeleteAutosave(ref.MongoAchievementID)
+
+	s.recordRevision(ref, ach, version+1, model.RevisionSubmitted, "submitted", &userID)
 	return nil
 }
 
-func (s *AchievementService) VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUID) error {
+func (s *AchievementService) VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUID, version int64) error {
 	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
 	if err != nil || ref.Status != "submitted" {
 		return fiber.NewError(http.StatusBadRequest, "only submitted can be verified")
 	}
-
-	if err := s.postgresRepo.VerifyAchievement(id, verifiedBy, nil); err != nil {
+	if err := ensurePrimary(ref); err != nil {
+		return err
+	}
+	if ref.Version != version {
+		return errPreconditionFailed
+	}
+	if err := s.checkClaim(ref, verifiedBy); err != nil {
 		return err
 	}
 
-	history := model.StatusHistory{Status: "verified", ChangedBy: &verifiedBy, ChangedAt: time.Now(), Note: "Diverifikasi"}
+	if err := s.postgresRepo.VerifyAchievement(id, verifiedBy, nil, version); err != nil {
+		return mapVersionConflict(err)
+	}
+	now := time.Now()
+
+	history := model.StatusHistory{Status: "verified", ChangedBy: &verifiedBy, ChangedAt: now, Note: "Diverifikasi"}
 	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
 
 	ach, _ := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	// verifikasi sudah tersimpan; receipt yang gagal diterbitkan bisa diterbitkan ulang admin lewat ReissueReceipt
+	if s.receipts != nil && ach != nil {
+		if _, err := s.issueReceipt(ref, ach, verifiedBy, now, version+1); err != nil {
+			log.Printf("verification receipt: cannot issue receipt for %s: %v", ref.ID, err)
+		}
+	}
 	title := "Prestasi Anda"
 	if ach != nil && ach.Title != "" {
 		title = ach.Title
@@ -190,15 +381,24 @@ func (s *AchievementService) VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUI
 	return nil
 }
 
-func (s *AchievementService) RejectAchievement(id uuid.UUID, verifiedBy uuid.UUID, note string) error {
+func (s *AchievementService) RejectAchievement(id uuid.UUID, verifiedBy uuid.UUID, note string, version int64) error {
 	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
 	if err != nil || ref.Status != "submitted" {
 		return fiber.NewError(http.StatusBadRequest, "only submitted can be rejected")
 	}
-
-	if err := s.postgresRepo.VerifyAchievement(id, verifiedBy, &note); err != nil {
+	if err := ensurePrimary(ref); err != nil {
 		return err
 	}
+	if ref.Version != version {
+		return errPreconditionFailed
+	}
+	if err := s.checkClaim(ref, verifiedBy); err != nil {
+		return err
+	}
+
+	if err := s.postgresRepo.VerifyAchievement(id, verifiedBy, &note, version); err != nil {
+		return mapVersionConflict(err)
+	}
 
 	history := model.StatusHistory{Status: "rejected", ChangedBy: &verifiedBy, ChangedAt: time.Now(), Note: "Ditolak: " + note}
 	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
@@ -213,6 +413,73 @@ func (s *AchievementService) RejectAchievement(id uuid.UUID, verifiedBy uuid.UUI
 	return nil
 }
 
+// RevokeAchievement mencabut verifikasi (mis. sertifikat palsu); hanya admin dan alasan wajib diisi.
+// Achievement yang dicabut bisa diperbaiki dan disubmit ulang seperti achievement yang ditolak.
+func (s *AchievementService) RevokeAchievement(id, adminID uuid.UUID, role, reason string, version int64) error {
+	if role != "Admin" {
+		return fiber.NewError(http.StatusForbidden, "only admins can revoke a verification")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return fiber.NewError(http.StatusBadRequest, "reason is required")
+	}
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref.Status != "verified" {
+		return fiber.NewError(http.StatusBadRequest, "only verified can be revoked")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return err
+	}
+	if ref.Version != version {
+		return errPreconditionFailed
+	}
+
+	if err := s.postgresRepo.RevokeAchievement(id, reason, version); err != nil {
+		return mapVersionConflict(err)
+	}
+
+	history := model.StatusHistory{Status: "revoked", ChangedBy: &adminID, ChangedAt: time.Now(), Note: "Verifikasi dicabut: " + reason}
+	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
+
+	ach, _ := s.mongoRepo.GetAchievementByID(ref.MongoAchievementID)
+	title := "Prestasi Anda"
+	if ach != nil && ach.Title != "" {
+		title = ach.Title
+	}
+	notif := model.Notification{Type: "achievement_revoked", Title: "Verifikasi Dicabut", Message: title + " dicabut verifikasinya: " + reason, Read: false, CreatedAt: time.Now()}
+	_ = s.mongoRepo.AddNotification(ref.MongoAchievementID, notif)
+	return nil
+}
+
+// WithdrawAchievement menarik submission milik mahasiswa sendiri kembali ke draft
+func (s *AchievementService) WithdrawAchievement(id, userID uuid.UUID, version int64) error {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref.Status != "submitted" {
+		return fiber.NewError(http.StatusBadRequest, "only submitted can be withdrawn")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return err
+	}
+	student, err := s.postgresRepo.GetStudentByUserID(userID)
+	if err != nil || student == nil || student.ID != ref.StudentID {
+		return fiber.NewError(http.StatusForbidden, "only the owner can withdraw this submission")
+	}
+	if ref.Version != version {
+		return errPreconditionFailed
+	}
+
+	if err := s.postgresRepo.WithdrawAchievement(id, version); err != nil {
+		return mapVersionConflict(err)
+	}
+
+	history := model.StatusHistory{Status: "draft", ChangedBy: &userID, ChangedAt: time.Now(), Note: "Submission ditarik oleh mahasiswa"}
+	_ = s.mongoRepo.AddStatusHistory(ref.MongoAchievementID, history)
+
+	notif := model.Notification{Type: "achievement_withdrawn", Title: "Submission Ditarik", Message: "Submission ditarik kembali ke draft", Read: false, CreatedAt: time.Now()}
+	_ = s.mongoRepo.AddNotification(ref.MongoAchievementID, notif)
+	return nil
+}
+
 func (s *AchievementService) GetAchievementHistory(id uuid.UUID) ([]model.StatusHistory, error) {
 	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
 	if err != nil {
@@ -225,26 +492,68 @@ func (s *AchievementService) GetAchievementHistory(id uuid.UUID) ([]model.Status
 	return ach.StatusHistory, nil
 }
 
-func (s *AchievementService) UploadAttachment(id uuid.UUID, file io.Reader, fileName, fileType string) (*model.Attachment, error) {
-	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
-	if err != nil || ref.Status == "deleted" {
-		return nil, fiber.NewError(http.StatusBadRequest, "cannot upload to this achievement")
+// ==================== ETAG / OPTIMISTIC CONCURRENCY ====================
+
+var errPreconditionFailed = fiber.NewError(http.StatusPreconditionFailed, "achievement was modified; reload and retry with the latest ETag")
+
+func mapVersionConflict(err error) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return errPreconditionFailed
 	}
-	return s.mongoRepo.UploadAttachment(ref.MongoAchievementID, file, fileName, fileType)
+	return err
+}
+
+func setETag(c *fiber.Ctx, version int64) {
+	c.Set(fiber.HeaderETag, `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// parseIfMatch membaca version dari header If-Match ("3", W/"3" atau 3)
+func parseIfMatch(c *fiber.Ctx) (int64, error) {
+	raw := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if raw == "" {
+		return 0, fiber.NewError(http.StatusPreconditionRequired, "If-Match header is required")
+	}
+	raw = strings.Trim(strings.TrimPrefix(raw, "W/"), `"`)
+	version, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || version < 1 {
+		return 0, errPreconditionFailed
+	}
+	return version, nil
 }
 
 // ==================== HANDLERS WITH SWAGGER ====================
 
 // @Summary List achievements (filtered by role)
-// @Description Mengambil daftar prestasi berdasarkan role user (mahasiswa: own, dosen: advisees, admin: all), dengan filter status dan pagination
+// @Description Mengambil daftar prestasi berdasarkan role user (mahasiswa: own, dosen: advisees, admin: all), dengan filter, sorting dan pagination. Filter konten (type, level, tags, points, tanggal kejadian) dibatasi 2000 prestasi yang cocok
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Param page query int false "Page number (default 1)"
 // @Param limit query int false "Items per page (default 10)"
 // @Param status query string false "Filter status (draft, submitted, verified, rejected, deleted)"
+// @Param type query string false "Filter achievement type"
+// @Param level query string false "Filter level"
+// @Param tags query string false "Filter tags, dipisah koma (semua harus ada)"
+// @Param min_points query int false "Minimum points"
+// @Param max_points query int false "Maximum points"
+// @Param program_study query string false "Filter program studi"
+// @Param academic_year query string false "Filter angkatan"
+// @Param student_id query string false "Filter student ID (UUID)"
+// @Param created_from query string false "Created from (YYYY-MM-DD atau RFC3339)"
+// @Param created_to query string false "Created to, inklusif"
+// @Param submitted_from query string false "Submitted from"
+// @Param submitted_to query string false "Submitted to, inklusif"
+// @Param verified_from query string false "Verified from"
+// @Param verified_to query string false "Verified to, inklusif"
+// @Param event_from query string false "Tanggal kejadian dari (eventDate, atau tanggal dibuat jika kosong)"
+// @Param event_to query string false "Tanggal kejadian sampai, inklusif"
+// @Param include query string false "Isi tambahan per item: achievement (konten Mongo dimuat batch per halaman)"
+// @Param pagination query string false "cursor untuk keyset pagination (halaman pertama); respons berbentuk model.CursorResponse"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya (mengaktifkan keyset pagination)"
+// @Param with_total query bool false "Sertakan total pada keyset pagination (menjalankan COUNT)"
+// @Param sort query string false "Sort fields dipisah koma, awalan - untuk descending (created_at, submitted_at, verified_at, status, points, title, type, level, event_date), mis. -points,submitted_at. Sort field konten (points, title, type, level, event_date) dibatasi 2000 hasil"
 // @Success 200 {object} model.PaginatedResponse[model.AchievementReference]
-// @Failure 400 {object} model.ErrorResponse "Invalid role"
+// @Failure 400 {object} model.ErrorResponse "Invalid role or filter, or content filter/sort over too many results"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
 // @Router /achievements [get]
@@ -266,24 +575,44 @@ func (s *AchievementService) ListHandler(c *fiber.Ctx) error {
 		limit = 10
 	}
 
-	status := c.Query("status")
-	var statusPtr *string
-	if status != "" {
-		statusPtr = &status
+	filter, err := parseListFilter(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	cursorPage, err := parseCursorPage(c, limit)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if cursorPage != nil {
+		resp, err := s.ListAchievementsByCursor(userID, role, filter, *cursorPage)
+		if err != nil {
+			return handleServiceError(c, err)
+		}
+		if hasInclude(c, "achievement") {
+			if err := includeAchievements(s.mongoRepo, resp.Data); err != nil {
+				return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		return c.JSON(resp)
 	}
 
-	resp, err := s.GetUserAchievements(userID, role, statusPtr, page, limit)
+	resp, err := s.ListAchievements(userID, role, filter, page, limit)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	if hasInclude(c, "achievement") {
+		if err := includeAchievements(s.mongoRepo, resp.Data); err != nil {
+			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
 	return c.JSON(resp)
 }
 
 // @Summary Get achievement detail
-// @Description Mengambil detail lengkap prestasi berdasarkan ID, termasuk history status
+// @Description Mengambil detail lengkap prestasi berdasarkan ID, termasuk history status. Untuk user yang boleh melihat attachment, previews berisi signed link thumbnail dan preview (halaman pertama PDF) yang sudah lolos scan antivirus.
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -300,13 +629,20 @@ func (s *AchievementService) DetailHandler(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
 	}
 
-	resp, err := s.GetAchievementDetail(id)
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+	role, _ := c.Locals("role").(string)
+
+	resp, err := s.GetAchievementDetail(id, userID, role)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	setETag(c, resp.Version)
 	return c.JSON(resp)
 }
 
@@ -340,20 +676,29 @@ func (s *AchievementService) CreateHandler(c *fiber.Ctx) error {
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	// Peringatan duplikat tidak memblokir pembuatan
+	if dups, err := s.DuplicateWarnings(ref.ID); err == nil && len(dups) > 0 {
+		ref.Duplicates = dups
+	}
+	setETag(c, ref.Version)
 	return c.Status(http.StatusCreated).JSON(ref)
 }
 
 // @Summary Update achievement
-// @Description Memperbarui prestasi (hanya untuk draft atau rejected status)
+// @Description Memperbarui prestasi (hanya untuk draft, rejected, atau revoked status). Attachments, status history, points dan timestamp tidak ikut diganti.
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Param id path string true "Achievement ID (UUID)"
 // @Param achievement body model.Achievement true "Updated achievement data"
+// @Param If-Match header string true "ETag (version) dari GET /achievements/{id}"
 // @Success 200 {object} map[string]string "message: Updated successfully"
 // @Failure 400 {object} model.ErrorResponse "Cannot update this achievement"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
 // @Failure 500 {object} model.ErrorResponse "Failed to update"
 // @Security ApiKeyAuth
+// @Failure 412 {object} model.ErrorResponse "Version mismatch (ETag out of date)"
+// @Failure 428 {object} model.ErrorResponse "If-Match header required"
 // @Router /achievements/{id} [put]
 func (s *AchievementService) UpdateHandler(c *fiber.Ctx) error {
 	idStr := c.Params("id")
@@ -362,18 +707,29 @@ func (s *AchievementService) UpdateHandler(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
 	}
 
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid user"})
+	}
+
 	var updatedAch model.Achievement
 	if err := c.BodyParser(&updatedAch); err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
 	}
 
-	err = s.UpdateAchievement(id, updatedAch)
+	err = s.UpdateAchievement(id, userID, updatedAch, version)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	setETag(c, version+1)
 	return c.JSON(fiber.Map{"message": "Updated successfully"})
 }
 
@@ -417,10 +773,13 @@ func (s *AchievementService) DeleteHandler(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Achievement ID (UUID)"
-// @Success 200 {object} map[string]string "status: submitted"
-// @Failure 400 {object} model.ErrorResponse "Only draft/rejected can be submitted"
+// @Param If-Match header string true "ETag (version) dari GET /achievements/{id}"
+// @Success 200 {object} map[string]interface{} "status: submitted, duplicates: kemungkinan duplikat (jika ada)"
+// @Failure 400 {object} model.ErrorResponse "Only draft/rejected/revoked can be submitted"
 // @Failure 500 {object} model.ErrorResponse "Failed to submit"
 // @Security ApiKeyAuth
+// @Failure 412 {object} model.ErrorResponse "Version mismatch (ETag out of date)"
+// @Failure 428 {object} model.ErrorResponse "If-Match header required"
 // @Router /achievements/{id}/submit [post]
 func (s *AchievementService) SubmitHandler(c *fiber.Ctx) error {
 	idStr := c.Params("id")
@@ -435,14 +794,24 @@ func (s *AchievementService) SubmitHandler(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
 	}
 
-	err = s.SubmitAchievement(id, userID)
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	err = s.SubmitAchievement(id, userID, version)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(fiber.Map{"status": "submitted"})
+	setETag(c, version+1)
+	resp := fiber.Map{"status": "submitted"}
+	if dups, err := s.DuplicateWarnings(id); err == nil && len(dups) > 0 {
+		resp["duplicates"] = dups
+	}
+	return c.JSON(resp)
 }
 
 // @Summary Verify achievement
@@ -451,10 +820,15 @@ func (s *AchievementService) SubmitHandler(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "Achievement ID (UUID)"
+// @Param If-Match header string true "ETag (version) dari GET /achievements/{id}"
 // @Success 200 {object} map[string]string "status: verified"
 // @Failure 400 {object} model.ErrorResponse "Only submitted can be verified"
+// @Failure 403 {object} model.ErrorResponse "Not a reviewer of this achievement"
+// @Failure 409 {object} model.ErrorResponse "Claimed by another reviewer"
 // @Failure 500 {object} model.ErrorResponse "Failed to verify"
 // @Security ApiKeyAuth
+// @Failure 412 {object} model.ErrorResponse "Version mismatch (ETag out of date)"
+// @Failure 428 {object} model.ErrorResponse "If-Match header required"
 // @Router /achievements/{id}/verify [post]
 func (s *AchievementService) VerifyHandler(c *fiber.Ctx) error {
 	idStr := c.Params("id")
@@ -469,13 +843,24 @@ func (s *AchievementService) VerifyHandler(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
 	}
 
-	err = s.VerifyAchievement(id, verifiedBy)
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	role, _ := c.Locals("role").(string)
+	if err := s.ensureCanReviewID(id, verifiedBy, role); err != nil {
+		return handleServiceError(c, err)
+	}
+
+	err = s.VerifyAchievement(id, verifiedBy, version)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	setETag(c, version+1)
 	return c.JSON(fiber.Map{"status": "verified"})
 }
 
@@ -486,10 +871,15 @@ func (s *AchievementService) VerifyHandler(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "Achievement ID (UUID)"
 // @Param rejection_note body object true "Rejection note" schema={"type":"object","properties":{"rejection_note":{"type":"string"}}}
+// @Param If-Match header string true "ETag (version) dari GET /achievements/{id}"
 // @Success 200 {object} map[string]string "status: rejected"
 // @Failure 400 {object} model.ErrorResponse "Note required or wrong status"
+// @Failure 403 {object} model.ErrorResponse "Not a reviewer of this achievement"
+// @Failure 409 {object} model.ErrorResponse "Claimed by another reviewer"
 // @Failure 500 {object} model.ErrorResponse "Failed to reject"
 // @Security ApiKeyAuth
+// @Failure 412 {object} model.ErrorResponse "Version mismatch (ETag out of date)"
+// @Failure 428 {object} model.ErrorResponse "If-Match header required"
 // @Router /achievements/{id}/reject [post]
 func (s *AchievementService) RejectHandler(c *fiber.Ctx) error {
 	idStr := c.Params("id")
@@ -512,83 +902,130 @@ func (s *AchievementService) RejectHandler(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
 	}
 
-	err = s.RejectAchievement(id, verifiedBy, req.RejectionNote)
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	role, _ := c.Locals("role").(string)
+	if err := s.ensureCanReviewID(id, verifiedBy, role); err != nil {
+		return handleServiceError(c, err)
+	}
+
+	err = s.RejectAchievement(id, verifiedBy, req.RejectionNote, version)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	setETag(c, version+1)
 	return c.JSON(fiber.Map{"status": "rejected"})
 }
 
-// @Summary Get achievement history
-// @Description Mengambil riwayat status prestasi
+// @Summary Revoke verification
+// @Description Mencabut verifikasi prestasi (hanya admin, dari verified status) dengan alasan wajib. Prestasi kembali bisa diedit dan disubmit ulang.
 // @Tags Achievements
 // @Accept json
 // @Produce json
 // @Param id path string true "Achievement ID (UUID)"
-// @Success 200 {array} model.StatusHistory
-// @Failure 404 {object} model.ErrorResponse "Achievement not found"
-// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Param reason body object true "Alasan pencabutan" schema={"type":"object","properties":{"reason":{"type":"string"}}}
+// @Param If-Match header string true "ETag (version) dari GET /achievements/{id}"
+// @Success 200 {object} map[string]string "status: revoked"
+// @Failure 400 {object} model.ErrorResponse "Reason required or wrong status"
+// @Failure 403 {object} model.ErrorResponse "Admin only"
+// @Failure 412 {object} model.ErrorResponse "Version mismatch (ETag out of date)"
+// @Failure 428 {object} model.ErrorResponse "If-Match header required"
 // @Security ApiKeyAuth
-// @Router /achievements/{id}/history [get]
-func (s *AchievementService) HistoryHandler(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	id, err := uuid.Parse(idStr)
+// @Router /achievements/{id}/revoke [post]
+func (s *AchievementService) RevokeHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
 	}
 
-	histories, err := s.GetAchievementHistory(id)
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+
+	adminID, err := uuid.Parse(c.Locals("user_id").(string))
 	if err != nil {
-		if fe, ok := err.(*fiber.Error); ok {
-			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
-		}
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
 	}
-	return c.JSON(histories)
+	version, err := parseIfMatch(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	role, _ := c.Locals("role").(string)
+	if err := s.RevokeAchievement(id, adminID, role, req.Reason, version); err != nil {
+		return handleServiceError(c, err)
+	}
+	setETag(c, version+1)
+	return c.JSON(fiber.Map{"status": "revoked"})
 }
 
-// @Summary Upload attachment
-// @Description Upload file attachment ke prestasi (hanya untuk non-deleted)
+// @Summary Withdraw submission
+// @Description Menarik kembali submission (oleh mahasiswa pemilik, dari submitted status) menjadi draft
 // @Tags Achievements
-// @Accept multipart/form-data
 // @Produce json
 // @Param id path string true "Achievement ID (UUID)"
-// @Param file formData file true "Attachment file"
-// @Success 200 {object} model.Attachment
-// @Failure 400 {object} model.ErrorResponse "No file or invalid achievement"
-// @Failure 500 {object} model.ErrorResponse "Failed to upload"
+// @Param If-Match header string true "ETag (version) dari GET /achievements/{id}"
+// @Success 200 {object} map[string]string "status: draft"
+// @Failure 400 {object} model.ErrorResponse "Only submitted can be withdrawn"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
+// @Failure 412 {object} model.ErrorResponse "Version mismatch (ETag out of date)"
+// @Failure 428 {object} model.ErrorResponse "If-Match header required"
 // @Security ApiKeyAuth
-// @Router /achievements/{id}/attachments [post]
-func (s *AchievementService) UploadAttachmentHandler(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	id, err := uuid.Parse(idStr)
+// @Router /achievements/{id}/withdraw [post]
+func (s *AchievementService) WithdrawHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
 	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
 	}
-
-	file, err := c.FormFile("file")
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
 	if err != nil {
-		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "No file uploaded"})
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
 	}
-
-	src, err := file.Open()
+	version, err := parseIfMatch(c)
 	if err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return handleServiceError(c, err)
+	}
+
+	if err := s.WithdrawAchievement(id, userID, version); err != nil {
+		return handleServiceError(c, err)
 	}
-	defer src.Close()
+	setETag(c, version+1)
+	return c.JSON(fiber.Map{"status": "draft"})
+}
 
-	ext := filepath.Ext(file.Filename)
-	fileName := uuid.New().String() + ext
+// @Summary Get achievement history
+// @Description Mengambil riwayat status prestasi
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Success 200 {array} model.StatusHistory
+// @Failure 404 {object} model.ErrorResponse "Achievement not found"
+// @Failure 500 {object} model.ErrorResponse "Internal server error"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/history [get]
+func (s *AchievementService) HistoryHandler(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
 
-	attachment, err := s.UploadAttachment(id, src, fileName, file.Header.Get("Content-Type"))
+	histories, err := s.GetAchievementHistory(id)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
 			return c.Status(fe.Code).JSON(fiber.Map{"error": fe.Message})
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
-	return c.JSON(attachment)
+	return c.JSON(histories)
 }
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/repository"
+)
+
+// ==================== TEAM ACHIEVEMENTS ====================
+
+const maxTeamMembers = 20
+
+// prepareTeam menormalkan tim baru: pembuat menjadi ketua (langsung confirmed), anggota lain pending
+func (s *AchievementService) prepareTeam(team *model.Team, leaderID uuid.UUID) error {
+	if team.PointsPolicy == "" {
+		team.PointsPolicy = s.cfg.TeamPointsPolicy
+	}
+	if !model.IsValidPointsPolicy(team.PointsPolicy) {
+		return fiber.NewError(http.StatusBadRequest, "invalid team points policy")
+	}
+
+	members := []model.TeamMember{{StudentID: leaderID, Role: model.TeamRoleLeader, Status: model.TeamMemberConfirmed}}
+	seen := map[uuid.UUID]bool{leaderID: true}
+	others := []uuid.UUID{}
+	for _, m := range team.Members {
+		if m.StudentID == uuid.Nil {
+			return fiber.NewError(http.StatusBadRequest, "team member studentId is required")
+		}
+		if seen[m.StudentID] {
+			continue
+		}
+		seen[m.StudentID] = true
+		others = append(others, m.StudentID)
+		members = append(members, model.TeamMember{StudentID: m.StudentID, Role: model.TeamRoleMember, Status: model.TeamMemberPending})
+	}
+	if len(others) == 0 {
+		return fiber.NewError(http.StatusBadRequest, "team must have at least one other member")
+	}
+	if len(members) > maxTeamMembers {
+		return fiber.NewError(http.StatusBadRequest, "team has too many members")
+	}
+
+	count, err := s.postgresRepo.CountStudentsByIDs(others)
+	if err != nil {
+		return err
+	}
+	if count != len(others) {
+		return fiber.NewError(http.StatusBadRequest, "team member not found")
+	}
+	team.Members = members
+	return nil
+}
+
+// ensurePrimary menolak aksi pada reference anggota tim; workflow selalu lewat reference ketua
+func ensurePrimary(ref *model.AchievementReference) error {
+	if ref.PrimaryReferenceID != nil {
+		return fiber.NewError(http.StatusBadRequest, "team achievement is managed through reference "+ref.PrimaryReferenceID.String())
+	}
+	return nil
+}
+
+// RespondTeamInvitation mengonfirmasi atau menolak keanggotaan tim; konfirmasi membuat reference milik anggota
+func (s *AchievementService) RespondTeamInvitation(id, userID uuid.UUID, accept bool) (*model.AchievementReference, error) {
+	ref, err := s.postgresRepo.GetAchievementReferenceByID(id)
+	if err != nil || ref.Status == "deleted" {
+		return nil, fiber.NewError(http.StatusNotFound, "achievement not found")
+	}
+	if err := ensurePrimary(ref); err != nil {
+		return nil, err
+	}
+	student, err := s.postgresRepo.GetStudentByUserID(userID)
+	if err != nil || student == nil {
+		return nil, fiber.NewError(http.StatusForbidden, "only students can join a team")
+	}
+
+	status := model.TeamMemberDeclined
+	if accept {
+		status = model.TeamMemberConfirmed
+	}
+	err = s.mongoRepo.RespondTeamInvitation(ref.MongoAchievementID, student.ID, status)
+	if errors.Is(err, repository.ErrNoPendingInvitation) {
+		return nil, fiber.NewError(http.StatusNotFound, "no pending team invitation")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !accept {
+		return nil, nil
+	}
+	return s.postgresRepo.CreateTeamMemberReference(ref.ID, student.ID)
+}
+
+func (s *AchievementService) GetTeamInvitations(userID uuid.UUID) ([]model.Achievement, error) {
+	student, err := s.postgresRepo.GetStudentByUserID(userID)
+	if err != nil || student == nil {
+		return nil, fiber.NewError(http.StatusForbidden, "only students have team invitations")
+	}
+	return s.mongoRepo.GetTeamInvitations(student.ID)
+}
+
+// @Summary List team invitations
+// @Description Prestasi tim yang menunggu konfirmasi mahasiswa yang sedang login
+// @Tags Achievements
+// @Produce json
+// @Success 200 {array} model.Achievement
+// @Failure 403 {object} model.ErrorResponse "Not a student"
+// @Security ApiKeyAuth
+// @Router /achievements/team/invitations [get]
+func (s *AchievementService) TeamInvitationsHandler(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	invitations, err := s.GetTeamInvitations(userID)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(invitations)
+}
+
+// @Summary Confirm team membership
+// @Description Anggota tim mengonfirmasi keikutsertaan; prestasi lalu muncul di daftar & statistik anggota tersebut
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID) milik ketua tim"
+// @Success 200 {object} model.AchievementReference
+// @Failure 404 {object} model.ErrorResponse "No pending invitation"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/team/confirm [post]
+func (s *AchievementService) ConfirmTeamHandler(c *fiber.Ctx) error {
+	return s.respondTeamHandler(c, true)
+}
+
+// @Summary Decline team membership
+// @Description Anggota tim menolak keikutsertaan
+// @Tags Achievements
+// @Produce json
+// @Param id path string true "Achievement ID (UUID) milik ketua tim"
+// @Success 200 {object} map[string]string "status: declined"
+// @Failure 404 {object} model.ErrorResponse "No pending invitation"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/team/decline [post]
+func (s *AchievementService) DeclineTeamHandler(c *fiber.Ctx) error {
+	return s.respondTeamHandler(c, false)
+}
+
+func (s *AchievementService) respondTeamHandler(c *fiber.Ctx, accept bool) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid achievement ID"})
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+	}
+	ref, err := s.RespondTeamInvitation(id, userID, accept)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if !accept {
+		return c.JSON(fiber.Map{"status": model.TeamMemberDeclined})
+	}
+	return c.JSON(ref)
+}
@@ -0,0 +1,578 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/storage"
+)
+
+// ==================== RESUMABLE UPLOAD (tus 1.0.0) ====================
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,checksum,expiration,termination"
+
+	// statusChecksumMismatch adalah status 460 dari ekstensi checksum tus
+	statusChecksumMismatch = 460
+)
+
+var errUploadCorrupt = errors.New("upload chunk failed its integrity check")
+
+// loadUploadTarget: syarat loadChangeableAchievement (pemilik, draft atau rejected) dan slot attachment masih ada
+func (s *AchievementService) loadUploadTarget(id, userID uuid.UUID) (*model.AchievementReference, error) {
+	ref, ach, err := s.loadChangeableAchievement(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ach.Attachments) >= s.cfg.MaxAttachments {
+		return nil, &model.AttachmentError{
+			Status: http.StatusConflict, Code: model.AttachmentErrTooMany,
+			Message: "an achievement can have at most " + strconv.Itoa(s.cfg.MaxAttachments) + " attachments",
+		}
+	}
+	return ref, nil
+}
+
+// maxUploadLength adalah batas ukuran terbesar dari semua jenis file; batas per jenis dicek setelah chunk pertama di-sniff
+func (s *AchievementService) maxUploadLength() int64 {
+	var limit int64
+	for _, v := range s.cfg.AttachmentMaxSize {
+		limit = max(limit, v)
+	}
+	return limit
+}
+
+// CreateUpload membuka sesi resumable upload untuk file sepanjang length byte
+func (s *AchievementService) CreateUpload(id, userID uuid.UUID, length int64, fileName string) (*model.AttachmentUpload, error) {
+	ref, err := s.loadUploadTarget(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if length <= 0 {
+		return nil, fiber.NewError(http.StatusBadRequest, "Upload-Length must be a positive number")
+	}
+	if limit := s.maxUploadLength(); length > limit {
+		return nil, model.NewAttachmentTooLargeError("any attachment", limit)
+	}
+	now := time.Now()
+	upload := &model.AttachmentUpload{
+		ID:                 uuid.New().String(),
+		AchievementID:      id,
+		MongoAchievementID: ref.MongoAchievementID,
+		UserID:             userID,
+		FileName:           fileName,
+		Length:             length,
+		Chunks:             []model.UploadChunk{},
+		CreatedAt:          now,
+		ExpiresAt:          now.Add(s.cfg.UploadExpiry),
+	}
+	if err := s.mongoRepo.CreateUpload(upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// GetUpload mengembalikan sesi upload milik user; sesi orang lain atau yang sudah kedaluwarsa dianggap tidak ada
+func (s *AchievementService) GetUpload(id uuid.UUID, uploadID string, userID uuid.UUID) (*model.AttachmentUpload, error) {
+	upload, err := s.mongoRepo.GetUpload(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload == nil || upload.AchievementID != id || upload.UserID != userID || !upload.ExpiresAt.After(time.Now()) {
+		return nil, fiber.NewError(http.StatusNotFound, "upload not found")
+	}
+	return upload, nil
+}
+
+// parseUploadChecksum memeriksa header Upload-Checksum ("sha256 <base64>") terhadap sha256 chunk
+func parseUploadChecksum(header string, sum []byte) error {
+	if header == "" {
+		return nil
+	}
+	algo, value, _ := strings.Cut(strings.TrimSpace(header), " ")
+	if algo != "sha256" {
+		return fiber.NewError(http.StatusBadRequest, "unsupported checksum algorithm "+algo+" (only sha256)")
+	}
+	want, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fiber.NewError(http.StatusBadRequest, "invalid Upload-Checksum value")
+	}
+	if !bytes.Equal(want, sum) {
+		return fiber.NewError(statusChecksumMismatch, "checksum mismatch")
+	}
+	return nil
+}
+
+// PatchUpload menerima satu chunk pada offset. Chunk disimpan sebagai object terpisah dan dicatat di sesi hanya
+// jika offset sesi belum berubah (PATCH paralel pada offset yang sama mendapat 409). Jenis file di-sniff dari chunk
+// pertama sehingga file yang ditolak tidak perlu diunggah sampai habis. Setelah chunk terakhir, chunk digabung
+// menjadi attachment dan dikembalikan; PATCH kosong pada offset akhir mengulang penggabungan yang gagal.
+func (s *AchievementService) PatchUpload(id uuid.UUID, uploadID string, userID uuid.UUID, offset int64, checksum string, body io.Reader) (*model.AttachmentUpload, *model.Attachment, error) {
+	upload, err := s.GetUpload(id, uploadID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if offset != upload.Offset {
+		return nil, nil, fiber.NewError(http.StatusConflict, "Upload-Offset does not match the current offset "+strconv.FormatInt(upload.Offset, 10))
+	}
+	data, err := io.ReadAll(io.LimitReader(body, s.cfg.UploadChunkMax+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(data)) > s.cfg.UploadChunkMax {
+		return nil, nil, fiber.NewError(http.StatusRequestEntityTooLarge, "chunk exceeds the "+strconv.FormatInt(s.cfg.UploadChunkMax, 10)+" byte limit")
+	}
+	if offset+int64(len(data)) > upload.Length {
+		return nil, nil, fiber.NewError(http.StatusRequestEntityTooLarge, "chunk exceeds Upload-Length")
+	}
+	sum := sha256.Sum256(data)
+	if err := parseUploadChecksum(checksum, sum[:]); err != nil {
+		return nil, nil, err
+	}
+
+	ctx := context.Background()
+	if len(data) == 0 {
+		if upload.Offset < upload.Length {
+			return upload, nil, nil
+		}
+		att, err := s.finalizeUpload(ctx, upload)
+		return upload, att, err
+	}
+
+	fileType := upload.FileType
+	if offset == 0 {
+		if int64(len(data)) < min(sniffLen, upload.Length) {
+			return nil, nil, fiber.NewError(http.StatusBadRequest, "the first chunk must contain at least "+strconv.Itoa(sniffLen)+" bytes")
+		}
+		if fileType, err = s.sniffUpload(upload, data); err != nil {
+			s.discardUpload(ctx, upload)
+			return nil, nil, err
+		}
+	}
+	chunk := model.UploadChunk{Key: upload.ChunkKey(offset), Offset: offset, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])}
+	if err := s.storage.Put(ctx, chunk.Key, bytes.NewReader(data), chunk.Size, fiber.MIMEOctetStream); err != nil {
+		return nil, nil, err
+	}
+	expiresAt := time.Now().Add(s.cfg.UploadExpiry)
+	if err := s.mongoRepo.AppendUploadChunk(upload.ID, chunk, fileType, expiresAt); err != nil {
+		_ = s.storage.Delete(ctx, chunk.Key)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, fiber.NewError(http.StatusConflict, "upload offset changed by another request")
+		}
+		return nil, nil, err
+	}
+	upload.Offset += chunk.Size
+	upload.Chunks = append(upload.Chunks, chunk)
+	upload.FileType = fileType
+	upload.ExpiresAt = expiresAt
+
+	if upload.Offset < upload.Length {
+		return upload, nil, nil
+	}
+	att, err := s.finalizeUpload(ctx, upload)
+	return upload, att, err
+}
+
+// sniffUpload menerapkan validasi readAttachment pada chunk pertama: executable dan jenis di luar allow-list
+// ditolak, lalu Upload-Length dicek terhadap batas ukuran jenisnya
+func (s *AchievementService) sniffUpload(upload *model.AttachmentUpload, first []byte) (string, error) {
+	head := first[:min(len(first), sniffLen)]
+	if model.IsExecutable(head) {
+		return "", model.NewAttachmentTypeError(model.AttachmentErrExecutable, "", "executable files are not allowed")
+	}
+	mimeType := model.SniffAttachmentType(head)
+	if mimeType == "" {
+		return "", model.NewAttachmentTypeError(model.AttachmentErrUnsupported, "", "only PDF, JPEG, PNG and MP4 attachments are allowed")
+	}
+	if limit := s.cfg.AttachmentMaxSize[mimeType]; upload.Length > limit {
+		return "", model.NewAttachmentTooLargeError(mimeType, limit)
+	}
+	return mimeType, nil
+}
+
+// finalizeUpload menggabungkan chunk (sha256 tiap chunk diperiksa lagi) ke storage attachment lalu menambahkannya
+// ke prestasi. PDF dan gambar melewati validasi penuh readAttachment; video di-stream dua kali (hash, lalu tulis)
+// tanpa dibaca utuh ke memori. Sesi dibuang setelah berhasil atau jika isinya ditolak; error lain (storage,
+// Mongo, status prestasi) membiarkan sesi agar penggabungan bisa diulang. Flag finalized dipasang dengan
+// compare-and-set sebelum menggabung, sehingga PATCH akhir yang bersamaan tidak menambah attachment dua kali.
+func (s *AchievementService) finalizeUpload(ctx context.Context, upload *model.AttachmentUpload) (*model.Attachment, error) {
+	if err := s.mongoRepo.SetUploadFinalized(upload.ID, true); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil // PATCH lain sedang atau sudah menggabungkan upload ini
+		}
+		return nil, err
+	}
+	// status dicek lagi: prestasi bisa sudah disubmit sejak sesi dibuat
+	if _, err := s.loadUploadTarget(upload.AchievementID, upload.UserID); err != nil {
+		s.reopenUpload(upload)
+		return nil, err
+	}
+	attachment, err := s.assembleUpload(ctx, upload)
+	if err != nil {
+		var ae *model.AttachmentError
+		if errors.As(err, &ae) || errors.Is(err, errUploadCorrupt) {
+			s.discardUpload(ctx, upload)
+		} else {
+			s.reopenUpload(upload)
+		}
+		if errors.Is(err, errUploadCorrupt) {
+			return nil, fiber.NewError(http.StatusUnprocessableEntity, err.Error()+"; upload discarded")
+		}
+		return nil, err
+	}
+	attachment.ID = uuid.New().String()
+	if err := s.mongoRepo.AddAttachment(upload.MongoAchievementID, attachment); err != nil {
+		s.releaseBlob(attachment)
+		s.reopenUpload(upload)
+		return nil, err
+	}
+	s.wakeScanner()
+	s.discardUpload(ctx, upload)
+	return &attachment, nil
+}
+
+// reopenUpload melepas flag finalized agar PATCH kosong pada offset akhir bisa mengulang penggabungan
+func (s *AchievementService) reopenUpload(upload *model.AttachmentUpload) {
+	if err := s.mongoRepo.SetUploadFinalized(upload.ID, false); err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		log.Printf("failed to reopen upload %s: %v", upload.ID, err)
+	}
+}
+
+func (s *AchievementService) assembleUpload(ctx context.Context, upload *model.AttachmentUpload) (model.Attachment, error) {
+	open := func() (io.ReadCloser, error) { return s.openUploadChunks(ctx, upload), nil }
+	if upload.FileType != model.MIMETypeMP4 {
+		src, _ := open()
+		defer src.Close()
+		data, mimeType, err := s.readAttachment(src, upload.Length)
+		if err != nil {
+			return model.Attachment{}, err
+		}
+		return s.storeAttachment(ctx, data, mimeType, upload.FileName)
+	}
+
+	src, _ := open()
+	h := sha256.New()
+	n, err := io.Copy(h, src)
+	src.Close()
+	if err != nil {
+		return model.Attachment{}, err
+	}
+	if n != upload.Length {
+		return model.Attachment{}, errUploadCorrupt
+	}
+	return s.storeBlob(ctx, hex.EncodeToString(h.Sum(nil)), n, upload.FileType, upload.FileName, open)
+}
+
+// chunkReader membaca chunk upload berurutan dan mengembalikan errUploadCorrupt jika isi chunk tidak cocok
+// dengan sha256 yang dicatat saat chunk diterima
+type chunkReader struct {
+	ctx    context.Context
+	store  storage.Storage
+	chunks []model.UploadChunk
+	cur    io.ReadCloser
+	hash   hash.Hash
+}
+
+func (s *AchievementService) openUploadChunks(ctx context.Context, upload *model.AttachmentUpload) io.ReadCloser {
+	return &chunkReader{ctx: ctx, store: s.storage, chunks: upload.Chunks}
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.cur == nil {
+			if len(r.chunks) == 0 {
+				return 0, io.EOF
+			}
+			body, _, err := r.store.Get(r.ctx, r.chunks[0].Key)
+			if errors.Is(err, storage.ErrNotFound) {
+				return 0, errUploadCorrupt
+			}
+			if err != nil {
+				return 0, err
+			}
+			r.cur, r.hash = body, sha256.New()
+		}
+		n, err := r.cur.Read(p)
+		r.hash.Write(p[:n])
+		if err == io.EOF {
+			r.cur.Close()
+			r.cur = nil
+			if hex.EncodeToString(r.hash.Sum(nil)) != r.chunks[0].SHA256 {
+				return n, errUploadCorrupt
+			}
+			r.chunks = r.chunks[1:]
+			err = nil
+		}
+		if n > 0 || err != nil {
+			return n, err
+		}
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.cur != nil {
+		return r.cur.Close()
+	}
+	return nil
+}
+
+// discardUpload menghapus chunk dan sesi upload; kegagalan hanya dicatat karena sesi kedaluwarsa dibersihkan lagi
+func (s *AchievementService) discardUpload(ctx context.Context, upload *model.AttachmentUpload) {
+	prefix := model.UploadKeyPrefix + upload.ID + "/"
+	objects, err := s.storage.List(ctx, prefix)
+	if err != nil {
+		log.Printf("failed to list chunks of upload %s: %v", upload.ID, err)
+	}
+	for _, obj := range objects {
+		if err := s.storage.Delete(ctx, obj.Key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("failed to delete upload chunk %s: %v", obj.Key, err)
+		}
+	}
+	if err := s.mongoRepo.DeleteUpload(upload.ID); err != nil {
+		log.Printf("failed to delete upload %s: %v", upload.ID, err)
+	}
+}
+
+// TerminateUpload membatalkan sesi upload beserta chunk yang sudah diterima
+func (s *AchievementService) TerminateUpload(id uuid.UUID, uploadID string, userID uuid.UUID) error {
+	upload, err := s.GetUpload(id, uploadID, userID)
+	if err != nil {
+		return err
+	}
+	s.discardUpload(context.Background(), upload)
+	return nil
+}
+
+// CleanupExpiredUploads membuang sesi upload yang kedaluwarsa; mengembalikan jumlah sesi yang dibuang
+func (s *AchievementService) CleanupExpiredUploads(ctx context.Context, now time.Time) (int, error) {
+	uploads, err := s.mongoRepo.FindExpiredUploads(now)
+	if err != nil {
+		return 0, err
+	}
+	for i := range uploads {
+		s.discardUpload(ctx, &uploads[i])
+	}
+	return len(uploads), nil
+}
+
+// StartUploadCleanup menjalankan CleanupExpiredUploads secara berkala sampai ctx selesai
+func (s *AchievementService) StartUploadCleanup(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if n, err := s.CleanupExpiredUploads(ctx, time.Now()); err != nil {
+				log.Printf("upload cleanup failed: %v", err)
+			} else if n > 0 {
+				log.Printf("upload cleanup: %d expired uploads discarded", n)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// ==================== HANDLERS WITH SWAGGER ====================
+
+// tusHeaders memasang header tus di setiap response dan menolak versi protokol lain (412)
+func tusHeaders(c *fiber.Ctx) bool {
+	c.Set("Tus-Resumable", tusVersion)
+	if c.Get("Tus-Resumable") != tusVersion {
+		c.Set("Tus-Version", tusVersion)
+		return false
+	}
+	return true
+}
+
+func setUploadHeaders(c *fiber.Ctx, upload *model.AttachmentUpload) {
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+}
+
+// uploadMetadataFileName mengambil filename dari Upload-Metadata ("key base64,key base64")
+func uploadMetadataFileName(header string) string {
+	for _, pair := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key != "filename" {
+			continue
+		}
+		name, err := base64.StdEncoding.DecodeString(value)
+		if err == nil {
+			return string(name)
+		}
+	}
+	return ""
+}
+
+// parseUploadRequest membaca achievement ID dan user dari request upload
+func parseUploadRequest(c *fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.NewError(http.StatusBadRequest, "Invalid achievement ID")
+	}
+	userID, err := uuid.Parse(c.Locals("user_id").(string))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.NewError(http.StatusUnauthorized, "Invalid user")
+	}
+	return id, userID, nil
+}
+
+// @Summary Create resumable upload
+// @Description Membuka sesi resumable upload (protokol tus 1.0.0: creation, checksum, expiration, termination) untuk file besar seperti video. Hanya pemilik, saat prestasi draft atau rejected. Jenis file di-sniff dari chunk pertama; batas ukuran sama dengan upload biasa.
+// @Tags Achievements
+// @Param id path string true "Achievement ID (UUID)"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Length header int true "Ukuran file (byte)"
+// @Param Upload-Metadata header string false "filename <base64>"
+// @Success 201 "Location: URL upload"
+// @Failure 400 {object} model.ErrorResponse "Achievement is not draft or rejected"
+// @Failure 403 {object} model.ErrorResponse "Not the owner"
+// @Failure 409 {object} model.AttachmentError "Too many attachments"
+// @Failure 412 "Unsupported tus version"
+// @Failure 413 {object} model.AttachmentError "File too large"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/uploads [post]
+func (s *AchievementService) CreateUploadHandler(c *fiber.Ctx) error {
+	if !tusHeaders(c) {
+		return c.SendStatus(http.StatusPreconditionFailed)
+	}
+	id, userID, err := parseUploadRequest(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	length, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Length header is required"})
+	}
+	fileName := uuid.New().String() + filepath.Ext(uploadMetadataFileName(c.Get("Upload-Metadata")))
+
+	upload, err := s.CreateUpload(id, userID, length, fileName)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	setUploadHeaders(c, upload)
+	c.Set("Tus-Extension", tusExtensions)
+	c.Set(fiber.HeaderLocation, upload.Path())
+	return c.SendStatus(http.StatusCreated)
+}
+
+// @Summary Resumable upload offset
+// @Description Mengembalikan offset sesi upload (Upload-Offset) agar client bisa melanjutkan setelah koneksi putus
+// @Tags Achievements
+// @Param id path string true "Achievement ID (UUID)"
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Success 200 "Upload-Offset, Upload-Length, Upload-Expires"
+// @Failure 404 "Upload not found or expired"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/uploads/{uploadId} [head]
+func (s *AchievementService) HeadUploadHandler(c *fiber.Ctx) error {
+	if !tusHeaders(c) {
+		return c.SendStatus(http.StatusPreconditionFailed)
+	}
+	id, userID, err := parseUploadRequest(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	upload, err := s.GetUpload(id, c.Params("uploadId"), userID)
+	if err != nil {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+		return handleServiceError(c, err)
+	}
+	setUploadHeaders(c, upload)
+	return c.SendStatus(http.StatusOK)
+}
+
+// @Summary Upload chunk
+// @Description Mengirim chunk pada Upload-Offset (Content-Type application/offset+octet-stream). Upload-Checksum "sha256 <base64>" opsional; mismatch menghasilkan 460. Setelah chunk terakhir file digabung, divalidasi dan ditambahkan sebagai attachment (200 dengan metadata attachment); sebelum itu 204. Status prestasi dicek lagi saat penggabungan; PATCH akhir yang bersamaan dengan penggabungan lain mendapat 204 tanpa menambah attachment.
+// @Tags Achievements
+// @Accept application/offset+octet-stream
+// @Produce json
+// @Param id path string true "Achievement ID (UUID)"
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Param Upload-Offset header int true "Offset chunk"
+// @Param Upload-Checksum header string false "sha256 <base64>"
+// @Success 200 {object} model.Attachment "Upload selesai"
+// @Success 204 "Chunk diterima"
+// @Failure 400 {object} model.ErrorResponse "Achievement is no longer draft or rejected"
+// @Failure 404 "Upload not found or expired"
+// @Failure 409 {object} model.ErrorResponse "Offset mismatch"
+// @Failure 413 {object} model.AttachmentError "Chunk or file too large"
+// @Failure 415 {object} model.AttachmentError "Type not allowed, executable or polyglot"
+// @Failure 460 {object} model.ErrorResponse "Checksum mismatch"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/uploads/{uploadId} [patch]
+func (s *AchievementService) PatchUploadHandler(c *fiber.Ctx) error {
+	if !tusHeaders(c) {
+		return c.SendStatus(http.StatusPreconditionFailed)
+	}
+	id, userID, err := parseUploadRequest(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if c.Get(fiber.HeaderContentType) != "application/offset+octet-stream" {
+		return c.Status(http.StatusUnsupportedMediaType).JSON(fiber.Map{"error": "Content-Type must be application/offset+octet-stream"})
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Upload-Offset header is required"})
+	}
+
+	upload, attachment, err := s.PatchUpload(id, c.Params("uploadId"), userID, offset, c.Get("Upload-Checksum"), bytes.NewReader(c.Body()))
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	setUploadHeaders(c, upload)
+	if attachment != nil {
+		return c.JSON(attachment)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// @Summary Cancel resumable upload
+// @Description Membatalkan sesi upload dan menghapus chunk yang sudah diterima
+// @Tags Achievements
+// @Param id path string true "Achievement ID (UUID)"
+// @Param uploadId path string true "Upload ID"
+// @Param Tus-Resumable header string true "1.0.0"
+// @Success 204
+// @Failure 404 {object} model.ErrorResponse "Upload not found or expired"
+// @Security ApiKeyAuth
+// @Router /achievements/{id}/uploads/{uploadId} [delete]
+func (s *AchievementService) DeleteUploadHandler(c *fiber.Ctx) error {
+	if !tusHeaders(c) {
+		return c.SendStatus(http.StatusPreconditionFailed)
+	}
+	id, userID, err := parseUploadRequest(c)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if err := s.TerminateUpload(id, c.Params("uploadId"), userID); err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
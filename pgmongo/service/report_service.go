@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -16,8 +17,8 @@ import (
 
 // ReportService defines the interface for report and statistics operations
 type ReportService interface {
-	GetAchievementStatistics(ctx context.Context, userID uuid.UUID) (*model.AchievementStatistics, error)
-	GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID) (*model.StudentAchievementStatistics, error)
+	GetAchievementStatistics(ctx context.Context, userID uuid.UUID, filter model.ReportFilter) (*model.AchievementStatistics, error)
+	GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID, filter model.ReportFilter) (*model.StudentAchievementStatistics, error)
 	HandleGetAchievementStatistics() fiber.Handler
 	HandleGetStudentAchievementStatistics() fiber.Handler
 }
@@ -26,13 +27,15 @@ type reportService struct {
 	reportRepo   repository.ReportRepository
 	studentRepo  *repository.StudentRepository
 	lecturerRepo repository.LecturerRepository
+	periodRepo   repository.AcademicPeriodRepository
 }
 
-func NewReportService(reportRepo repository.ReportRepository, studentRepo *repository.StudentRepository, lecturerRepo repository.LecturerRepository) ReportService {
+func NewReportService(reportRepo repository.ReportRepository, studentRepo *repository.StudentRepository, lecturerRepo repository.LecturerRepository, periodRepo repository.AcademicPeriodRepository) ReportService {
 	return &reportService{
 		reportRepo:   reportRepo,
 		studentRepo:  studentRepo,
 		lecturerRepo: lecturerRepo,
+		periodRepo:   periodRepo,
 	}
 }
 
@@ -41,11 +44,15 @@ func NewReportService(reportRepo repository.ReportRepository, studentRepo *repos
 // @Tags Reports
 // @Accept json
 // @Produce json
+// @Param period query string false "Kode semester (2024/2025-ganjil) atau tahun akademik (2024/2025); default tahun akademik berjalan"
+// @Param from query string false "Event date from (YYYY-MM-DD)"
+// @Param to query string false "Event date to, inklusif (YYYY-MM-DD)"
 // @Success 200 {object} model.AchievementStatistics
+// @Failure 400 {object} model.ErrorResponse "Unknown period or invalid date"
 // @Failure 403 {object} model.ErrorResponse "No profile found"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Router /reports/statistics [get]
-func (s *reportService) GetAchievementStatistics(ctx context.Context, userID uuid.UUID) (*model.AchievementStatistics, error) {
+func (s *reportService) GetAchievementStatistics(ctx context.Context, userID uuid.UUID, filter model.ReportFilter) (*model.AchievementStatistics, error) {
 	// Access check based on role
 	isStudent, err := s.isStudent(userID)
 	if err != nil {
@@ -60,7 +67,7 @@ func (s *reportService) GetAchievementStatistics(ctx context.Context, userID uui
 			return nil, fmt.Errorf("no student profile found")
 		}
 		// For student: Own stats
-		return s.aggregateOwnStats(ctx, student.ID)
+		return s.aggregateOwnStats(ctx, student.ID, filter)
 	}
 
 	isLecturer, err := s.isLecturer(userID)
@@ -76,11 +83,11 @@ func (s *reportService) GetAchievementStatistics(ctx context.Context, userID uui
 			return nil, fmt.Errorf("no lecturer profile found")
 		}
 		// For lecturer: Advisees stats
-		return s.aggregateAdviseesStats(ctx, lecturer.ID)
+		return s.aggregateAdviseesStats(ctx, lecturer.ID, filter)
 	}
 
 	// Admin: Full global stats
-	return s.reportRepo.GetAchievementStatistics(ctx, userID)
+	return s.reportRepo.GetAchievementStatistics(ctx, userID, filter)
 }
 
 // @Summary Get student achievement statistics
@@ -89,12 +96,16 @@ func (s *reportService) GetAchievementStatistics(ctx context.Context, userID uui
 // @Accept json
 // @Produce json
 // @Param student_id path string true "Student ID (UUID)"
+// @Param period query string false "Kode semester (2024/2025-ganjil) atau tahun akademik (2024/2025); default tahun akademik berjalan"
+// @Param from query string false "Event date from (YYYY-MM-DD)"
+// @Param to query string false "Event date to, inklusif (YYYY-MM-DD)"
 // @Success 200 {object} model.StudentAchievementStatistics
+// @Failure 400 {object} model.ErrorResponse "Unknown period or invalid date"
 // @Failure 403 {object} model.ErrorResponse "Access denied"
 // @Failure 404 {object} model.ErrorResponse "Student not found"
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Router /reports/students/{student_id}/statistics [get]
-func (s *reportService) GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID) (*model.StudentAchievementStatistics, error) {
+func (s *reportService) GetStudentAchievementStatistics(ctx context.Context, studentID, userID uuid.UUID, filter model.ReportFilter) (*model.StudentAchievementStatistics, error) {
 	// Access check
 	student, err := s.studentRepo.GetStudentByID(studentID)
 	if err != nil {
@@ -129,7 +140,7 @@ func (s *reportService) GetStudentAchievementStatistics(ctx context.Context, stu
 	}
 
 	// Authorized: Fetch student-specific stats
-	return s.reportRepo.GetStudentAchievementStatistics(ctx, studentID, userID)
+	return s.reportRepo.GetStudentAchievementStatistics(ctx, studentID, userID, filter)
 }
 
 func (s *reportService) HandleGetAchievementStatistics() fiber.Handler {
@@ -143,7 +154,12 @@ func (s *reportService) HandleGetAchievementStatistics() fiber.Handler {
 			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
 		}
 
-		stats, err := s.GetAchievementStatistics(c.Context(), userID)
+		filter, err := s.reportFilter(c)
+		if err != nil {
+			return handleServiceError(c, err)
+		}
+
+		stats, err := s.GetAchievementStatistics(c.Context(), userID, filter)
 		if err != nil {
 			errMsg := err.Error()
 			status := http.StatusInternalServerError
@@ -174,7 +190,12 @@ func (s *reportService) HandleGetStudentAchievementStatistics() fiber.Handler {
 			return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
 		}
 
-		stats, err := s.GetStudentAchievementStatistics(c.Context(), studentID, userID)
+		filter, err := s.reportFilter(c)
+		if err != nil {
+			return handleServiceError(c, err)
+		}
+
+		stats, err := s.GetStudentAchievementStatistics(c.Context(), studentID, userID, filter)
 		if err != nil {
 			errMsg := err.Error()
 			status := http.StatusInternalServerError
@@ -192,6 +213,16 @@ func (s *reportService) HandleGetStudentAchievementStatistics() fiber.Handler {
 }
 
 // Helper methods
+
+// reportFilter membaca parameter period/from/to terhadap kalender akademik
+func (s *reportService) reportFilter(c *fiber.Ctx) (model.ReportFilter, error) {
+	periods, err := s.periodRepo.ListPeriods()
+	if err != nil {
+		return model.ReportFilter{}, err
+	}
+	return buildReportFilter(model.NewAcademicCalendar(periods), c.Query("period"), c.Query("from"), c.Query("to"), time.Now())
+}
+
 func (s *reportService) isStudent(userID uuid.UUID) (bool, error) {
 	student, err := s.studentRepo.GetStudentByUserID(userID)
 	return student != nil, err
@@ -214,13 +245,13 @@ func (s *reportService) getLecturerID(userID uuid.UUID) (uuid.UUID, error) {
 }
 
 // Aggregate own stats
-func (s *reportService) aggregateOwnStats(ctx context.Context, studentID uuid.UUID) (*model.AchievementStatistics, error) {
-	studentStats, err := s.reportRepo.GetStudentAchievementStatistics(ctx, studentID, uuid.Nil)
+func (s *reportService) aggregateOwnStats(ctx context.Context, studentID uuid.UUID, filter model.ReportFilter) (*model.AchievementStatistics, error) {
+	studentStats, err := s.reportRepo.GetStudentAchievementStatistics(ctx, studentID, uuid.Nil, filter)
 	if err != nil {
 		return nil, err
 	}
 
-	totalPoints, err := s.reportRepo.GetTotalPointsForStudent(ctx, studentID)
+	totalPoints, err := s.reportRepo.GetTotalPointsForStudent(ctx, studentID, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -235,9 +266,12 @@ func (s *reportService) aggregateOwnStats(ctx context.Context, studentID uuid.UU
 	}
 
 	stats := &model.AchievementStatistics{
-		TotalPerType:   studentStats.PerType,
-		TotalPerPeriod: studentStats.PerPeriod,
-		Distribution:   studentStats.Distribution,
+		TotalPerType:    studentStats.PerType,
+		TotalPerPeriod:  studentStats.PerPeriod,
+		Distribution:    studentStats.Distribution,
+		TagCounts:       studentStats.TagCounts,
+		OrganizerCounts: studentStats.OrganizerCounts,
+		TotalRevoked:    studentStats.TotalRevoked,
 		TopStudents: []model.TopStudent{
 			{
 				StudentID: studentID.String(),
@@ -252,7 +286,7 @@ func (s *reportService) aggregateOwnStats(ctx context.Context, studentID uuid.UU
 }
 
 // Aggregate advisees stats
-func (s *reportService) aggregateAdviseesStats(ctx context.Context, lecturerID uuid.UUID) (*model.AchievementStatistics, error) {
+func (s *reportService) aggregateAdviseesStats(ctx context.Context, lecturerID uuid.UUID, filter model.ReportFilter) (*model.AchievementStatistics, error) {
 	advisees, err := s.studentRepo.GetAdviseesByLecturerID(lecturerID)
 	if err != nil {
 		return nil, err
@@ -261,10 +295,13 @@ func (s *reportService) aggregateAdviseesStats(ctx context.Context, lecturerID u
 	var totalType map[string]int64 = make(map[string]int64)
 	var totalPeriod map[string]int64 = make(map[string]int64)
 	var totalDist map[string]int64 = make(map[string]int64)
+	totalTags := make(map[string]int64)
+	totalOrganizers := make(map[string]int64)
+	var totalRevoked int64
 	var topStudents []model.TopStudent
 
 	for _, advisee := range advisees {
-		studentStats, err := s.reportRepo.GetStudentAchievementStatistics(ctx, advisee.ID, uuid.Nil)
+		studentStats, err := s.reportRepo.GetStudentAchievementStatistics(ctx, advisee.ID, uuid.Nil, filter)
 		if err != nil {
 			continue
 		}
@@ -279,8 +316,15 @@ func (s *reportService) aggregateAdviseesStats(ctx context.Context, lecturerID u
 		for level, count := range studentStats.Distribution {
 			totalDist[level] += count
 		}
+		for tag, count := range studentStats.TagCounts {
+			totalTags[tag] += count
+		}
+		for organizer, count := range studentStats.OrganizerCounts {
+			totalOrganizers[organizer] += count
+		}
+		totalRevoked += studentStats.TotalRevoked
 
-		points, err := s.reportRepo.GetTotalPointsForStudent(ctx, advisee.ID)
+		points, err := s.reportRepo.GetTotalPointsForStudent(ctx, advisee.ID, filter)
 		if err != nil {
 			points = 0
 		}
@@ -307,10 +351,13 @@ func (s *reportService) aggregateAdviseesStats(ctx context.Context, lecturerID u
 	}
 
 	stats := &model.AchievementStatistics{
-		TotalPerType:   totalType,
-		TotalPerPeriod: totalPeriod,
-		TopStudents:    topStudents,
-		Distribution:   totalDist,
+		TotalPerType:    totalType,
+		TotalPerPeriod:  totalPeriod,
+		TopStudents:     topStudents,
+		Distribution:    totalDist,
+		TagCounts:       totalTags,
+		OrganizerCounts: totalOrganizers,
+		TotalRevoked:    totalRevoked,
 	}
 
 	return stats, nil
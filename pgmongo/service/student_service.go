@@ -12,14 +12,16 @@ import (
 )
 
 type StudentService struct {
-	studentRepo *repository.StudentRepository
-	achRepo     *repository.AchievementRepository
+	studentRepo  *repository.StudentRepository
+	achRepo      *repository.AchievementRepository
+	achMongoRepo repository.AchievementMongoRepository
 }
 
-func NewStudentService(studentRepo *repository.StudentRepository, achRepo *repository.AchievementRepository) *StudentService {
+func NewStudentService(studentRepo *repository.StudentRepository, achRepo *repository.AchievementRepository, achMongoRepo repository.AchievementMongoRepository) *StudentService {
 	return &StudentService{
-		studentRepo: studentRepo,
-		achRepo:     achRepo,
+		studentRepo:  studentRepo,
+		achRepo:      achRepo,
+		achMongoRepo: achMongoRepo,
 	}
 }
 
@@ -65,6 +67,33 @@ func (s *StudentService) GetAllStudents(userID uuid.UUID, page, limit int) (*mod
 	}, nil
 }
 
+// GetAllStudentsByCursor is the keyset variant of GetAllStudents; mahasiswa mendapat profilnya sendiri dalam satu halaman
+func (s *StudentService) GetAllStudentsByCursor(userID uuid.UUID, page model.CursorPage) (*model.CursorResponse[model.Student], error) {
+	student, err := s.studentRepo.GetStudentByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if student == nil {
+		lect, err := s.studentRepo.GetLecturerByUserID(userID)
+		if err != nil {
+			return nil, err
+		}
+		if lect == nil {
+			// Admin: keyset pagination
+			return s.studentRepo.GetStudentsByCursor(page)
+		}
+		// Dosen wali: keyset pagination atas mahasiswa bimbingannya
+		return s.studentRepo.GetAdviseesByCursor(lect.ID, page)
+	}
+
+	resp := &model.CursorResponse[model.Student]{Data: []model.Student{*student}, Limit: page.Limit}
+	if page.WithTotal {
+		total := int64(1)
+		resp.Total = &total
+	}
+	return resp, nil
+}
+
 func (s *StudentService) GetOwnStudentProfile(userID uuid.UUID) (*model.Student, error) {
 	student, err := s.studentRepo.GetStudentByUserID(userID)
 	if err != nil {
@@ -122,6 +151,14 @@ func (s *StudentService) GetStudentAchievements(studentID, userID uuid.UUID, sta
 	return s.achRepo.GetAchievementReferencesByStudentIDs([]uuid.UUID{studentID}, status, page, limit)
 }
 
+func (s *StudentService) GetStudentAchievementsByCursor(studentID, userID uuid.UUID, status *string, page model.CursorPage) (*model.CursorResponse[model.AchievementReference], error) {
+	_, err := s.GetStudentByID(studentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.achRepo.ListAchievementReferencesByCursor(model.ReferenceFilter{StudentIDs: []uuid.UUID{studentID}, Status: status}, page)
+}
+
 func (s *StudentService) UpdateStudentAdvisor(studentID, advisorID, userID uuid.UUID) error {
 	// Hanya admin yang boleh
 	isStudent, _ := s.isStudent(userID)
@@ -170,6 +207,9 @@ func (s *StudentService) getLecturerID(userID uuid.UUID) (uuid.UUID, error) {
 // @Produce json
 // @Param page query int false "Page number (default 1)"
 // @Param limit query int false "Items per page (default 10)"
+// @Param pagination query string false "cursor untuk keyset pagination (halaman pertama); respons berbentuk model.CursorResponse"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya (mengaktifkan keyset pagination)"
+// @Param with_total query bool false "Sertakan total pada keyset pagination (menjalankan COUNT)"
 // @Success 200 {object} model.PaginatedResponse[model.Student]
 // @Failure 500 {object} model.ErrorResponse "Internal server error"
 // @Security ApiKeyAuth
@@ -190,6 +230,18 @@ func (s *StudentService) GetAllStudentsHandler(c *fiber.Ctx) error {
 		limit = 10
 	}
 
+	cursorPage, err := parseCursorPage(c, limit)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if cursorPage != nil {
+		result, err := s.GetAllStudentsByCursor(userID, *cursorPage)
+		if err != nil {
+			return handleServiceError(c, err)
+		}
+		return c.JSON(result)
+	}
+
 	result, err := s.GetAllStudents(userID, page, limit)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
@@ -274,6 +326,10 @@ func (s *StudentService) GetStudentByIDHandler(c *fiber.Ctx) error {
 // @Param status query string false "Filter status (draft, submitted, verified, rejected, deleted)"
 // @Param page query int false "Page number (default 1)"
 // @Param limit query int false "Items per page (default 10)"
+// @Param include query string false "Isi tambahan per item: achievement (konten Mongo dimuat batch per halaman)"
+// @Param pagination query string false "cursor untuk keyset pagination (halaman pertama); respons berbentuk model.CursorResponse"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya (mengaktifkan keyset pagination)"
+// @Param with_total query bool false "Sertakan total pada keyset pagination (menjalankan COUNT)"
 // @Success 200 {object} model.PaginatedResponse[model.AchievementReference]
 // @Failure 400 {object} model.ErrorResponse "Access denied"
 // @Failure 404 {object} model.ErrorResponse "Student not found"
@@ -308,6 +364,23 @@ func (s *StudentService) GetAchievementsHandler(c *fiber.Ctx) error {
 		limit = 10
 	}
 
+	cursorPage, err := parseCursorPage(c, limit)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	if cursorPage != nil {
+		result, err := s.GetStudentAchievementsByCursor(studentID, userID, statusPtr, *cursorPage)
+		if err != nil {
+			return handleServiceError(c, err)
+		}
+		if hasInclude(c, "achievement") {
+			if err := includeAchievements(s.achMongoRepo, result.Data); err != nil {
+				return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			}
+		}
+		return c.JSON(result)
+	}
+
 	result, err := s.GetStudentAchievements(studentID, userID, statusPtr, page, limit)
 	if err != nil {
 		if fe, ok := err.(*fiber.Error); ok {
@@ -315,6 +388,11 @@ func (s *StudentService) GetAchievementsHandler(c *fiber.Ctx) error {
 		}
 		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
+	if hasInclude(c, "achievement") {
+		if err := includeAchievements(s.achMongoRepo, result.Data); err != nil {
+			return c.Status(http.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
 
 	return c.JSON(result)
 }
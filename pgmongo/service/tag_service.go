@@ -0,0 +1,331 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/repository"
+)
+
+// ==================== TAG TAXONOMY ====================
+
+type TagService struct {
+	tagRepo repository.TagRepository
+}
+
+func NewTagService(tagRepo repository.TagRepository) *TagService {
+	return &TagService{tagRepo: tagRepo}
+}
+
+func (s *TagService) Taxonomy() (*model.TagTaxonomy, error) {
+	tags, err := s.tagRepo.ListTags()
+	if err != nil {
+		return nil, err
+	}
+	return model.NewTagTaxonomy(tags), nil
+}
+
+func (s *TagService) ListTags() ([]model.Tag, error) {
+	return s.tagRepo.ListTags()
+}
+
+func (s *TagService) Autocomplete(prefix string, limit int) ([]model.TagSuggestion, error) {
+	taxonomy, err := s.Taxonomy()
+	if err != nil {
+		return nil, err
+	}
+	return taxonomy.Suggest(prefix, limit), nil
+}
+
+func (s *TagService) CreateTag(role string, req model.TagRequest) (*model.Tag, error) {
+	if role != "Admin" {
+		return nil, fiber.NewError(http.StatusForbidden, "only admins can manage tags")
+	}
+	taxonomy, err := s.Taxonomy()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tag := &model.Tag{ID: uuid.New(), CreatedAt: now, UpdatedAt: now}
+	if err := applyTagRequest(taxonomy, tag, req); err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.CreateTag(tag); err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
+// UpdateTag mengganti nama/parent/sinonim. Slug ikut berubah mengikuti nama; slug lama disimpan sebagai sinonim
+// agar achievement yang sudah memakai slug lama tetap ter-resolve.
+func (s *TagService) UpdateTag(role string, id uuid.UUID, req model.TagRequest) (*model.Tag, error) {
+	if role != "Admin" {
+		return nil, fiber.NewError(http.StatusForbidden, "only admins can manage tags")
+	}
+	taxonomy, err := s.Taxonomy()
+	if err != nil {
+		return nil, err
+	}
+	existing := taxonomy.ByID(id)
+	if existing == nil {
+		return nil, fiber.NewError(http.StatusNotFound, "tag not found")
+	}
+	tag := *existing
+	tag.UpdatedAt = time.Now()
+	if slug := model.NormalizeTag(req.Name); slug != "" && slug != existing.Slug {
+		req.Synonyms = append(req.Synonyms, existing.Slug)
+	}
+	if err := applyTagRequest(taxonomy, &tag, req); err != nil {
+		return nil, err
+	}
+	if err := s.tagRepo.UpdateTag(&tag); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(http.StatusNotFound, "tag not found")
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (s *TagService) DeleteTag(role string, id uuid.UUID) error {
+	if role != "Admin" {
+		return fiber.NewError(http.StatusForbidden, "only admins can manage tags")
+	}
+	err := s.tagRepo.DeleteTag(id)
+	switch {
+	case errors.Is(err, repository.ErrTagInUse):
+		return fiber.NewError(http.StatusConflict, "tag still has child tags")
+	case errors.Is(err, sql.ErrNoRows):
+		return fiber.NewError(http.StatusNotFound, "tag not found")
+	}
+	return err
+}
+
+// applyTagRequest memvalidasi request terhadap vocabulary: slug/sinonim tidak boleh bentrok dengan tag lain
+// dan parent tidak boleh membentuk siklus.
+func applyTagRequest(taxonomy *model.TagTaxonomy, tag *model.Tag, req model.TagRequest) error {
+	tag.Name = strings.TrimSpace(req.Name)
+	tag.Slug = model.NormalizeTag(tag.Name)
+	if tag.Slug == "" {
+		return fiber.NewError(http.StatusBadRequest, "tag name is required")
+	}
+	if req.ParentID != nil {
+		if taxonomy.ByID(*req.ParentID) == nil {
+			return fiber.NewError(http.StatusBadRequest, "parent tag not found")
+		}
+		if taxonomy.IsDescendant(*req.ParentID, tag.ID) {
+			return fiber.NewError(http.StatusBadRequest, "parent tag would create a cycle")
+		}
+	}
+	tag.ParentID = req.ParentID
+
+	synonyms := []string{}
+	seen := map[string]bool{tag.Slug: true}
+	for _, syn := range req.Synonyms {
+		key := model.NormalizeTag(syn)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		synonyms = append(synonyms, key)
+	}
+	tag.Synonyms = synonyms
+
+	for key := range seen {
+		if other := taxonomy.Resolve(key); other != nil && other.ID != tag.ID {
+			return fiber.NewError(http.StatusConflict, "\""+key+"\" is already used by tag "+other.Slug)
+		}
+	}
+	return nil
+}
+
+// ==================== ACHIEVEMENT TAGS ====================
+
+func (s *AchievementService) tagTaxonomy() (*model.TagTaxonomy, error) {
+	tags, err := s.tagRepo.ListTags()
+	if err != nil {
+		return nil, err
+	}
+	return model.NewTagTaxonomy(tags), nil
+}
+
+// normalizeTags memetakan tag masukan ke slug kanonik. Tag yang tidak dikenal ditolak,
+// kecuali tag lama yang sudah ada di dokumen (legacy) agar edit field lain tetap bisa disimpan.
+func (s *AchievementService) normalizeTags(raw, existing []string) ([]string, error) {
+	if len(raw) == 0 {
+		return raw, nil
+	}
+	taxonomy, err := s.tagTaxonomy()
+	if err != nil {
+		return nil, err
+	}
+
+	legacy := map[string]bool{}
+	for _, t := range existing {
+		legacy[t] = true
+	}
+	slugs := []string{}
+	seen := map[string]bool{}
+	rejected := []string{}
+	for _, r := range raw {
+		if strings.TrimSpace(r) == "" {
+			continue
+		}
+		slug := r
+		if tag := taxonomy.Resolve(r); tag != nil {
+			slug = tag.Slug
+		} else if !legacy[r] {
+			rejected = append(rejected, r)
+			continue
+		}
+		if !seen[slug] {
+			seen[slug] = true
+			slugs = append(slugs, slug)
+		}
+	}
+	if len(rejected) > 0 {
+		return nil, fiber.NewError(http.StatusBadRequest, "unknown tags: "+strings.Join(rejected, ", "))
+	}
+	return slugs, nil
+}
+
+// expandTagFilter mengubah filter tag menjadi grup slug: tiap tag cocok dengan dirinya dan seluruh turunannya
+func (s *AchievementService) expandTagFilter(filter *model.AchievementContentFilter) error {
+	if len(filter.Tags) == 0 {
+		return nil
+	}
+	taxonomy, err := s.tagTaxonomy()
+	if err != nil {
+		return err
+	}
+	for _, raw := range filter.Tags {
+		tag := taxonomy.Resolve(raw)
+		if tag == nil {
+			// tag di luar vocabulary tetap dicari apa adanya (data lama)
+			filter.TagGroups = append(filter.TagGroups, []string{raw})
+			continue
+		}
+		filter.TagGroups = append(filter.TagGroups, taxonomy.Expand(tag))
+	}
+	filter.Tags = nil
+	return nil
+}
+
+// @Summary List tags
+// @Description Seluruh vocabulary tag beserta parent dan sinonim
+// @Tags Tags
+// @Produce json
+// @Success 200 {array} model.Tag
+// @Security ApiKeyAuth
+// @Router /tags [get]
+func (s *TagService) ListTagsHandler(c *fiber.Ctx) error {
+	tags, err := s.ListTags()
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(tags)
+}
+
+// @Summary Autocomplete tags
+// @Description Saran tag berdasarkan awalan slug, nama atau sinonim; hasil berisi slug kanonik dan path hierarki
+// @Tags Tags
+// @Produce json
+// @Param q query string false "Prefix"
+// @Param limit query int false "Max suggestions (default 10)"
+// @Success 200 {array} model.TagSuggestion
+// @Security ApiKeyAuth
+// @Router /tags/autocomplete [get]
+func (s *TagService) AutocompleteHandler(c *fiber.Ctx) error {
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+	suggestions, err := s.Autocomplete(c.Query("q"), limit)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(suggestions)
+}
+
+// @Summary Create tag
+// @Description Menambah tag ke vocabulary (admin)
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param body body model.TagRequest true "Tag"
+// @Success 201 {object} model.Tag
+// @Failure 400 {object} model.ErrorResponse "Invalid tag"
+// @Failure 403 {object} model.ErrorResponse "Not an admin"
+// @Failure 409 {object} model.ErrorResponse "Slug or synonym already used"
+// @Security ApiKeyAuth
+// @Router /tags [post]
+func (s *TagService) CreateTagHandler(c *fiber.Ctx) error {
+	var req model.TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	role, _ := c.Locals("role").(string)
+	tag, err := s.CreateTag(role, req)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.Status(http.StatusCreated).JSON(tag)
+}
+
+// @Summary Update tag
+// @Description Mengubah nama, parent atau sinonim tag (admin); slug lama tetap dikenali sebagai sinonim
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Tag ID (UUID)"
+// @Param body body model.TagRequest true "Tag"
+// @Success 200 {object} model.Tag
+// @Failure 404 {object} model.ErrorResponse "Tag not found"
+// @Failure 409 {object} model.ErrorResponse "Slug or synonym already used"
+// @Security ApiKeyAuth
+// @Router /tags/{id} [put]
+func (s *TagService) UpdateTagHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+	var req model.TagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request body"})
+	}
+	role, _ := c.Locals("role").(string)
+	tag, err := s.UpdateTag(role, id, req)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.JSON(tag)
+}
+
+// @Summary Delete tag
+// @Description Menghapus tag (admin); tag yang masih punya turunan tidak bisa dihapus
+// @Tags Tags
+// @Param id path string true "Tag ID (UUID)"
+// @Success 204
+// @Failure 404 {object} model.ErrorResponse "Tag not found"
+// @Failure 409 {object} model.ErrorResponse "Tag has child tags"
+// @Security ApiKeyAuth
+// @Router /tags/{id} [delete]
+func (s *TagService) DeleteTagHandler(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid tag ID"})
+	}
+	role, _ := c.Locals("role").(string)
+	if err := s.DeleteTag(role, id); err != nil {
+		return handleServiceError(c, err)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,96 @@
+// File: BACKEND-UAS/pgmongo/storage/storage.go
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotFound dikembalikan saat object dengan key tersebut tidak ada di storage
+var ErrNotFound = errors.New("storage: object not found")
+
+// ObjectInfo is the metadata of a stored object
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage menyimpan file attachment. Key selalu relatif dan memakai "/" (mis. "attachments/<uuid>.pdf"),
+// sehingga metadata di Mongo tidak bergantung pada backend yang dipakai.
+type Storage interface {
+	// Put menyimpan isi r; size < 0 berarti ukuran tidak diketahui
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	// GetRange membaca length byte mulai dari offset; length < 0 berarti sampai akhir object
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	// List returns every object whose key starts with prefix ("" berarti semua object)
+	List(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// SignedURL returns a URL that grants read access to the object until ttl elapses
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// SignatureVerifier diimplementasikan backend yang signed link-nya dilayani aplikasi sendiri (Local);
+// backend lain (S3) memberikan URL yang langsung diverifikasi oleh penyedia storage.
+type SignatureVerifier interface {
+	VerifySignature(key string, expires int64, signature string) error
+}
+
+// Config memilih backend storage; Driver "local" (default) atau "s3"
+type Config struct {
+	Driver string
+
+	// local
+	LocalRoot  string // direktori penyimpanan, default ./uploads
+	SignedBase string // prefix URL yang melayani signed link local, mis. /api/v1/files
+	SigningKey []byte
+
+	S3 S3Config
+}
+
+// DeriveSigningKey menurunkan kunci signed link dari secret lain (mis. JWT secret) dengan HMAC berlabel,
+// sehingga signature link tidak pernah dibuat dengan kunci yang sama persis dengan token login
+func DeriveSigningKey(secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("attachment-signed-link\x00v1"))
+	return mac.Sum(nil)
+}
+
+// New membuat backend sesuai cfg.Driver
+func New(cfg Config) (Storage, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "local":
+		root := cfg.LocalRoot
+		if root == "" {
+			root = "./uploads"
+		}
+		return NewLocal(root, cfg.SignedBase, cfg.SigningKey)
+	case "s3":
+		return NewS3(cfg.S3)
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+	}
+}
+
+// CleanKey validates an object key: relatif, tanpa segmen "." / "..", dipisah "/"
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return "", fmt.Errorf("storage: invalid key %q", key)
+		}
+	}
+	return path.Clean(key), nil
+}
@@ -2,32 +2,61 @@
 package tests
 
 import (
+	"bufio"
+	"compress/zlib"
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
 	"io"
+	"mime/multipart"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
-	"unsafe"
 
 	jwtpkg "github.com/golang-jwt/jwt/v5"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 
+	"BACKEND-UAS/pgmongo/extract"
 	"BACKEND-UAS/pgmongo/jwt"
 	"BACKEND-UAS/pgmongo/model"
+	"BACKEND-UAS/pgmongo/preview"
+	"BACKEND-UAS/pgmongo/receipt"
 	"BACKEND-UAS/pgmongo/repository"
+	"BACKEND-UAS/pgmongo/scanner"
 	"BACKEND-UAS/pgmongo/service"
+	"BACKEND-UAS/pgmongo/storage"
 )
 
 // ======================= MOCK JWT SERVICE =======================
@@ -169,6 +198,153 @@ func TestUserRepository_Create(t *testing.T) {
 	})
 }
 
+func TestStudentRepository_GetStudentsByCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewStudentRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	after := &model.Cursor{CreatedAt: now.Add(time.Hour), ID: uuid.New()}
+	columns := []string{
+		"s.id", "s.user_id", "s.student_id", "s.program_study", "s.academic_year", "s.advisor_id", "s.created_at",
+		"u.id", "u.username", "u.email", "u.full_name", "u.role_id", "u.is_active", "u.created_at", "u.updated_at",
+		"l.id", "l.user_id", "l.lecturer_id", "l.department", "l.created_at",
+		"lu.id", "lu.username", "lu.email", "lu.full_name", "lu.role_id", "lu.is_active", "lu.created_at", "lu.updated_at",
+	}
+	row := func(id uuid.UUID, createdAt time.Time) []driver.Value {
+		return []driver.Value{
+			id.String(), uuid.New().String(), "2101", "Informatika", "2021", nil, createdAt,
+			uuid.New().String(), "mhs", "mhs@example.com", "Mahasiswa", "role", true, createdAt, createdAt,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		}
+	}
+	firstID, secondID := uuid.New(), uuid.New()
+	rows := sqlmock.NewRows(columns).AddRow(row(firstID, now)...).AddRow(row(secondID, now.Add(-time.Minute))...)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM students`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE (s.created_at, s.id) < ($2, $3::uuid)`)).
+		WithArgs(2, after.CreatedAt, after.ID.String()).
+		WillReturnRows(rows)
+
+	resp, err := repo.GetStudentsByCursor(model.CursorPage{After: after, Limit: 1, WithTotal: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, firstID, resp.Data[0].ID)
+	require.NotNil(t, resp.Total)
+	assert.Equal(t, int64(42), *resp.Total)
+
+	next, err := model.DecodeCursor(resp.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, firstID, next.ID)
+	assert.True(t, now.Equal(next.CreatedAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = model.DecodeCursor("not-a-cursor")
+	assert.ErrorIs(t, err, model.ErrInvalidCursor)
+}
+
+func TestStudentRepository_GetAdviseesByCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewStudentRepository(db)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	lecturerID := uuid.New()
+	after := &model.Cursor{CreatedAt: now.Add(time.Hour), ID: uuid.New()}
+	columns := []string{
+		"s.id", "s.user_id", "s.student_id", "s.program_study", "s.academic_year", "s.advisor_id", "s.created_at",
+		"u.id", "u.username", "u.email", "u.full_name", "u.role_id", "u.is_active", "u.created_at", "u.updated_at",
+		"l.id", "l.user_id", "l.lecturer_id", "l.department", "l.created_at",
+		"lu.id", "lu.username", "lu.email", "lu.full_name", "lu.role_id", "lu.is_active", "lu.created_at", "lu.updated_at",
+	}
+	row := func(id uuid.UUID, createdAt time.Time) []driver.Value {
+		return []driver.Value{
+			id.String(), uuid.New().String(), "2101", "Informatika", "2021", lecturerID.String(), createdAt,
+			uuid.New().String(), "mhs", "mhs@example.com", "Mahasiswa", "role", true, createdAt, createdAt,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		}
+	}
+	firstID, secondID := uuid.New(), uuid.New()
+	rows := sqlmock.NewRows(columns).AddRow(row(firstID, now)...).AddRow(row(secondID, now.Add(-time.Minute))...)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM students WHERE advisor_id = $1`)).
+		WithArgs(lecturerID.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE s.advisor_id = $2 AND (s.created_at, s.id) < ($3, $4::uuid)`)).
+		WithArgs(2, lecturerID.String(), after.CreatedAt, after.ID.String()).
+		WillReturnRows(rows)
+
+	resp, err := repo.GetAdviseesByCursor(lecturerID, model.CursorPage{After: after, Limit: 1, WithTotal: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, firstID, resp.Data[0].ID)
+	require.NotNil(t, resp.Total)
+	assert.Equal(t, int64(7), *resp.Total)
+
+	next, err := model.DecodeCursor(resp.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, firstID, next.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAchievementRepository_RevokeClearsVerifier(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+	id := uuid.New()
+
+	mock.ExpectExec(regexp.QuoteMeta(`SET status = 'revoked', rejection_note = $1, verified_by = NULL, verified_at = NULL`)).
+		WithArgs("Sertifikat palsu", id.String(), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE achievement_references m`)).
+		WithArgs(id.String()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	require.NoError(t, repo.RevokeAchievement(id, "Sertifikat palsu", 3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAchievementRepository_ListSubmittedPaginatesInPostgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewAchievementRepository(db)
+	studentID, reviewerID := uuid.New(), uuid.New()
+	claimedSince := time.Now().Add(-time.Hour)
+	filter := model.SubmittedReferenceFilter{
+		StudentIDs: []uuid.UUID{studentID}, ClaimedBy: &reviewerID, ClaimedSince: claimedSince, MongoIDs: []string{"m1", "m2"},
+	}
+	where := `WHERE ar.status = 'submitted' AND ar.primary_reference_id IS NULL AND (ar.student_id = ANY($1::uuid[]) OR (ar.claimed_by = $2 AND ar.claimed_at >= $3)) AND ar.mongo_achievement_id = ANY($4)`
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM achievement_references ar ` + where)).
+		WithArgs(pq.Array([]string{studentID.String()}), reviewerID.String(), claimedSince, pq.Array([]string{"m1", "m2"})).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta(where + ` ORDER BY ar.submitted_at ASC, ar.created_at ASC LIMIT $5 OFFSET $6`)).
+		WithArgs(pq.Array([]string{studentID.String()}), reviewerID.String(), claimedSince, pq.Array([]string{"m1", "m2"}), 5, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"ar.id"}))
+
+	resp, err := repo.ListSubmittedAchievementReferences(filter, 3, 5)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), resp.Total)
+	assert.Equal(t, 3, resp.TotalPages)
+	assert.Empty(t, resp.Data)
+
+	// hasil filter Mongo kosong: tidak ada query sama sekali
+	filter.MongoIDs = []string{}
+	resp, err = repo.ListSubmittedAchievementReferences(filter, 1, 5)
+	require.NoError(t, err)
+	assert.Zero(t, resp.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 // ======================= MOCK USER REPOSITORY UNTUK SERVICE =======================
 
 type mockUserRepo struct {
@@ -459,11 +635,34 @@ type mockAchievementPostgresRepo struct {
 	GetAchievementReferencesByStudentIDsFunc func(studentIDs []uuid.UUID, status *string, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
 	GetAllAchievementReferencesFunc          func(status *string, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
 	CreateAchievementReferenceFunc           func(ref *model.AchievementReference) error
-	SoftDeleteAchievementReferenceFunc       func(id uuid.UUID) error
-	SubmitAchievementFunc                    func(id uuid.UUID) error
-	VerifyAchievementFunc                    func(id uuid.UUID, verifiedBy uuid.UUID, rejectionNote *string) error
+	SoftDeleteAchievementReferenceFunc       func(id uuid.UUID, untouchedSince time.Time) error
+	SubmitAchievementFunc                    func(id uuid.UUID, expectedStatus string, version int64) error
+	VerifyAchievementFunc                    func(id uuid.UUID, verifiedBy uuid.UUID, rejectionNote *string, version int64) error
+	BumpVersionFunc                          func(id uuid.UUID, expectedStatus string, version int64) error
+	RevokeAchievementFunc                    func(id uuid.UUID, reason string, version int64) error
+	WithdrawAchievementFunc                  func(id uuid.UUID, version int64) error
+	CountStudentsByIDsFunc                   func(ids []uuid.UUID) (int, error)
+	CreateTeamMemberReferenceFunc            func(primaryID, studentID uuid.UUID) (*model.AchievementReference, error)
+	GetAchievementReferencesByMongoIDsFunc   func(mongoIDs []string) ([]model.AchievementReference, error)
+	FindAchievementReferencesFunc            func(filter model.ReferenceFilter) ([]model.AchievementReference, error)
+	CountAchievementReferencesFunc           func(filter model.ReferenceFilter) (int64, error)
+	ListAchievementReferencesFunc            func(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
+	ListAchievementReferencesByCursorFunc    func(filter model.ReferenceFilter, page model.CursorPage) (*model.CursorResponse[model.AchievementReference], error)
+	ListSubmittedAchievementReferencesFunc   func(filter model.SubmittedReferenceFilter, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error)
+	GetClaimedStudentIDsFunc                 func(reviewerID uuid.UUID, claimedSince time.Time) ([]uuid.UUID, error)
+	ClaimAchievementFunc                     func(id, reviewerID uuid.UUID, staleBefore time.Time) error
+	AssignReviewerFunc                       func(id, reviewerID uuid.UUID) error
+	ReleaseClaimFunc                         func(id uuid.UUID) error
+	TouchAchievementReferenceFunc            func(id uuid.UUID) error
+	FindStaleDraftsFunc                      func(untouchedSince time.Time, unremindedOnly bool) ([]model.AchievementReference, error)
+	FindAbandonedDraftsFunc                  func(untouchedSince, remindedBefore time.Time) ([]model.AchievementReference, error)
+	MarkDraftRemindedFunc                    func(id uuid.UUID) error
+	SaveVerificationReceiptFunc              func(id uuid.UUID, receipt string) error
+	GetVerificationReceiptFunc               func(id uuid.UUID) (string, error)
 }
 
+var _ repository.AchievementPostgresRepository = (*mockAchievementPostgresRepo)(nil)
+
 func (m *mockAchievementPostgresRepo) GetAchievementReferenceByID(id uuid.UUID) (*model.AchievementReference, error) {
 	return m.GetAchievementReferenceByIDFunc(id)
 }
@@ -485,34 +684,135 @@ func (m *mockAchievementPostgresRepo) GetAllAchievementReferences(status *string
 func (m *mockAchievementPostgresRepo) CreateAchievementReference(ref *model.AchievementReference) error {
 	return m.CreateAchievementReferenceFunc(ref)
 }
-func (m *mockAchievementPostgresRepo) SoftDeleteAchievementReference(id uuid.UUID) error {
-	return m.SoftDeleteAchievementReferenceFunc(id)
+func (m *mockAchievementPostgresRepo) SoftDeleteAchievementReference(id uuid.UUID, untouchedSince time.Time) error {
+	return m.SoftDeleteAchievementReferenceFunc(id, untouchedSince)
+}
+func (m *mockAchievementPostgresRepo) SubmitAchievement(id uuid.UUID, expectedStatus string, version int64) error {
+	return m.SubmitAchievementFunc(id, expectedStatus, version)
+}
+func (m *mockAchievementPostgresRepo) VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUID, rejectionNote *string, version int64) error {
+	return m.VerifyAchievementFunc(id, verifiedBy, rejectionNote, version)
+}
+func (m *mockAchievementPostgresRepo) RevokeAchievement(id uuid.UUID, reason string, version int64) error {
+	return m.RevokeAchievementFunc(id, reason, version)
+}
+func (m *mockAchievementPostgresRepo) WithdrawAchievement(id uuid.UUID, version int64) error {
+	return m.WithdrawAchievementFunc(id, version)
+}
+func (m *mockAchievementPostgresRepo) CountStudentsByIDs(ids []uuid.UUID) (int, error) {
+	return m.CountStudentsByIDsFunc(ids)
+}
+func (m *mockAchievementPostgresRepo) CreateTeamMemberReference(primaryID, studentID uuid.UUID) (*model.AchievementReference, error) {
+	return m.CreateTeamMemberReferenceFunc(primaryID, studentID)
+}
+func (m *mockAchievementPostgresRepo) GetAchievementReferencesByMongoIDs(mongoIDs []string) ([]model.AchievementReference, error) {
+	return m.GetAchievementReferencesByMongoIDsFunc(mongoIDs)
+}
+func (m *mockAchievementPostgresRepo) FindAchievementReferences(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+	return m.FindAchievementReferencesFunc(filter)
+}
+func (m *mockAchievementPostgresRepo) CountAchievementReferences(filter model.ReferenceFilter) (int64, error) {
+	return m.CountAchievementReferencesFunc(filter)
+}
+func (m *mockAchievementPostgresRepo) ListAchievementReferences(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+	return m.ListAchievementReferencesFunc(filter, sort, page, limit)
 }
-func (m *mockAchievementPostgresRepo) SubmitAchievement(id uuid.UUID) error {
-	return m.SubmitAchievementFunc(id)
+func (m *mockAchievementPostgresRepo) ListAchievementReferencesByCursor(filter model.ReferenceFilter, page model.CursorPage) (*model.CursorResponse[model.AchievementReference], error) {
+	return m.ListAchievementReferencesByCursorFunc(filter, page)
 }
-func (m *mockAchievementPostgresRepo) VerifyAchievement(id uuid.UUID, verifiedBy uuid.UUID, rejectionNote *string) error {
-	return m.VerifyAchievementFunc(id, verifiedBy, rejectionNote)
+func (m *mockAchievementPostgresRepo) TouchAchievementReference(id uuid.UUID) error {
+	return m.TouchAchievementReferenceFunc(id)
+}
+func (m *mockAchievementPostgresRepo) FindStaleDrafts(untouchedSince time.Time, unremindedOnly bool) ([]model.AchievementReference, error) {
+	return m.FindStaleDraftsFunc(untouchedSince, unremindedOnly)
+}
+func (m *mockAchievementPostgresRepo) FindAbandonedDrafts(untouchedSince, remindedBefore time.Time) ([]model.AchievementReference, error) {
+	return m.FindAbandonedDraftsFunc(untouchedSince, remindedBefore)
+}
+func (m *mockAchievementPostgresRepo) MarkDraftReminded(id uuid.UUID) error {
+	return m.MarkDraftRemindedFunc(id)
+}
+func (m *mockAchievementPostgresRepo) SaveVerificationReceipt(id uuid.UUID, receipt string) error {
+	if m.SaveVerificationReceiptFunc == nil {
+		return nil
+	}
+	return m.SaveVerificationReceiptFunc(id, receipt)
+}
+func (m *mockAchievementPostgresRepo) GetVerificationReceipt(id uuid.UUID) (string, error) {
+	if m.GetVerificationReceiptFunc == nil {
+		return "", nil
+	}
+	return m.GetVerificationReceiptFunc(id)
+}
+func (m *mockAchievementPostgresRepo) BumpVersion(id uuid.UUID, expectedStatus string, version int64) error {
+	return m.BumpVersionFunc(id, expectedStatus, version)
+}
+func (m *mockAchievementPostgresRepo) ListSubmittedAchievementReferences(filter model.SubmittedReferenceFilter, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+	return m.ListSubmittedAchievementReferencesFunc(filter, page, limit)
+}
+
+func (m *mockAchievementPostgresRepo) GetClaimedStudentIDs(reviewerID uuid.UUID, claimedSince time.Time) ([]uuid.UUID, error) {
+	return m.GetClaimedStudentIDsFunc(reviewerID, claimedSince)
+}
+func (m *mockAchievementPostgresRepo) ClaimAchievement(id, reviewerID uuid.UUID, staleBefore time.Time) error {
+	return m.ClaimAchievementFunc(id, reviewerID, staleBefore)
+}
+func (m *mockAchievementPostgresRepo) AssignReviewer(id, reviewerID uuid.UUID) error {
+	return m.AssignReviewerFunc(id, reviewerID)
+}
+func (m *mockAchievementPostgresRepo) ReleaseClaim(id uuid.UUID) error {
+	return m.ReleaseClaimFunc(id)
 }
 
 type mockAchievementMongoRepo struct {
 	GetAchievementByIDFunc    func(mongoID string) (*model.Achievement, error)
+	GetAchievementsByIDsFunc  func(mongoIDs []string) (map[string]*model.Achievement, error)
 	CreateAchievementFunc     func(ach *model.Achievement) error
-	UpdateAchievementFunc     func(mongoID string, ach *model.Achievement) error
+	UpdateAchievementFunc     func(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error
 	SoftDeleteAchievementFunc func(mongoID string) error
 	AddStatusHistoryFunc      func(mongoID string, history model.StatusHistory) error
+	RestoreAchievementContentFunc func(mongoID string, fields bson.M, historyID uuid.UUID) error
 	AddNotificationFunc       func(mongoID string, notif model.Notification) error
-	UploadAttachmentFunc      func(mongoID string, file io.Reader, fileName, fileType string) (*model.Attachment, error)
+	AddAttachmentFunc         func(mongoID string, attachment model.Attachment) error
+	RemoveAttachmentFunc      func(mongoID string, attachment model.Attachment) error
+	ReplaceAttachmentFunc     func(mongoID string, old, replacement model.Attachment) error
+	FindAttachmentsPendingScanFunc func(limit int) ([]model.AttachmentRef, error)
+	ListAttachmentRefsFunc    func() ([]model.AttachmentRef, error)
+	AdjustBlobRefCountFunc    func(blob model.AttachmentBlob, delta int) error
+	ListBlobsFunc             func() ([]model.AttachmentBlob, error)
+	DeleteUnreferencedBlobFunc func(hash string, untouchedSince time.Time) (bool, error)
+	CreateUploadFunc          func(upload *model.AttachmentUpload) error
+	GetUploadFunc             func(id string) (*model.AttachmentUpload, error)
+	AppendUploadChunkFunc     func(id string, chunk model.UploadChunk, fileType string, expiresAt time.Time) error
+	SetUploadFinalizedFunc    func(id string, finalized bool) error
+	DeleteUploadFunc          func(id string) error
+	FindExpiredUploadsFunc    func(now time.Time) ([]model.AttachmentUpload, error)
+	ListRevisionsFunc         func(mongoID string) ([]model.AchievementRevision, error)
+	GetRevisionFunc           func(mongoID string, revision int64) (*model.AchievementRevision, error)
+	RespondTeamInvitationFunc func(mongoID string, studentID uuid.UUID, status string) error
+	GetTeamInvitationsFunc    func(studentID uuid.UUID) ([]model.Achievement, error)
+	FindDuplicateCandidatesFunc func(ach *model.Achievement) ([]model.Achievement, error)
+	SearchAchievementsFunc      func(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error)
+	FindAchievementListKeysFunc func(filter model.AchievementContentFilter) ([]model.AchievementListKey, error)
+
+	revisions []model.AchievementRevision // semua revisi yang dicatat lewat AddRevision
+	autosaves map[string]model.AchievementAutosave
 }
 
 func (m *mockAchievementMongoRepo) GetAchievementByID(mongoID string) (*model.Achievement, error) {
 	return m.GetAchievementByIDFunc(mongoID)
 }
+func (m *mockAchievementMongoRepo) GetAchievementsByIDs(mongoIDs []string) (map[string]*model.Achievement, error) {
+	return m.GetAchievementsByIDsFunc(mongoIDs)
+}
 func (m *mockAchievementMongoRepo) CreateAchievement(ach *model.Achievement) error {
 	return m.CreateAchievementFunc(ach)
 }
-func (m *mockAchievementMongoRepo) UpdateAchievement(mongoID string, ach *model.Achievement) error {
-	return m.UpdateAchievementFunc(mongoID, ach)
+func (m *mockAchievementMongoRepo) UpdateAchievement(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
+	return m.UpdateAchievementFunc(mongoID, fields, history, expectedVersion)
+}
+func (m *mockAchievementMongoRepo) RestoreAchievementContent(mongoID string, fields bson.M, historyID uuid.UUID) error {
+	return m.RestoreAchievementContentFunc(mongoID, fields, historyID)
 }
 func (m *mockAchievementMongoRepo) SoftDeleteAchievement(mongoID string) error {
 	return m.SoftDeleteAchievementFunc(mongoID)
@@ -520,14 +820,179 @@ func (m *mockAchievementMongoRepo) SoftDeleteAchievement(mongoID string) error {
 func (m *mockAchievementMongoRepo) AddStatusHistory(mongoID string, history model.StatusHistory) error {
 	return m.AddStatusHistoryFunc(mongoID, history)
 }
+func (m *mockAchievementMongoRepo) AddRevision(rev *model.AchievementRevision) error {
+	m.revisions = append(m.revisions, *rev)
+	return nil
+}
+func (m *mockAchievementMongoRepo) SaveAutosave(autosave *model.AchievementAutosave) error {
+	if m.autosaves == nil {
+		m.autosaves = map[string]model.AchievementAutosave{}
+	}
+	m.autosaves[autosave.MongoAchievementID] = *autosave
+	return nil
+}
+func (m *mockAchievementMongoRepo) GetAutosave(mongoID string) (*model.AchievementAutosave, error) {
+	a, ok := m.autosaves[mongoID]
+	if !ok {
+		return nil, nil
+	}
+	return &a, nil
+}
+func (m *mockAchievementMongoRepo) DeleteAutosave(mongoID string) error {
+	delete(m.autosaves, mongoID)
+	return nil
+}
+func (m *mockAchievementMongoRepo) ListRevisions(mongoID string) ([]model.AchievementRevision, error) {
+	return m.ListRevisionsFunc(mongoID)
+}
+func (m *mockAchievementMongoRepo) GetRevision(mongoID string, revision int64) (*model.AchievementRevision, error) {
+	return m.GetRevisionFunc(mongoID, revision)
+}
+func (m *mockAchievementMongoRepo) RespondTeamInvitation(mongoID string, studentID uuid.UUID, status string) error {
+	return m.RespondTeamInvitationFunc(mongoID, studentID, status)
+}
+func (m *mockAchievementMongoRepo) GetTeamInvitations(studentID uuid.UUID) ([]model.Achievement, error) {
+	return m.GetTeamInvitationsFunc(studentID)
+}
+func (m *mockAchievementMongoRepo) FindDuplicateCandidates(ach *model.Achievement) ([]model.Achievement, error) {
+	return m.FindDuplicateCandidatesFunc(ach)
+}
+func (m *mockAchievementMongoRepo) SearchAchievements(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error) {
+	return m.SearchAchievementsFunc(query, studentIDs, limit)
+}
+func (m *mockAchievementMongoRepo) FindAchievementListKeys(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+	return m.FindAchievementListKeysFunc(filter)
+}
 func (m *mockAchievementMongoRepo) AddNotification(mongoID string, notif model.Notification) error {
 	return m.AddNotificationFunc(mongoID, notif)
 }
-func (m *mockAchievementMongoRepo) UploadAttachment(mongoID string, file io.Reader, fileName, fileType string) (*model.Attachment, error) {
-	if m.UploadAttachmentFunc != nil {
-		return m.UploadAttachmentFunc(mongoID, file, fileName, fileType)
+func (m *mockAchievementMongoRepo) AddAttachment(mongoID string, attachment model.Attachment) error {
+	if m.AddAttachmentFunc != nil {
+		return m.AddAttachmentFunc(mongoID, attachment)
+	}
+	return nil
+}
+func (m *mockAchievementMongoRepo) RemoveAttachment(mongoID string, attachment model.Attachment) error {
+	return m.RemoveAttachmentFunc(mongoID, attachment)
+}
+func (m *mockAchievementMongoRepo) ReplaceAttachment(mongoID string, old, replacement model.Attachment) error {
+	return m.ReplaceAttachmentFunc(mongoID, old, replacement)
+}
+func (m *mockAchievementMongoRepo) FindAttachmentsPendingScan(limit int) ([]model.AttachmentRef, error) {
+	return m.FindAttachmentsPendingScanFunc(limit)
+}
+func (m *mockAchievementMongoRepo) ListAttachmentRefs() ([]model.AttachmentRef, error) {
+	return m.ListAttachmentRefsFunc()
+}
+func (m *mockAchievementMongoRepo) AdjustBlobRefCount(blob model.AttachmentBlob, delta int) error {
+	if m.AdjustBlobRefCountFunc != nil {
+		return m.AdjustBlobRefCountFunc(blob, delta)
+	}
+	return nil
+}
+func (m *mockAchievementMongoRepo) ListBlobs() ([]model.AttachmentBlob, error) {
+	return m.ListBlobsFunc()
+}
+func (m *mockAchievementMongoRepo) DeleteUnreferencedBlob(hash string, untouchedSince time.Time) (bool, error) {
+	return m.DeleteUnreferencedBlobFunc(hash, untouchedSince)
+}
+
+func (m *mockAchievementMongoRepo) CreateUpload(upload *model.AttachmentUpload) error {
+	return m.CreateUploadFunc(upload)
+}
+
+func (m *mockAchievementMongoRepo) GetUpload(id string) (*model.AttachmentUpload, error) {
+	return m.GetUploadFunc(id)
+}
+
+func (m *mockAchievementMongoRepo) AppendUploadChunk(id string, chunk model.UploadChunk, fileType string, expiresAt time.Time) error {
+	return m.AppendUploadChunkFunc(id, chunk, fileType, expiresAt)
+}
+
+func (m *mockAchievementMongoRepo) SetUploadFinalized(id string, finalized bool) error {
+	return m.SetUploadFinalizedFunc(id, finalized)
+}
+
+func (m *mockAchievementMongoRepo) DeleteUpload(id string) error {
+	return m.DeleteUploadFunc(id)
+}
+
+func (m *mockAchievementMongoRepo) FindExpiredUploads(now time.Time) ([]model.AttachmentUpload, error) {
+	return m.FindExpiredUploadsFunc(now)
+}
+
+var _ repository.AchievementMongoRepository = (*mockAchievementMongoRepo)(nil)
+
+// mockTagRepo menyimpan vocabulary di memori
+type mockTagRepo struct {
+	tags []model.Tag
+}
+
+var _ repository.TagRepository = (*mockTagRepo)(nil)
+
+func (m *mockTagRepo) ListTags() ([]model.Tag, error) {
+	return append([]model.Tag{}, m.tags...), nil
+}
+func (m *mockTagRepo) CreateTag(tag *model.Tag) error {
+	m.tags = append(m.tags, *tag)
+	return nil
+}
+func (m *mockTagRepo) UpdateTag(tag *model.Tag) error {
+	for i := range m.tags {
+		if m.tags[i].ID == tag.ID {
+			m.tags[i] = *tag
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+func (m *mockTagRepo) DeleteTag(id uuid.UUID) error {
+	for i := range m.tags {
+		if m.tags[i].ID == id {
+			m.tags = append(m.tags[:i], m.tags[i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// sampleTaxonomy: Computer Science > AI (sinonim "artificial intelligence", "kecerdasan buatan")
+func sampleTaxonomy() (cs, ai model.Tag) {
+	cs = model.Tag{ID: uuid.New(), Slug: "computer-science", Name: "Computer Science", Synonyms: []string{"cs", "ilmu-komputer"}}
+	ai = model.Tag{ID: uuid.New(), Slug: "ai", Name: "AI", ParentID: &cs.ID, Synonyms: []string{"artificial-intelligence", "kecerdasan-buatan"}}
+	return cs, ai
+}
+
+type mockAcademicPeriodRepo struct {
+	periods []model.AcademicPeriod
+}
+
+var _ repository.AcademicPeriodRepository = (*mockAcademicPeriodRepo)(nil)
+
+func (m *mockAcademicPeriodRepo) ListPeriods() ([]model.AcademicPeriod, error) {
+	return append([]model.AcademicPeriod{}, m.periods...), nil
+}
+func (m *mockAcademicPeriodRepo) CreatePeriod(period *model.AcademicPeriod) error {
+	m.periods = append(m.periods, *period)
+	return nil
+}
+func (m *mockAcademicPeriodRepo) DeletePeriod(id uuid.UUID) error {
+	for i := range m.periods {
+		if m.periods[i].ID == id {
+			m.periods = append(m.periods[:i], m.periods[i+1:]...)
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// samplePeriods: tahun akademik 2024/2025 (ganjil Sep-Jan, genap Feb-Jul)
+func samplePeriods() []model.AcademicPeriod {
+	date := func(v string) time.Time { t, _ := time.Parse("2006-01-02", v); return t }
+	return []model.AcademicPeriod{
+		{ID: uuid.New(), Code: "2024/2025-genap", AcademicYear: "2024/2025", Semester: model.SemesterGenap, StartDate: date("2025-02-01"), EndDate: date("2025-07-31")},
+		{ID: uuid.New(), Code: "2024/2025-ganjil", AcademicYear: "2024/2025", Semester: model.SemesterGanjil, StartDate: date("2024-09-01"), EndDate: date("2025-01-31")},
 	}
-	return nil, nil
 }
 
 type AchievementServiceTestSuite struct {
@@ -535,6 +1000,11 @@ type AchievementServiceTestSuite struct {
 	service       *service.AchievementService
 	pgRepo        *mockAchievementPostgresRepo
 	mongoRepo     *mockAchievementMongoRepo
+	tagRepo       *mockTagRepo
+	store         *storage.Local
+	storeRoot     string
+	scanner       *scanner.Fake
+	receipts      *receipt.Signer
 	studentID     uuid.UUID
 	userID        uuid.UUID
 	achievementID uuid.UUID
@@ -549,11 +1019,16 @@ func (s *AchievementServiceTestSuite) SetupTest() {
 
 	s.pgRepo = &mockAchievementPostgresRepo{}
 	s.mongoRepo = &mockAchievementMongoRepo{}
+	s.tagRepo = &mockTagRepo{}
+	s.storeRoot = s.T().TempDir()
+	store, err := storage.NewLocal(s.storeRoot, "/api/v1/files", []byte("test-signing-key"))
+	s.Require().NoError(err)
+	s.store = store
+	s.scanner = scanner.NewFake()
+	s.receipts, err = receipt.NewSigner(bytes.Repeat([]byte{1}, 32))
+	s.Require().NoError(err)
 
-	s.service = service.NewAchievementService(
-		(*repository.AchievementRepository)(unsafe.Pointer(s.pgRepo)),
-		(*repository.AchievementRepositoryMongo)(unsafe.Pointer(s.mongoRepo)),
-	)
+	s.service = service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, s.scanner, preview.Builtin{}, extract.Builtin{}, s.receipts, service.AchievementConfig{})
 }
 
 func TestRunAchievementServiceSuite(t *testing.T) {
@@ -608,6 +1083,197 @@ func (s *AchievementServiceTestSuite) TestGetUserAchievements_Admin() {
 	assert.Equal(s.T(), expected, resp)
 }
 
+func (s *AchievementServiceTestSuite) TestListAchievements_ContentFilterPaginatesInPostgres() {
+	minPoints := 10
+	s.mongoRepo.FindAchievementListKeysFunc = func(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+		assert.Nil(s.T(), filter.MongoIDs)
+		assert.Nil(s.T(), filter.StudentIDs)
+		assert.Equal(s.T(), 2001, filter.Limit)
+		assert.Equal(s.T(), [][]string{{"ai"}}, filter.TagGroups)
+		return []model.AchievementListKey{{MongoID: s.mongoID.Hex(), Points: 20}}, nil
+	}
+	s.pgRepo.ListAchievementReferencesFunc = func(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+		assert.Nil(s.T(), filter.StudentIDs)
+		assert.Equal(s.T(), []string{s.mongoID.Hex()}, filter.MongoIDs)
+		assert.Equal(s.T(), []model.SortField{{Field: model.SortSubmittedAt, Desc: true}}, sort)
+		return &model.PaginatedResponse[model.AchievementReference]{Data: []model.AchievementReference{{ID: s.achievementID}}, Page: page, Limit: limit, Total: 1, TotalPages: 1}, nil
+	}
+
+	filter := model.AchievementListFilter{
+		Content: model.AchievementContentFilter{Tags: []string{"ai"}, MinPoints: &minPoints},
+		Sort:    []model.SortField{{Field: model.SortSubmittedAt, Desc: true}},
+	}
+	resp, err := s.service.ListAchievements(s.userID, "Admin", filter, 1, 10)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), resp.Total)
+}
+
+func (s *AchievementServiceTestSuite) TestListAchievements_ContentFilterScopedAndBounded() {
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.mongoRepo.FindAchievementListKeysFunc = func(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+		// filter konten hanya berjalan atas prestasi mahasiswa yang terlihat
+		assert.Equal(s.T(), []uuid.UUID{s.studentID}, filter.StudentIDs)
+		return []model.AchievementListKey{{MongoID: s.mongoID.Hex()}}, nil
+	}
+	s.pgRepo.ListAchievementReferencesFunc = func(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+		assert.Equal(s.T(), []string{s.mongoID.Hex()}, filter.MongoIDs)
+		return &model.PaginatedResponse[model.AchievementReference]{Data: []model.AchievementReference{}}, nil
+	}
+	filter := model.AchievementListFilter{Content: model.AchievementContentFilter{Level: "nasional"}}
+	_, err := s.service.ListAchievements(s.userID, "Mahasiswa", filter, 1, 10)
+	require.NoError(s.T(), err)
+
+	// hasil di atas batas ditolak sebelum ID-nya dikirim ke Postgres
+	s.mongoRepo.FindAchievementListKeysFunc = func(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+		keys := make([]model.AchievementListKey, filter.Limit)
+		for i := range keys {
+			keys[i].MongoID = primitive.NewObjectID().Hex()
+		}
+		return keys, nil
+	}
+	s.pgRepo.ListAchievementReferencesFunc = func(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+		s.T().Fatal("references must not be queried above the content filter bound")
+		return nil, nil
+	}
+	_, err = s.service.ListAchievements(s.userID, "Admin", filter, 1, 10)
+	assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err))
+	_, err = s.service.ListAchievementsByCursor(s.userID, "Admin", filter, model.CursorPage{Limit: 10})
+	assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err))
+}
+
+func (s *AchievementServiceTestSuite) TestListAchievements_SortByContentField() {
+	older, newer := time.Now().Add(-time.Hour), time.Now()
+	low, high, missing := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+	lowRef, highRef, tieRef := uuid.New(), uuid.New(), uuid.New()
+
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.pgRepo.CountAchievementReferencesFunc = func(filter model.ReferenceFilter) (int64, error) {
+		return 4, nil
+	}
+	s.pgRepo.FindAchievementReferencesFunc = func(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+		assert.Equal(s.T(), []uuid.UUID{s.studentID}, filter.StudentIDs)
+		return []model.AchievementReference{
+			{ID: lowRef, MongoAchievementID: low.Hex(), SubmittedAt: &newer},
+			{ID: uuid.New(), MongoAchievementID: missing.Hex()},
+			{ID: tieRef, MongoAchievementID: high.Hex()},
+			{ID: highRef, MongoAchievementID: high.Hex(), SubmittedAt: &older},
+		}, nil
+	}
+	s.mongoRepo.FindAchievementListKeysFunc = func(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+		assert.Len(s.T(), filter.MongoIDs, 4)
+		return []model.AchievementListKey{{MongoID: low.Hex(), Points: 5}, {MongoID: high.Hex(), Points: 50}}, nil
+	}
+
+	filter := model.AchievementListFilter{Sort: []model.SortField{{Field: model.SortPoints, Desc: true}, {Field: model.SortSubmittedAt}}}
+	resp, err := s.service.ListAchievements(s.userID, "Mahasiswa", filter, 1, 10)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(3), resp.Total)
+	require.Len(s.T(), resp.Data, 3)
+	assert.Equal(s.T(), []uuid.UUID{highRef, tieRef, lowRef}, []uuid.UUID{resp.Data[0].ID, resp.Data[1].ID, resp.Data[2].ID})
+}
+
+func (s *AchievementServiceTestSuite) TestListAchievements_ContentSortRejectsLargeResults() {
+	s.pgRepo.CountAchievementReferencesFunc = func(filter model.ReferenceFilter) (int64, error) {
+		assert.Nil(s.T(), filter.StudentIDs)
+		return 2001, nil
+	}
+	s.pgRepo.FindAchievementReferencesFunc = func(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+		s.T().Fatal("references must not be loaded above the content sort bound")
+		return nil, nil
+	}
+
+	filter := model.AchievementListFilter{Sort: []model.SortField{{Field: model.SortTitle}}}
+	_, err := s.service.ListAchievements(s.userID, "Admin", filter, 1, 10)
+	assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err))
+}
+
+func (s *AchievementServiceTestSuite) TestListHandler_IncludeAchievementBatchLoads() {
+	otherRefID := uuid.New()
+	s.pgRepo.GetAllAchievementReferencesFunc = func(status *string, p, l int) (*model.PaginatedResponse[model.AchievementReference], error) {
+		return &model.PaginatedResponse[model.AchievementReference]{
+			Data: []model.AchievementReference{
+				{ID: s.achievementID, MongoAchievementID: s.mongoID.Hex()},
+				{ID: otherRefID, MongoAchievementID: s.mongoID.Hex()},
+			},
+			Page: p, Limit: l, Total: 2, TotalPages: 1,
+		}, nil
+	}
+	calls := 0
+	s.mongoRepo.GetAchievementsByIDsFunc = func(mongoIDs []string) (map[string]*model.Achievement, error) {
+		calls++
+		assert.Equal(s.T(), []string{s.mongoID.Hex()}, mongoIDs)
+		return map[string]*model.Achievement{s.mongoID.Hex(): {ID: s.mongoID, Title: "Juara 1"}}, nil
+	}
+
+	app := fiber.New()
+	app.Get("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("user_id", s.userID.String())
+		c.Locals("role", "Admin")
+		return c.Next()
+	}, s.service.ListHandler)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements?include=achievement", nil), -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(s.T(), 1, calls)
+
+	var body model.PaginatedResponse[model.AchievementReference]
+	require.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(s.T(), body.Data, 2)
+	for _, item := range body.Data {
+		require.NotNil(s.T(), item.Achievement)
+		assert.Equal(s.T(), "Juara 1", item.Achievement.Title)
+	}
+
+	calls = 0
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/achievements", nil), -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(s.T(), 0, calls)
+}
+
+func (s *AchievementServiceTestSuite) TestListAchievementsByCursor() {
+	lecturerID := uuid.New()
+	after := &model.Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+	s.pgRepo.GetLecturerByUserIDFunc = func(userID uuid.UUID) (*model.Lecturer, error) {
+		return &model.Lecturer{ID: lecturerID}, nil
+	}
+	s.pgRepo.GetStudentIDsByAdvisorFunc = func(advisorID uuid.UUID) ([]uuid.UUID, error) {
+		return []uuid.UUID{s.studentID}, nil
+	}
+	s.pgRepo.ListAchievementReferencesByCursorFunc = func(filter model.ReferenceFilter, page model.CursorPage) (*model.CursorResponse[model.AchievementReference], error) {
+		assert.Equal(s.T(), []uuid.UUID{s.studentID}, filter.StudentIDs)
+		assert.Equal(s.T(), after, page.After)
+		assert.False(s.T(), page.WithTotal)
+		return &model.CursorResponse[model.AchievementReference]{Data: []model.AchievementReference{{ID: s.achievementID}}, Limit: page.Limit, NextCursor: "next"}, nil
+	}
+
+	resp, err := s.service.ListAchievementsByCursor(s.userID, "Dosen Wali", model.AchievementListFilter{}, model.CursorPage{After: after, Limit: 10})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "next", resp.NextCursor)
+	assert.Nil(s.T(), resp.Total)
+
+	_, err = s.service.ListAchievementsByCursor(s.userID, "Dosen Wali", model.AchievementListFilter{Sort: []model.SortField{{Field: model.SortPoints}}}, model.CursorPage{Limit: 10})
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusBadRequest, fe.Code)
+}
+
+func TestParseSortFields(t *testing.T) {
+	fields, err := model.ParseSortFields("-points, submitted_at,-points")
+	assert.NoError(t, err)
+	assert.Equal(t, []model.SortField{{Field: model.SortPoints, Desc: true}, {Field: model.SortSubmittedAt}}, fields)
+	assert.True(t, fields[0].IsContentField())
+	assert.False(t, fields[1].IsContentField())
+
+	_, err = model.ParseSortFields("password")
+	assert.Error(t, err)
+}
+
 func (s *AchievementServiceTestSuite) TestCreateAchievement_Success() {
 	ach := model.Achievement{Title: "Juara 1 Lomba"}
 
@@ -634,19 +1300,156 @@ func (s *AchievementServiceTestSuite) TestCreateAchievement_Success() {
 	assert.Equal(s.T(), "draft", ref.Status)
 }
 
+func (s *AchievementServiceTestSuite) TestCreateAchievement_NormalizesTags() {
+	cs, ai := sampleTaxonomy()
+	s.tagRepo.tags = []model.Tag{cs, ai}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.mongoRepo.CreateAchievementFunc = func(a *model.Achievement) error {
+		assert.Equal(s.T(), []string{"ai", "computer-science"}, a.Tags)
+		a.ID = s.mongoID
+		return nil
+	}
+	s.pgRepo.CreateAchievementReferenceFunc = func(ref *model.AchievementReference) error { return nil }
+
+	_, err := s.service.CreateAchievement(s.userID, model.Achievement{Title: "Juara", Tags: []string{"Artificial Intelligence", " CS ", "ai"}})
+	require.NoError(s.T(), err)
+
+	_, err = s.service.CreateAchievement(s.userID, model.Achievement{Title: "Juara", Tags: []string{"ai", "basket"}})
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusBadRequest, fe.Code)
+	assert.Contains(s.T(), fe.Message, "basket")
+}
+
+func (s *AchievementServiceTestSuite) TestListAchievements_TagFilterIncludesChildTags() {
+	cs, ai := sampleTaxonomy()
+	s.tagRepo.tags = []model.Tag{cs, ai}
+	s.mongoRepo.FindAchievementListKeysFunc = func(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+		assert.Empty(s.T(), filter.Tags)
+		assert.Equal(s.T(), [][]string{{"computer-science", "ai"}}, filter.TagGroups)
+		return []model.AchievementListKey{}, nil
+	}
+	s.pgRepo.ListAchievementReferencesFunc = func(filter model.ReferenceFilter, sort []model.SortField, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+		assert.Equal(s.T(), []string{}, filter.MongoIDs)
+		return &model.PaginatedResponse[model.AchievementReference]{Data: []model.AchievementReference{}}, nil
+	}
+
+	_, err := s.service.ListAchievements(s.userID, "Admin", model.AchievementListFilter{Content: model.AchievementContentFilter{Tags: []string{"Ilmu Komputer"}}}, 1, 10)
+	require.NoError(s.T(), err)
+}
+
+func TestTagTaxonomy(t *testing.T) {
+	cs, ai := sampleTaxonomy()
+	ml := model.Tag{ID: uuid.New(), Slug: "machine-learning", Name: "Machine Learning", ParentID: &ai.ID}
+	taxonomy := model.NewTagTaxonomy([]model.Tag{cs, ai, ml})
+
+	assert.Equal(t, "computer-science", model.NormalizeTag("  Computer_Science "))
+	assert.Equal(t, "ai", taxonomy.Resolve("Kecerdasan Buatan").Slug)
+	assert.Equal(t, []string{"computer-science", "ai", "machine-learning"}, taxonomy.Expand(taxonomy.Resolve("cs")))
+	assert.Equal(t, []string{"Computer Science", "AI", "Machine Learning"}, taxonomy.Path(taxonomy.ByID(ml.ID)))
+	assert.True(t, taxonomy.IsDescendant(ml.ID, cs.ID))
+	assert.False(t, taxonomy.IsDescendant(cs.ID, ml.ID))
+
+	suggestions := taxonomy.Suggest("ma", 5)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "machine-learning", suggestions[0].Slug)
+
+	suggestions = taxonomy.Suggest("kecer", 5)
+	require.Len(t, suggestions, 1)
+	assert.Equal(t, "ai", suggestions[0].Slug)
+	assert.Equal(t, "kecerdasan-buatan", suggestions[0].MatchedSynonym)
+}
+
+func TestTagService_CreateAndUpdate(t *testing.T) {
+	cs, ai := sampleTaxonomy()
+	repo := &mockTagRepo{tags: []model.Tag{cs, ai}}
+	svc := service.NewTagService(repo)
+
+	_, err := svc.CreateTag("Mahasiswa", model.TagRequest{Name: "Robotics"})
+	assert.Equal(t, http.StatusForbidden, err.(*fiber.Error).Code)
+
+	_, err = svc.CreateTag("Admin", model.TagRequest{Name: "Data Mining", Synonyms: []string{"Artificial Intelligence"}})
+	assert.Equal(t, http.StatusConflict, err.(*fiber.Error).Code)
+
+	robotics, err := svc.CreateTag("Admin", model.TagRequest{Name: "Robotics", ParentID: &cs.ID, Synonyms: []string{"Robotika", "robotics"}})
+	require.NoError(t, err)
+	assert.Equal(t, "robotics", robotics.Slug)
+	assert.Equal(t, []string{"robotika"}, robotics.Synonyms)
+
+	// cs tidak boleh dipindah ke bawah turunannya sendiri
+	_, err = svc.UpdateTag("Admin", cs.ID, model.TagRequest{Name: "Computer Science", ParentID: &robotics.ID})
+	assert.Equal(t, http.StatusBadRequest, err.(*fiber.Error).Code)
+
+	renamed, err := svc.UpdateTag("Admin", ai.ID, model.TagRequest{Name: "Artificial Intelligence"})
+	require.NoError(t, err)
+	assert.Equal(t, "artificial-intelligence", renamed.Slug)
+	assert.Equal(t, []string{"ai"}, renamed.Synonyms)
+}
+
+func TestAcademicCalendar(t *testing.T) {
+	cal := model.NewAcademicCalendar(samplePeriods())
+	at := func(v string) time.Time { t, _ := time.Parse("2006-01-02", v); return t }
+
+	assert.Equal(t, "2024/2025-ganjil", cal.PeriodFor(at("2025-01-31")).Code)
+	assert.Equal(t, "2024/2025-genap", cal.PeriodFor(at("2025-02-01")).Code)
+	assert.Nil(t, cal.PeriodFor(at("2025-08-15")))
+
+	from, to, ok := cal.Range("2024/2025")
+	require.True(t, ok)
+	assert.Equal(t, at("2024-09-01"), from)
+	assert.Equal(t, at("2025-08-01"), to)
+	_, _, ok = cal.Range("2030/2031")
+	assert.False(t, ok)
+
+	filter := model.ReportFilter{From: &from, To: &to, Calendar: cal}
+	assert.True(t, filter.Contains(at("2025-07-31")))
+	assert.False(t, filter.Contains(at("2025-08-01")))
+	assert.Equal(t, "2024/2025-genap", filter.PeriodKey(at("2025-03-10")))
+	assert.Equal(t, "2025-08", filter.PeriodKey(at("2025-08-10")))
+
+	// periode mengikuti tanggal kejadian, bukan tanggal input
+	ach := &model.Achievement{CreatedAt: at("2025-03-01"), Details: map[string]interface{}{"eventDate": "2024-12-20"}}
+	assert.Equal(t, "2024/2025-ganjil", filter.PeriodKey(ach.EventDate()))
+	assert.Equal(t, at("2025-03-01"), (&model.Achievement{CreatedAt: at("2025-03-01")}).EventDate())
+}
+
+func TestAcademicPeriodService_CreatePeriod(t *testing.T) {
+	repo := &mockAcademicPeriodRepo{periods: samplePeriods()}
+	svc := service.NewAcademicPeriodService(repo)
+
+	_, err := svc.CreatePeriod("Dosen Wali", model.AcademicPeriodRequest{AcademicYear: "2025/2026", Semester: "ganjil", StartDate: "2025-09-01", EndDate: "2026-01-31"})
+	assert.Equal(t, http.StatusForbidden, err.(*fiber.Error).Code)
+
+	_, err = svc.CreatePeriod("Admin", model.AcademicPeriodRequest{AcademicYear: "2025/2027", Semester: "ganjil", StartDate: "2025-09-01", EndDate: "2026-01-31"})
+	assert.Equal(t, http.StatusBadRequest, err.(*fiber.Error).Code)
+
+	_, err = svc.CreatePeriod("Admin", model.AcademicPeriodRequest{AcademicYear: "2024/2025", Semester: "pendek", StartDate: "2025-07-15", EndDate: "2025-08-31"})
+	assert.Equal(t, http.StatusConflict, err.(*fiber.Error).Code)
+
+	period, err := svc.CreatePeriod("Admin", model.AcademicPeriodRequest{AcademicYear: "2024/2025", Semester: "Pendek", StartDate: "2025-08-01", EndDate: "2025-08-31"})
+	require.NoError(t, err)
+	assert.Equal(t, "2024/2025-pendek", period.Code)
+	assert.Len(t, repo.periods, 3)
+}
+
 func (s *AchievementServiceTestSuite) TestSubmitAchievement_Success() {
 	ref := &model.AchievementReference{
 		ID:                 s.achievementID,
 		MongoAchievementID: s.mongoID.Hex(),
 		Status:             "draft",
+		Version:            3,
 	}
 
 	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
 		return ref, nil
 	}
 
-	s.pgRepo.SubmitAchievementFunc = func(id uuid.UUID) error {
+	s.pgRepo.SubmitAchievementFunc = func(id uuid.UUID, expectedStatus string, version int64) error {
 		assert.Equal(s.T(), s.achievementID, id)
+		assert.Equal(s.T(), "draft", expectedStatus)
+		assert.Equal(s.T(), int64(3), version)
 		return nil
 	}
 
@@ -656,24 +1459,243 @@ func (s *AchievementServiceTestSuite) TestSubmitAchievement_Success() {
 		return nil
 	}
 
-	err := s.service.SubmitAchievement(s.achievementID, s.userID)
-	assert.NoError(s.T(), err)
-}
-
-func (s *AchievementServiceTestSuite) TestVerifyAchievement_Success() {
-	ref := &model.AchievementReference{
-		ID:                 s.achievementID,
-		MongoAchievementID: s.mongoID.Hex(),
-		Status:             "submitted",
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Title: "Test Achievement"}, nil
 	}
 
-	ach := &model.Achievement{Title: "Test Achievement", ID: s.mongoID}
+	err := s.service.SubmitAchievement(s.achievementID, s.userID, ref.Version)
+	assert.NoError(s.T(), err)
+
+	require.Len(s.T(), s.mongoRepo.revisions, 1)
+	assert.Equal(s.T(), int64(4), s.mongoRepo.revisions[0].Revision)
+	assert.Equal(s.T(), model.RevisionSubmitted, s.mongoRepo.revisions[0].Event)
+}
 
+func (s *AchievementServiceTestSuite) TestSubmitAchievement_MissingEventFields() {
+	ref := &model.AchievementReference{ID: s.achievementID, MongoAchievementID: s.mongoID.Hex(), Status: "draft", Version: 1}
 	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
 		return ref, nil
 	}
-
-	s.pgRepo.VerifyAchievementFunc = func(id uuid.UUID, verifiedBy uuid.UUID, note *string) error {
+	s.pgRepo.SubmitAchievementFunc = func(id uuid.UUID, expectedStatus string, version int64) error {
+		s.T().Fatal("submit must not be attempted with missing fields")
+		return nil
+	}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, AchievementType: "competition", Details: map[string]interface{}{"eventDate": "2025-03-01"}}, nil
+	}
+
+	err := s.service.SubmitAchievement(s.achievementID, s.userID, ref.Version)
+	require.Error(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, err.(*fiber.Error).Code)
+	assert.Contains(s.T(), err.Error(), "organizer")
+	assert.NotContains(s.T(), err.Error(), "eventDate")
+}
+
+func (s *AchievementServiceTestSuite) TestExportHandler_CSV() {
+	older, newer := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	otherMongoID, legacyMongoID := primitive.NewObjectID(), primitive.NewObjectID()
+	s.pgRepo.FindAchievementReferencesFunc = func(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+		return []model.AchievementReference{
+			{ID: uuid.New(), StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "verified"},
+			{ID: uuid.New(), StudentID: s.studentID, MongoAchievementID: otherMongoID.Hex(), Status: "draft"},
+			{ID: uuid.New(), StudentID: s.studentID, MongoAchievementID: legacyMongoID.Hex(), Status: "verified"},
+		}, nil
+	}
+	s.mongoRepo.GetAchievementsByIDsFunc = func(mongoIDs []string) (map[string]*model.Achievement, error) {
+		return map[string]*model.Achievement{
+			s.mongoID.Hex():    {ID: s.mongoID, Title: "Juara 1", AchievementType: "competition", EventAt: &older, Organizer: "Kemendikbud", Tags: []string{"ai", "iot"}},
+			otherMongoID.Hex(): {ID: otherMongoID, Title: "Sertifikat", AchievementType: "certification", EventAt: &newer, CertificateNumber: "CERT-01", VerificationURL: "https://verify.example.com/CERT-01"},
+			// data lama: tanggal hanya di details, diurutkan sesuai EventDate()
+			legacyMongoID.Hex(): {ID: legacyMongoID, Title: "Lomba lama", Details: bson.M{"tanggal": "15/12/2024"}},
+		}, nil
+	}
+
+	app := fiber.New()
+	app.Get("/achievements/export", func(c *fiber.Ctx) error {
+		c.Locals("user_id", s.userID.String())
+		c.Locals("role", "Admin")
+		return c.Next()
+	}, s.service.ExportHandler)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/achievements/export?sort=-event_date", nil), -1)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	assert.Contains(s.T(), resp.Header.Get("Content-Type"), "text/csv")
+
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(s.T(), err)
+	require.Len(s.T(), rows, 4)
+	assert.Equal(s.T(), "event_date", rows[0][8])
+	assert.Equal(s.T(), []string{"Sertifikat", "2025-03-01", "", "CERT-01", "https://verify.example.com/CERT-01"}, []string{rows[1][3], rows[1][8], rows[1][9], rows[1][11], rows[1][12]})
+	assert.Equal(s.T(), []string{"Lomba lama", "2024-12-15"}, []string{rows[2][3], rows[2][8]})
+	assert.Equal(s.T(), []string{"Juara 1", "2024-10-01", "Kemendikbud", "ai;iot"}, []string{rows[3][3], rows[3][8], rows[3][9], rows[3][7]})
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/achievements/export?format=xlsx", nil), -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAchievementEventFields(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	future := now.AddDate(0, 1, 0)
+
+	ach := &model.Achievement{AchievementType: "competition", EventAt: &future}
+	assert.EqualError(t, ach.ValidateEventFields(now), "eventDate must not be in the future")
+	ach = &model.Achievement{VerificationURL: "verify.example.com/x"}
+	assert.Error(t, ach.ValidateEventFields(now))
+	ach = &model.Achievement{VerificationURL: " https://verify.example.com/x ", Organizer: "  BEM  "}
+	ach.NormalizeEventFields()
+	assert.NoError(t, ach.ValidateEventFields(now))
+	assert.Equal(t, "BEM", ach.Organizer)
+
+	cert := &model.Achievement{AchievementType: "certification"}
+	assert.Equal(t, []string{"eventDate", "organizer", "certificateNumber"}, cert.MissingEventFields())
+	assert.Empty(t, (&model.Achievement{AchievementType: "other"}).MissingEventFields())
+
+	// data lama: tanggal & penyelenggara di details dipindah ke field baru
+	legacy := &model.Achievement{AchievementType: "competition", Details: map[string]interface{}{"tanggal": "12/03/2024", "penyelenggara": "Dikti"}}
+	assert.Empty(t, legacy.MissingEventFields())
+	legacy.PromoteLegacyEventFields()
+	require.NotNil(t, legacy.EventAt)
+	assert.Equal(t, "2024-03-12", legacy.EventAt.Format("2006-01-02"))
+	assert.Equal(t, "Dikti", legacy.Organizer)
+
+	a := &model.Achievement{Title: "Sertifikat A", CertificateNumber: "cert 01"}
+	b := &model.Achievement{Title: "Sertifikat B", CertificateNumber: "CERT01"}
+	assert.Equal(t, []string{model.DuplicateSameCertificate}, model.DuplicateReasons(a, b))
+}
+
+func (s *AchievementServiceTestSuite) TestAutosaveAchievement() {
+	ref := &model.AchievementReference{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft", Version: 4}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return ref, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID == s.userID {
+			return &model.Student{ID: s.studentID}, nil
+		}
+		return &model.Student{ID: uuid.New()}, nil
+	}
+	touched := 0
+	s.pgRepo.TouchAchievementReferenceFunc = func(id uuid.UUID) error {
+		touched++
+		return nil
+	}
+
+	_, err := s.service.AutosaveAchievement(s.achievementID, uuid.New(), model.Achievement{Title: "x"})
+	assert.Equal(s.T(), http.StatusForbidden, err.(*fiber.Error).Code)
+
+	// field milik server diabaikan, tidak ada BumpVersion / history / revisi
+	autosave, err := s.service.AutosaveAchievement(s.achievementID, s.userID, model.Achievement{Title: "Juara 2 (draf)", Points: 999, Tags: []string{"belum-ada-di-vocabulary"}})
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(4), autosave.BaseVersion)
+	assert.Equal(s.T(), 0, autosave.Content.Points)
+	assert.Equal(s.T(), 1, touched)
+	assert.Empty(s.T(), s.mongoRepo.revisions)
+
+	got, err := s.service.GetAutosave(s.achievementID, s.userID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "Juara 2 (draf)", got.Content.Title)
+
+	// submit membersihkan autosave
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Title: "Juara 2"}, nil
+	}
+	s.pgRepo.SubmitAchievementFunc = func(id uuid.UUID, expectedStatus string, version int64) error { return nil }
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, history model.StatusHistory) error { return nil }
+	require.NoError(s.T(), s.service.SubmitAchievement(s.achievementID, s.userID, 4))
+	_, err = s.service.GetAutosave(s.achievementID, s.userID)
+	assert.Error(s.T(), err)
+}
+
+func (s *AchievementServiceTestSuite) TestCleanupDrafts() {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	abandoned := model.AchievementReference{ID: uuid.New(), MongoAchievementID: primitive.NewObjectID().Hex(), Status: "draft", UpdatedAt: now.AddDate(0, 0, -90)}
+	touched := model.AchievementReference{ID: uuid.New(), MongoAchievementID: primitive.NewObjectID().Hex(), Status: "draft", UpdatedAt: now.AddDate(0, 0, -80)}
+	stale := model.AchievementReference{ID: uuid.New(), MongoAchievementID: primitive.NewObjectID().Hex(), Status: "draft", UpdatedAt: now.AddDate(0, 0, -20)}
+	// tidak pernah diingatkan (mis. job sempat mati): pengingat dulu, hapus paling cepat 46 hari lagi
+	neverReminded := model.AchievementReference{ID: uuid.New(), MongoAchievementID: primitive.NewObjectID().Hex(), Status: "draft", UpdatedAt: now.AddDate(0, 0, -70)}
+
+	s.pgRepo.FindAbandonedDraftsFunc = func(untouchedSince, remindedBefore time.Time) ([]model.AchievementReference, error) {
+		assert.Equal(s.T(), now.AddDate(0, 0, -60), untouchedSince)
+		assert.Equal(s.T(), now.AddDate(0, 0, -46), remindedBefore)
+		return []model.AchievementReference{abandoned, touched}, nil
+	}
+	s.pgRepo.FindStaleDraftsFunc = func(untouchedSince time.Time, unremindedOnly bool) ([]model.AchievementReference, error) {
+		assert.True(s.T(), unremindedOnly)
+		assert.Equal(s.T(), now.AddDate(0, 0, -14), untouchedSince)
+		return []model.AchievementReference{stale, neverReminded}, nil
+	}
+	var softDeleted []string
+	s.mongoRepo.SoftDeleteAchievementFunc = func(mongoID string) error {
+		softDeleted = append(softDeleted, mongoID)
+		return nil
+	}
+	s.pgRepo.SoftDeleteAchievementReferenceFunc = func(id uuid.UUID, untouchedSince time.Time) error {
+		assert.Equal(s.T(), now.AddDate(0, 0, -60), untouchedSince)
+		if id == touched.ID {
+			return repository.ErrDraftChanged // diedit setelah dibaca job
+		}
+		return nil
+	}
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, history model.StatusHistory) error {
+		assert.Equal(s.T(), "deleted", history.Status)
+		return nil
+	}
+	notified := map[string]model.Notification{}
+	s.mongoRepo.AddNotificationFunc = func(mongoID string, notif model.Notification) error {
+		notified[mongoID] = notif
+		return nil
+	}
+	var reminded []uuid.UUID
+	s.pgRepo.MarkDraftRemindedFunc = func(id uuid.UUID) error {
+		reminded = append(reminded, id)
+		return nil
+	}
+
+	result, err := s.service.CleanupDrafts(now)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), &model.DraftCleanupResult{Reminded: 2, Deleted: 1}, result)
+	assert.Equal(s.T(), []string{abandoned.MongoAchievementID}, softDeleted)
+	assert.Equal(s.T(), []uuid.UUID{stale.ID, neverReminded.ID}, reminded)
+	require.Len(s.T(), notified, 2)
+	assert.Equal(s.T(), "draft_reminder", notified[stale.MongoAchievementID].Type)
+	assert.Contains(s.T(), notified[stale.MongoAchievementID].Message, "2025-07-17")         // grace 46 hari sejak pengingat
+	assert.Contains(s.T(), notified[neverReminded.MongoAchievementID].Message, "2025-07-17") // bukan 2025-05-22 yang sudah lewat
+}
+
+func TestAchievementRepository_SoftDeleteDraftIsConditional(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := repository.NewAchievementRepository(db)
+
+	id := uuid.New()
+	cutoff := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectExec(regexp.QuoteMeta(`WHERE id = $1 AND status = 'draft' AND updated_at <= $2`)).
+		WithArgs(id.String(), cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.SoftDeleteAchievementReference(id, cutoff)
+	assert.ErrorIs(t, err, repository.ErrDraftChanged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func (s *AchievementServiceTestSuite) TestVerifyAchievement_Success() {
+	ref := &model.AchievementReference{
+		ID:                 s.achievementID,
+		MongoAchievementID: s.mongoID.Hex(),
+		Status:             "submitted",
+		Version:            1,
+	}
+
+	ach := &model.Achievement{Title: "Test Achievement", ID: s.mongoID}
+
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return ref, nil
+	}
+
+	s.pgRepo.VerifyAchievementFunc = func(id uuid.UUID, verifiedBy uuid.UUID, note *string, version int64) error {
 		assert.Nil(s.T(), note)
 		return nil
 	}
@@ -692,8 +1714,274 @@ func (s *AchievementServiceTestSuite) TestVerifyAchievement_Success() {
 		return nil
 	}
 
-	err := s.service.VerifyAchievement(s.achievementID, s.userID)
+	err := s.service.VerifyAchievement(s.achievementID, s.userID, ref.Version)
+	assert.NoError(s.T(), err)
+}
+
+func (s *AchievementServiceTestSuite) TestVerifyAchievement_IssuesReceipt() {
+	ref := &model.AchievementReference{
+		ID:                 s.achievementID,
+		StudentID:          s.studentID,
+		MongoAchievementID: s.mongoID.Hex(),
+		Status:             "submitted",
+		Version:            3,
+	}
+	ach := &model.Achievement{
+		ID:          s.mongoID,
+		StudentID:   s.studentID,
+		Title:       "Juara 1 Lomba Robotik",
+		Attachments: []model.Attachment{{ID: "b", FileName: "sertifikat.pdf", ContentHash: strings.Repeat("ab", 32)}, {ID: "a", FileName: "foto.jpg", ContentHash: strings.Repeat("cd", 32)}},
+	}
+	var stored string
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) { return ref, nil }
+	s.pgRepo.VerifyAchievementFunc = func(id uuid.UUID, verifiedBy uuid.UUID, note *string, version int64) error {
+		ref.Status = "verified"
+		return nil
+	}
+	s.pgRepo.SaveVerificationReceiptFunc = func(id uuid.UUID, receipt string) error {
+		assert.Equal(s.T(), s.achievementID, id)
+		stored = receipt
+		return nil
+	}
+	s.pgRepo.GetVerificationReceiptFunc = func(id uuid.UUID) (string, error) { return stored, nil }
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, history model.StatusHistory) error { return nil }
+	s.mongoRepo.AddNotificationFunc = func(mongoID string, notif model.Notification) error { return nil }
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) { return ach, nil }
+
+	require.NoError(s.T(), s.service.VerifyAchievement(s.achievementID, s.userID, ref.Version))
+	require.NotEmpty(s.T(), stored)
+
+	claims, err := s.receipts.Verify(stored)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), s.achievementID.String(), claims.AchievementID)
+	assert.Equal(s.T(), s.userID.String(), claims.VerifiedBy)
+	assert.Equal(s.T(), int64(4), claims.Version)
+	assert.Equal(s.T(), []string{"a", "b"}, []string{claims.Attachments[0].ID, claims.Attachments[1].ID})
+
+	res, err := s.service.VerifyReceipt(stored)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), res.Genuine)
+	assert.True(s.T(), res.Unchanged)
+	assert.Empty(s.T(), res.Problems)
+	assert.Equal(s.T(), "Juara 1 Lomba Robotik", res.Title)
+	assert.Equal(s.T(), s.receipts.KeyID(), res.KeyID)
+
+	// status history dan notifikasi tidak dihitung sebagai perubahan isi
+	ach.StatusHistory = append(ach.StatusHistory, model.StatusHistory{Status: "verified"})
+	res, _ = s.service.VerifyReceipt(stored)
+	assert.True(s.T(), res.Unchanged)
+
+	ach.Title = "Juara 1 Lomba Robotik Nasional"
+	res, _ = s.service.VerifyReceipt(stored)
+	assert.True(s.T(), res.Genuine)
+	assert.False(s.T(), res.Unchanged)
+	assert.Equal(s.T(), []string{model.ReceiptContentChanged}, res.Problems)
+	ach.Title = "Juara 1 Lomba Robotik"
+
+	ach.Attachments[0].ContentHash = strings.Repeat("ef", 32)
+	res, _ = s.service.VerifyReceipt(stored)
+	assert.Equal(s.T(), []string{model.ReceiptAttachmentsChanged}, res.Problems)
+	ach.Attachments[0].ContentHash = strings.Repeat("ab", 32)
+
+	ref.Status = "rejected"
+	res, _ = s.service.VerifyReceipt(stored)
+	assert.Equal(s.T(), "rejected", res.Status)
+	assert.Equal(s.T(), []string{model.ReceiptStatusChanged}, res.Problems)
+	ref.Status = "verified"
+
+	original := stored
+	stored = "re-verified"
+	res, _ = s.service.VerifyReceipt(original)
+	assert.Equal(s.T(), []string{model.ReceiptSuperseded}, res.Problems)
+	stored = original
+
+	// payload diubah tanpa tanda tangan baru
+	parts := strings.Split(stored, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"achievement_id":"` + uuid.NewString() + `"}`))
+	res, err = s.service.VerifyReceipt(strings.Join(parts, "."))
+	require.NoError(s.T(), err)
+	assert.False(s.T(), res.Genuine)
+	assert.False(s.T(), res.Unchanged)
+	assert.Equal(s.T(), []string{model.ReceiptInvalidSignature}, res.Problems)
+
+	// receipt dari kunci lain tidak dianggap asli
+	other, err := receipt.NewSigner(bytes.Repeat([]byte{2}, 32))
+	require.NoError(s.T(), err)
+	forged, err := other.Sign(*claims)
+	require.NoError(s.T(), err)
+	res, _ = s.service.VerifyReceipt(forged)
+	assert.False(s.T(), res.Genuine)
+}
+
+func (s *AchievementServiceTestSuite) TestVerificationReceiptHandlers() {
+	app := fiber.New()
+	app.Get("/api/v1/verification-receipts/verify", s.service.VerifyReceiptHandler)
+	app.Post("/api/v1/verification-receipts/verify", s.service.VerifyReceiptHandler)
+	app.Get("/api/v1/verification-receipts/keys", s.service.ReceiptKeysHandler)
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/verification-receipts/keys", nil))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var keys struct {
+		Keys []receipt.JWK `json:"keys"`
+	}
+	require.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&keys))
+	require.Len(s.T(), keys.Keys, 1)
+	assert.Equal(s.T(), "OKP", keys.Keys[0].Kty)
+	assert.Equal(s.T(), "Ed25519", keys.Keys[0].Crv)
+	assert.Equal(s.T(), s.receipts.KeyID(), keys.Keys[0].Kid)
+	x, err := base64.RawURLEncoding.DecodeString(keys.Keys[0].X)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []byte(s.receipts.PublicKey()), x)
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/verification-receipts/verify", nil))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusBadRequest, resp.StatusCode)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/verification-receipts/verify", strings.NewReader(`{"receipt":"not.a.receipt"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var res model.ReceiptVerification
+	require.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&res))
+	assert.False(s.T(), res.Genuine)
+	assert.Equal(s.T(), []string{model.ReceiptInvalidSignature}, res.Problems)
+
+	// tanpa signer endpoint publik mati
+	disabled := service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, s.scanner, nil, nil, nil, service.AchievementConfig{})
+	_, err = disabled.VerifyReceipt("x")
+	assert.Equal(s.T(), http.StatusServiceUnavailable, fiberStatus(err))
+}
+
+func (s *AchievementServiceTestSuite) TestVerifyReceipt_RetiredKey() {
+	ref := &model.AchievementReference{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "verified", Version: 4}
+	ach := &model.Achievement{ID: s.mongoID, StudentID: s.studentID, Title: "Juara 1 Lomba Robotik"}
+	contentHash, err := ach.ContentHash()
+	require.NoError(s.T(), err)
+	old, err := receipt.NewSigner(bytes.Repeat([]byte{2}, 32))
+	require.NoError(s.T(), err)
+	token, err := old.Sign(receipt.Claims{AchievementID: s.achievementID.String(), ContentHash: contentHash, Attachments: ach.ReceiptAttachments(), VerifiedAt: time.Now()})
+	require.NoError(s.T(), err)
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) { return ref, nil }
+	s.pgRepo.GetVerificationReceiptFunc = func(id uuid.UUID) (string, error) { return token, nil }
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) { return ach, nil }
+
+	// kunci lama tidak didaftarkan: receipt sebelum rotasi tidak dianggap asli
+	res, err := s.service.VerifyReceipt(token)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), res.Genuine)
+
+	_, err = receipt.NewSigner(bytes.Repeat([]byte{1}, 32), []byte("short"))
+	assert.Error(s.T(), err)
+	rotated, err := receipt.NewSigner(bytes.Repeat([]byte{1}, 32), old.PublicKey())
+	require.NoError(s.T(), err)
+	svc := service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, s.scanner, nil, nil, rotated, service.AchievementConfig{})
+	res, err = svc.VerifyReceipt(token)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), res.Genuine)
+	assert.True(s.T(), res.Unchanged)
+	assert.Equal(s.T(), old.KeyID(), res.KeyID)
+
+	// JWK Set memuat kunci aktif lalu kunci lama
+	keys := rotated.JWKs()
+	require.Len(s.T(), keys, 2)
+	assert.Equal(s.T(), rotated.KeyID(), keys[0].Kid)
+	assert.Equal(s.T(), old.KeyID(), keys[1].Kid)
+	assert.Equal(s.T(), base64.RawURLEncoding.EncodeToString(old.PublicKey()), keys[1].X)
+}
+
+func (s *AchievementServiceTestSuite) TestLoadOrCreateSeed() {
+	path := filepath.Join(s.T().TempDir(), "keys", "receipt_signing.key")
+	seed, created, err := receipt.LoadOrCreateSeed(path)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), created)
+	assert.Len(s.T(), seed, 32)
+	info, err := os.Stat(path)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), os.FileMode(0o600), info.Mode().Perm())
+
+	again, created, err := receipt.LoadOrCreateSeed(path)
+	require.NoError(s.T(), err)
+	assert.False(s.T(), created)
+	assert.Equal(s.T(), seed, again)
+
+	other, _, err := receipt.LoadOrCreateSeed(filepath.Join(s.T().TempDir(), "other.key"))
+	require.NoError(s.T(), err)
+	assert.NotEqual(s.T(), seed, other)
+}
+
+func (s *AchievementServiceTestSuite) TestReissueReceipt() {
+	verifiedAt := time.Now().Add(-time.Hour)
+	verifier := uuid.New()
+	ref := &model.AchievementReference{
+		ID:                 s.achievementID,
+		StudentID:          s.studentID,
+		MongoAchievementID: s.mongoID.Hex(),
+		Status:             "verified",
+		VerifiedAt:         &verifiedAt,
+		VerifiedBy:         &verifier,
+		Version:            4,
+	}
+	ach := &model.Achievement{ID: s.mongoID, StudentID: s.studentID, Title: "Juara 1 Lomba Robotik"}
+	stored := ""
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) { return ref, nil }
+	s.pgRepo.GetVerificationReceiptFunc = func(id uuid.UUID) (string, error) { return stored, nil }
+	s.pgRepo.SaveVerificationReceiptFunc = func(id uuid.UUID, receipt string) error {
+		stored = receipt
+		return nil
+	}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) { return ach, nil }
+
+	_, err := s.service.ReissueReceipt(s.achievementID, "Dosen Wali")
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err))
+
+	// receipt gagal diterbitkan saat verifikasi
+	token, err := s.service.ReissueReceipt(s.achievementID, "Admin")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), stored, token)
+	claims, err := s.receipts.Verify(token)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), verifier.String(), claims.VerifiedBy)
+	assert.Equal(s.T(), verifiedAt.UTC().Truncate(time.Second), claims.VerifiedAt)
+	assert.Equal(s.T(), int64(4), claims.Version)
+	res, err := s.service.VerifyReceipt(token)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), res.Unchanged)
+
+	// receipt yang masih sah tidak diganti
+	_, err = s.service.ReissueReceipt(s.achievementID, "Admin")
+	assert.Equal(s.T(), http.StatusConflict, fiberStatus(err))
+	assert.Equal(s.T(), token, stored)
+
+	// receipt dari kunci yang tidak lagi dikenal diterbitkan ulang
+	old, err := receipt.NewSigner(bytes.Repeat([]byte{2}, 32))
+	require.NoError(s.T(), err)
+	stored, err = old.Sign(*claims)
+	require.NoError(s.T(), err)
+	token, err = s.service.ReissueReceipt(s.achievementID, "Admin")
+	require.NoError(s.T(), err)
+	_, err = s.receipts.Verify(token)
 	assert.NoError(s.T(), err)
+
+	ref.Status = "submitted"
+	stored = ""
+	_, err = s.service.ReissueReceipt(s.achievementID, "Admin")
+	assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err))
+	ref.Status = "verified"
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("role", "Admin")
+		return c.Next()
+	})
+	app.Post("/achievements/:id/receipt", s.service.ReissueReceiptHandler)
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/achievements/"+s.achievementID.String()+"/receipt", nil))
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var body map[string]string
+	require.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(s.T(), stored, body["receipt"])
 }
 
 func (s *AchievementServiceTestSuite) TestDeleteAchievement_OnlyDraft() {
@@ -708,7 +1996,7 @@ func (s *AchievementServiceTestSuite) TestDeleteAchievement_OnlyDraft() {
 	}
 
 	s.mongoRepo.SoftDeleteAchievementFunc = func(mongoID string) error { return nil }
-	s.pgRepo.SoftDeleteAchievementReferenceFunc = func(id uuid.UUID) error { return nil }
+	s.pgRepo.SoftDeleteAchievementReferenceFunc = func(id uuid.UUID, untouchedSince time.Time) error { return nil }
 	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, history model.StatusHistory) error { return nil }
 
 	err := s.service.DeleteAchievement(s.achievementID, s.userID)
@@ -729,3 +2017,2342 @@ func (s *AchievementServiceTestSuite) TestDeleteAchievement_NonDraft_ReturnsErro
 	assert.Error(s.T(), err)
 	assert.Contains(s.T(), err.Error(), "can only delete draft")
 }
+
+// ======================= VERIFICATION QUEUE TESTS =======================
+
+func TestEvaluateSLA(t *testing.T) {
+	sla := 4 * 24 * time.Hour
+	submitted := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want string
+	}{
+		{"fresh", submitted.Add(time.Hour), model.SLAOnTrack},
+		{"last_quarter", submitted.Add(3*24*time.Hour + time.Hour), model.SLADueSoon},
+		{"late", submitted.Add(5 * 24 * time.Hour), model.SLAOverdue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dueAt, status := service.EvaluateSLA(submitted, tt.now, sla)
+			assert.Equal(t, submitted.Add(sla), dueAt)
+			assert.Equal(t, tt.want, status)
+		})
+	}
+}
+
+func (s *AchievementServiceTestSuite) TestGetVerificationQueue_FiltersAdviseesAndType() {
+	lecturerID := uuid.New()
+	otherMongoID := primitive.NewObjectID()
+	submitted := time.Now().Add(-48 * time.Hour)
+
+	s.pgRepo.GetLecturerByUserIDFunc = func(userID uuid.UUID) (*model.Lecturer, error) {
+		return &model.Lecturer{ID: lecturerID}, nil
+	}
+	s.pgRepo.GetStudentIDsByAdvisorFunc = func(advisorID uuid.UUID) ([]uuid.UUID, error) {
+		assert.Equal(s.T(), lecturerID, advisorID)
+		return []uuid.UUID{s.studentID}, nil
+	}
+	claimedStudentID := uuid.New()
+	s.pgRepo.GetClaimedStudentIDsFunc = func(reviewerID uuid.UUID, claimedSince time.Time) ([]uuid.UUID, error) {
+		assert.Equal(s.T(), s.userID, reviewerID)
+		return []uuid.UUID{claimedStudentID}, nil
+	}
+	// type/level difilter di Mongo, dibatasi ke mahasiswa bimbingan + mahasiswa yang item-nya sedang di-claim
+	s.mongoRepo.FindAchievementListKeysFunc = func(filter model.AchievementContentFilter) ([]model.AchievementListKey, error) {
+		assert.Equal(s.T(), "Competition", filter.AchievementType)
+		assert.Equal(s.T(), []uuid.UUID{s.studentID, claimedStudentID}, filter.StudentIDs)
+		assert.Positive(s.T(), filter.Limit)
+		return []model.AchievementListKey{{MongoID: s.mongoID.Hex()}, {MongoID: otherMongoID.Hex()}}, nil
+	}
+	s.pgRepo.ListSubmittedAchievementReferencesFunc = func(filter model.SubmittedReferenceFilter, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+		assert.Equal(s.T(), []uuid.UUID{s.studentID}, filter.StudentIDs)
+		require.NotNil(s.T(), filter.ClaimedBy)
+		assert.Equal(s.T(), s.userID, *filter.ClaimedBy)
+		assert.Equal(s.T(), []string{s.mongoID.Hex(), otherMongoID.Hex()}, filter.MongoIDs)
+		assert.Equal(s.T(), 2, page)
+		assert.Equal(s.T(), 1, limit)
+		return &model.PaginatedResponse[model.AchievementReference]{
+			Data: []model.AchievementReference{
+				{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "submitted", SubmittedAt: &submitted},
+			},
+			Page: page, Limit: limit, Total: 2, TotalPages: 2,
+		}, nil
+	}
+	// dokumen Mongo hanya untuk halaman ini
+	s.mongoRepo.GetAchievementsByIDsFunc = func(mongoIDs []string) (map[string]*model.Achievement, error) {
+		assert.Equal(s.T(), []string{s.mongoID.Hex()}, mongoIDs)
+		return map[string]*model.Achievement{
+			s.mongoID.Hex(): {ID: s.mongoID, Title: "Juara 1", AchievementType: "competition", Attachments: []model.Attachment{{ContentHash: "abc"}}},
+		}, nil
+	}
+	dupMongoID := primitive.NewObjectID()
+	dupRefID := uuid.New()
+	s.mongoRepo.FindDuplicateCandidatesFunc = func(ach *model.Achievement) ([]model.Achievement, error) {
+		assert.Equal(s.T(), s.mongoID, ach.ID)
+		return []model.Achievement{{ID: dupMongoID, Title: "Juara 1", Attachments: []model.Attachment{{ContentHash: "abc"}}}}, nil
+	}
+	s.pgRepo.GetAchievementReferencesByMongoIDsFunc = func(mongoIDs []string) ([]model.AchievementReference, error) {
+		assert.Equal(s.T(), []string{dupMongoID.Hex()}, mongoIDs)
+		return []model.AchievementReference{{ID: dupRefID, MongoAchievementID: dupMongoID.Hex(), Status: "verified"}}, nil
+	}
+
+	resp, err := s.service.GetVerificationQueue(s.userID, "Dosen Wali", model.VerificationQueueFilter{AchievementType: "Competition"}, 2, 1)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(2), resp.Total)
+	assert.Equal(s.T(), 2, resp.TotalPages)
+	require.Len(s.T(), resp.Data, 1)
+	assert.Equal(s.T(), s.achievementID, resp.Data[0].ID)
+	assert.Equal(s.T(), int64(48), resp.Data[0].WaitingHours)
+	assert.Equal(s.T(), model.SLAOnTrack, resp.Data[0].SLAStatus)
+	require.Len(s.T(), resp.Data[0].Duplicates, 1)
+	assert.Equal(s.T(), dupRefID, resp.Data[0].Duplicates[0].AchievementID)
+	assert.Equal(s.T(), "/api/v1/achievements/"+dupRefID.String(), resp.Data[0].Duplicates[0].Link)
+	assert.Equal(s.T(), []string{model.DuplicateSameAttachment, model.DuplicateSameTitle}, resp.Data[0].Duplicates[0].Reasons)
+}
+
+func (s *AchievementServiceTestSuite) TestDuplicateWarnings_HideOtherStudentsData() {
+	dupMongoID := primitive.NewObjectID()
+	dupRefID := uuid.New()
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft"}, nil
+	}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Title: "Juara 1", CertificateNumber: "CERT-001"}, nil
+	}
+	s.mongoRepo.FindDuplicateCandidatesFunc = func(ach *model.Achievement) ([]model.Achievement, error) {
+		return []model.Achievement{{ID: dupMongoID, Title: "Juara 1", CertificateNumber: "CERT-001"}}, nil
+	}
+	s.pgRepo.GetAchievementReferencesByMongoIDsFunc = func(mongoIDs []string) ([]model.AchievementReference, error) {
+		return []model.AchievementReference{{ID: dupRefID, StudentID: uuid.New(), MongoAchievementID: dupMongoID.Hex(), Status: "verified"}}, nil
+	}
+
+	warnings, err := s.service.DuplicateWarnings(s.achievementID)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), warnings, 1)
+	assert.Equal(s.T(), dupRefID, warnings[0].AchievementID)
+	assert.Contains(s.T(), warnings[0].Reasons, model.DuplicateSameCertificate)
+
+	raw, err := json.Marshal(warnings)
+	require.NoError(s.T(), err)
+	var decoded []map[string]interface{}
+	require.NoError(s.T(), json.Unmarshal(raw, &decoded))
+	require.Len(s.T(), decoded, 1)
+	assert.Len(s.T(), decoded[0], 2)
+	assert.Contains(s.T(), decoded[0], "achievement_id")
+	assert.Contains(s.T(), decoded[0], "reasons")
+	assert.NotContains(s.T(), string(raw), "CERT-001")
+}
+
+func TestDuplicateReasons(t *testing.T) {
+	base := &model.Achievement{
+		Title:   "Juara 1 - Hackathon  Nasional!",
+		Details: bson.M{"eventDate": "2024-05-10", "Organizer": "Kominfo"},
+	}
+
+	sameEvent := &model.Achievement{Title: "juara 1 hackathon nasional", Details: bson.M{"date": "10/05/2024"}}
+	assert.Equal(t, []string{model.DuplicateSameTitle, model.DuplicateSameEventDate}, model.DuplicateReasons(base, sameEvent))
+
+	titleOnly := &model.Achievement{Title: "Juara 1 Hackathon Nasional", Details: bson.M{"penyelenggara": "BEM"}}
+	assert.Empty(t, model.DuplicateReasons(base, titleOnly))
+
+	sameFile := &model.Achievement{Title: "Sertifikat lain", Attachments: []model.Attachment{{ContentHash: "h1"}}}
+	withFile := &model.Achievement{Title: "Sertifikat", Attachments: []model.Attachment{{ContentHash: "h1"}}}
+	assert.Equal(t, []string{model.DuplicateSameAttachment}, model.DuplicateReasons(withFile, sameFile))
+
+	assert.Equal(t, "juara 1 hackathon nasional", model.NormalizeTitle(base.Title))
+}
+
+func (s *AchievementServiceTestSuite) TestSearchAchievements_VisibilityOrderAndHighlights() {
+	otherMongoID := primitive.NewObjectID()
+	otherRefID := uuid.New()
+	status := "verified"
+
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.mongoRepo.SearchAchievementsFunc = func(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error) {
+		assert.Equal(s.T(), "hackathon", query)
+		// text search di Mongo sudah dibatasi ke mahasiswa yang terlihat dan jumlah hasil maksimum
+		assert.Equal(s.T(), []uuid.UUID{s.studentID}, studentIDs)
+		assert.Equal(s.T(), 1000, limit)
+		return []model.AchievementSearchHit{
+			{Achievement: model.Achievement{ID: otherMongoID}, Score: 1.5},
+			{Achievement: model.Achievement{ID: s.mongoID}, Score: 3.2},
+		}, nil
+	}
+	s.mongoRepo.GetAchievementsByIDsFunc = func(mongoIDs []string) (map[string]*model.Achievement, error) {
+		return map[string]*model.Achievement{
+			otherMongoID.Hex(): {ID: otherMongoID, Title: "Finalis Hackathon"},
+			s.mongoID.Hex():    {ID: s.mongoID, Title: "Juara 1 Hackathon Nasional", Details: bson.M{"organizer": "Kominfo"}},
+		}, nil
+	}
+	s.pgRepo.FindAchievementReferencesFunc = func(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+		assert.Equal(s.T(), []uuid.UUID{s.studentID}, filter.StudentIDs)
+		assert.ElementsMatch(s.T(), []string{s.mongoID.Hex(), otherMongoID.Hex()}, filter.MongoIDs)
+		assert.Equal(s.T(), &status, filter.Status)
+		return []model.AchievementReference{
+			{ID: otherRefID, StudentID: s.studentID, MongoAchievementID: otherMongoID.Hex(), Status: status},
+			{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: status},
+		}, nil
+	}
+
+	filter := model.AchievementSearchFilter{Query: " hackathon "}
+	filter.Status = &status
+	resp, err := s.service.SearchAchievements(s.userID, "Mahasiswa", filter, 1, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), resp.Data, 2)
+	assert.Equal(s.T(), s.achievementID, resp.Data[0].ID)
+	assert.Equal(s.T(), 3.2, resp.Data[0].Score)
+	assert.Equal(s.T(), "Juara 1 <em>Hackathon</em> Nasional", resp.Data[0].Highlights["title"])
+	assert.NotContains(s.T(), resp.Data[0].Highlights, "details.organizer")
+	assert.Equal(s.T(), otherRefID, resp.Data[1].ID)
+
+	_, err = s.service.SearchAchievements(s.userID, "Mahasiswa", model.AchievementSearchFilter{Query: "  "}, 1, 10)
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusBadRequest, fe.Code)
+}
+
+func (s *AchievementServiceTestSuite) TestSearchAchievements_HighlightsEscapeHTML() {
+	s.mongoRepo.SearchAchievementsFunc = func(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error) {
+		return []model.AchievementSearchHit{{Achievement: model.Achievement{ID: s.mongoID}, Score: 1}}, nil
+	}
+	s.pgRepo.FindAchievementReferencesFunc = func(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+		return []model.AchievementReference{{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex()}}, nil
+	}
+	s.mongoRepo.GetAchievementsByIDsFunc = func(mongoIDs []string) (map[string]*model.Achievement, error) {
+		return map[string]*model.Achievement{s.mongoID.Hex(): {
+			ID:        s.mongoID,
+			Title:     `<script>alert("x")</script> Juara Lomba`,
+			Organizer: "R&D <b>Lomba</b>",
+		}}, nil
+	}
+
+	resp, err := s.service.SearchAchievements(s.userID, "Admin", model.AchievementSearchFilter{Query: "lomba script"}, 1, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), resp.Data, 1)
+	highlights := resp.Data[0].Highlights
+	assert.Equal(s.T(), `&lt;<em>script</em>&gt;alert(&#34;x&#34;)&lt;/<em>script</em>&gt; Juara <em>Lomba</em>`, highlights["title"])
+	assert.Equal(s.T(), `R&amp;D &lt;b&gt;<em>Lomba</em>&lt;/b&gt;`, highlights["organizer"])
+	assert.NotContains(s.T(), highlights["title"], "<script>")
+}
+
+func (s *AchievementServiceTestSuite) TestSearchAchievements_TotalCountsAllVisibleHits() {
+	hits := make([]model.AchievementSearchHit, 0, 600)
+	refs := make([]model.AchievementReference, 0, 600)
+	for i := 0; i < 600; i++ {
+		mid := primitive.NewObjectID()
+		hits = append(hits, model.AchievementSearchHit{Achievement: model.Achievement{ID: mid}, Score: float64(600 - i)})
+		refs = append(refs, model.AchievementReference{ID: uuid.New(), StudentID: s.studentID, MongoAchievementID: mid.Hex()})
+	}
+	s.mongoRepo.SearchAchievementsFunc = func(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error) {
+		assert.Nil(s.T(), studentIDs)
+		return hits, nil
+	}
+	s.pgRepo.FindAchievementReferencesFunc = func(filter model.ReferenceFilter) ([]model.AchievementReference, error) {
+		assert.Len(s.T(), filter.MongoIDs, 600)
+		return refs, nil
+	}
+	s.mongoRepo.GetAchievementsByIDsFunc = func(mongoIDs []string) (map[string]*model.Achievement, error) {
+		// hanya dokumen di halaman yang diminta yang dimuat
+		assert.Equal(s.T(), []string{refs[590].MongoAchievementID, refs[591].MongoAchievementID}, mongoIDs[:2])
+		assert.Len(s.T(), mongoIDs, 10)
+		return map[string]*model.Achievement{}, nil
+	}
+
+	resp, err := s.service.SearchAchievements(s.userID, "Admin", model.AchievementSearchFilter{Query: "lomba"}, 60, 10)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(600), resp.Total)
+	assert.Equal(s.T(), 60, resp.TotalPages)
+	require.Len(s.T(), resp.Data, 10)
+	assert.Equal(s.T(), refs[590].ID, resp.Data[0].ID)
+
+	// dosen wali tanpa mahasiswa bimbingan tidak menjalankan text search sama sekali
+	s.pgRepo.GetLecturerByUserIDFunc = func(userID uuid.UUID) (*model.Lecturer, error) { return &model.Lecturer{ID: uuid.New()}, nil }
+	s.pgRepo.GetStudentIDsByAdvisorFunc = func(advisorID uuid.UUID) ([]uuid.UUID, error) { return nil, nil }
+	s.mongoRepo.SearchAchievementsFunc = func(query string, studentIDs []uuid.UUID, limit int) ([]model.AchievementSearchHit, error) {
+		s.T().Fatal("search must not run without visible students")
+		return nil, nil
+	}
+	resp, err = s.service.SearchAchievements(s.userID, "Dosen Wali", model.AchievementSearchFilter{Query: "lomba"}, 1, 10)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), resp.Total)
+	assert.Empty(s.T(), resp.Data)
+}
+
+func (s *AchievementServiceTestSuite) TestGetVerificationQueue_StudentRoleForbidden() {
+	_, err := s.service.GetVerificationQueue(s.userID, "Mahasiswa", model.VerificationQueueFilter{}, 1, 10)
+	assert.Error(s.T(), err)
+	fe, ok := err.(*fiber.Error)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusForbidden, fe.Code)
+}
+
+func (s *AchievementServiceTestSuite) TestClaimAchievement_Conflict() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, Status: "submitted"}, nil
+	}
+	s.pgRepo.ClaimAchievementFunc = func(id, reviewerID uuid.UUID, staleBefore time.Time) error {
+		assert.True(s.T(), staleBefore.Before(time.Now()))
+		return repository.ErrClaimConflict
+	}
+
+	err := s.service.ClaimAchievement(s.achievementID, s.userID, "Admin")
+	fe, ok := err.(*fiber.Error)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusConflict, fe.Code)
+}
+
+func (s *AchievementServiceTestSuite) TestVerifyAchievement_ClaimedByOtherReviewer() {
+	other := uuid.New()
+	claimedAt := time.Now().Add(-10 * time.Minute)
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, Status: "submitted", ClaimedBy: &other, ClaimedAt: &claimedAt}, nil
+	}
+
+	err := s.service.VerifyAchievement(s.achievementID, s.userID, 0)
+	fe, ok := err.(*fiber.Error)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusConflict, fe.Code)
+}
+
+func (s *AchievementServiceTestSuite) TestBatchReview_PerItemResults() {
+	okID, badID, staleID := uuid.New(), uuid.New(), uuid.New()
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		status := "submitted"
+		if id == badID {
+			status = "draft"
+		}
+		return &model.AchievementReference{ID: id, MongoAchievementID: s.mongoID.Hex(), Status: status, Version: 2}, nil
+	}
+	s.pgRepo.VerifyAchievementFunc = func(id uuid.UUID, verifiedBy uuid.UUID, note *string, version int64) error {
+		assert.Equal(s.T(), okID, id)
+		assert.Equal(s.T(), int64(2), version)
+		assert.Equal(s.T(), "duplikat", *note)
+		return nil
+	}
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, history model.StatusHistory) error { return nil }
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) { return nil, nil }
+	s.mongoRepo.AddNotificationFunc = func(mongoID string, notif model.Notification) error { return nil }
+
+	note := "duplikat"
+	// staleID berubah (version 2) setelah reviewer melihat version 1 di queue
+	resp := s.service.BatchReview([]model.BatchReviewItem{{ID: okID, Version: 2}, {ID: badID, Version: 2}, {ID: staleID, Version: 1}}, s.userID, "Admin", &note)
+	assert.Equal(s.T(), 1, resp.Succeeded)
+	assert.Equal(s.T(), 2, resp.Failed)
+	assert.Equal(s.T(), "rejected", resp.Results[0].Status)
+	assert.Equal(s.T(), "error", resp.Results[1].Status)
+	assert.Equal(s.T(), http.StatusBadRequest, resp.Results[1].Code)
+	assert.Contains(s.T(), resp.Results[1].Error, "only submitted")
+	assert.Equal(s.T(), "error", resp.Results[2].Status)
+	assert.Equal(s.T(), http.StatusPreconditionFailed, resp.Results[2].Code)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", s.userID.String())
+		c.Locals("role", "Admin")
+		return c.Next()
+	})
+	app.Post("/achievements/batch/reject", s.service.BatchRejectHandler)
+	post := func(body string) *http.Response {
+		req := httptest.NewRequest(http.MethodPost, "/achievements/batch/reject", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		res, err := app.Test(req)
+		require.NoError(s.T(), err)
+		return res
+	}
+	// version wajib per item
+	assert.Equal(s.T(), http.StatusBadRequest, post(`{"items":[{"id":"`+okID.String()+`"}],"rejection_note":"duplikat"}`).StatusCode)
+	res := post(`{"items":[{"id":"` + okID.String() + `","version":2}],"rejection_note":"duplikat"}`)
+	assert.Equal(s.T(), http.StatusOK, res.StatusCode)
+	var body model.BatchReviewResponse
+	require.NoError(s.T(), json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(s.T(), 1, body.Succeeded)
+}
+
+func (s *AchievementServiceTestSuite) TestSubmitAchievement_StaleVersion() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, MongoAchievementID: s.mongoID.Hex(), Status: "draft", Version: 4}, nil
+	}
+
+	err := s.service.SubmitAchievement(s.achievementID, s.userID, 3)
+	fe, ok := err.(*fiber.Error)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusPreconditionFailed, fe.Code)
+}
+
+func (s *AchievementServiceTestSuite) TestUpdateAchievement_ConcurrentWriteLoses() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft", Version: 2}, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) { return &model.Student{ID: s.studentID}, nil }
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Title: "Lama", Version: 2}, nil
+	}
+	var written model.StatusHistory
+	s.mongoRepo.UpdateAchievementFunc = func(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
+		written = history
+		return nil
+	}
+	// status berubah (mis. disubmit) di antara penulisan Mongo dan bump version Postgres
+	s.pgRepo.BumpVersionFunc = func(id uuid.UUID, expectedStatus string, version int64) error {
+		return repository.ErrVersionConflict
+	}
+	var restored bson.M
+	s.mongoRepo.RestoreAchievementContentFunc = func(mongoID string, fields bson.M, historyID uuid.UUID) error {
+		assert.NotEqual(s.T(), uuid.Nil, historyID)
+		assert.Equal(s.T(), written.ID, historyID)
+		restored = fields
+		return nil
+	}
+
+	err := s.service.UpdateAchievement(s.achievementID, s.userID, model.Achievement{Title: "Baru"}, 2)
+	fe, ok := err.(*fiber.Error)
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusPreconditionFailed, fe.Code)
+	require.NotNil(s.T(), restored)
+	assert.Equal(s.T(), "Lama", restored["title"])
+}
+
+func (s *AchievementServiceTestSuite) TestUpdateAchievement_MongoFailureKeepsVersion() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft", Version: 2}, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) { return &model.Student{ID: s.studentID}, nil }
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Version: 2}, nil
+	}
+	s.mongoRepo.UpdateAchievementFunc = func(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
+		return errors.New("mongo down")
+	}
+	s.pgRepo.BumpVersionFunc = func(id uuid.UUID, expectedStatus string, version int64) error {
+		s.T().Fatal("version must not be bumped when the content write fails")
+		return nil
+	}
+
+	err := s.service.UpdateAchievement(s.achievementID, s.userID, model.Achievement{Title: "Baru"}, 2)
+	assert.EqualError(s.T(), err, "mongo down")
+}
+
+func (s *AchievementServiceTestSuite) patchFixture() *model.Achievement {
+	ach := &model.Achievement{
+		ID:          s.mongoID,
+		Title:       "Juara 2 Hackathon",
+		Details:     bson.M{"rank": "2", "organizer": "Kominfo"},
+		Attachments: []model.Attachment{{FileName: "sertifikat.pdf", FileURL: "/uploads/sertifikat.pdf"}},
+		Points:      50,
+		Version:     1,
+	}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft", Version: 1}, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID != s.userID {
+			return &model.Student{ID: uuid.New()}, nil
+		}
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) { return ach, nil }
+	s.pgRepo.BumpVersionFunc = func(id uuid.UUID, expectedStatus string, version int64) error { return nil }
+	return ach
+}
+
+func (s *AchievementServiceTestSuite) TestPatchAchievement_OnlyOwner() {
+	s.patchFixture()
+	s.mongoRepo.UpdateAchievementFunc = func(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
+		s.T().Fatal("non-owner must not write")
+		return nil
+	}
+
+	_, err := s.service.PatchAchievement(s.achievementID, uuid.New(), "application/merge-patch+json", []byte(`{"title":"Diambil alih"}`), 1)
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err))
+
+	err = s.service.UpdateAchievement(s.achievementID, uuid.New(), model.Achievement{Title: "Diambil alih"}, 1)
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err))
+}
+
+func (s *AchievementServiceTestSuite) TestPatchAchievement_MergePatchRecordsDiff() {
+	s.patchFixture()
+	var saved bson.M
+	var recorded model.StatusHistory
+	s.mongoRepo.UpdateAchievementFunc = func(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
+		saved, recorded = fields, history
+		return nil
+	}
+
+	body := []byte(`{"title":"Juara 1 Hackathon","details":{"rank":"1","organizer":null}}`)
+	changes, err := s.service.PatchAchievement(s.achievementID, s.userID, "application/merge-patch+json", body, 1)
+	require.NoError(s.T(), err)
+
+	assert.Equal(s.T(), "Juara 1 Hackathon", saved["title"])
+	assert.NotContains(s.T(), saved, "attachments")
+	assert.NotContains(s.T(), saved, "points")
+	assert.Equal(s.T(), []string{"details.organizer", "details.rank", "title"}, []string{changes[0].Field, changes[1].Field, changes[2].Field})
+	assert.Equal(s.T(), changes, recorded.Changes)
+	assert.Equal(s.T(), &s.userID, recorded.ChangedBy)
+}
+
+func (s *AchievementServiceTestSuite) TestPatchAchievement_JSONPatchCannotTouchServerFields() {
+	s.patchFixture()
+
+	body := []byte(`[{"op":"replace","path":"/title","value":"Baru"},{"op":"remove","path":"/attachments/0"}]`)
+	_, err := s.service.PatchAchievement(s.achievementID, s.userID, "application/json-patch+json", body, 1)
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, fe.Code)
+	assert.Contains(s.T(), fe.Message, "attachments")
+}
+
+func (s *AchievementServiceTestSuite) TestPatchAchievement_JSONPatchOps() {
+	s.patchFixture()
+	_, ai := sampleTaxonomy()
+	s.tagRepo.tags = []model.Tag{ai, {ID: uuid.New(), Slug: "iot", Name: "IoT", Synonyms: []string{}}}
+	var saved bson.M
+	s.mongoRepo.UpdateAchievementFunc = func(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
+		saved = fields
+		return nil
+	}
+
+	body := []byte(`[
+		{"op":"test","path":"/details/rank","value":"2"},
+		{"op":"add","path":"/tags","value":["ai"]},
+		{"op":"add","path":"/tags/-","value":"iot"},
+		{"op":"move","from":"/details/organizer","path":"/details/penyelenggara"},
+		{"op":"add","path":"/certificateNumber","value":" sert 12-a "}
+	]`)
+	_, err := s.service.PatchAchievement(s.achievementID, s.userID, "application/json-patch+json", body, 1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"ai", "iot"}, saved["tags"])
+	assert.Equal(s.T(), bson.M{"rank": "2", "penyelenggara": "Kominfo"}, saved["details"])
+	assert.Equal(s.T(), "sert 12-a", saved["certificateNumber"])
+	assert.Equal(s.T(), "SERT12-A", saved["certificateKey"])
+}
+
+func (s *AchievementServiceTestSuite) TestPatchAchievement_RecordsRevision() {
+	s.patchFixture()
+	s.mongoRepo.UpdateAchievementFunc = func(mongoID string, fields bson.M, history model.StatusHistory, expectedVersion int64) error {
+		return nil
+	}
+
+	_, err := s.service.PatchAchievement(s.achievementID, s.userID, "application/merge-patch+json", []byte(`{"title":"Juara 1"}`), 1)
+	require.NoError(s.T(), err)
+
+	require.Len(s.T(), s.mongoRepo.revisions, 1)
+	rev := s.mongoRepo.revisions[0]
+	assert.Equal(s.T(), int64(2), rev.Revision)
+	assert.Equal(s.T(), model.RevisionUpdated, rev.Event)
+	assert.Equal(s.T(), "Juara 1", rev.Snapshot.Title)
+	assert.Len(s.T(), rev.Snapshot.Attachments, 1)
+}
+
+func (s *AchievementServiceTestSuite) TestDiffAchievementRevisions_Latest() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "submitted", Version: 5}, nil
+	}
+	eventAt := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	snapshots := map[int64]*model.Achievement{
+		2: {Title: "Juara 2", Details: bson.M{"rank": "2"}, Organizer: "Kominfo", Location: "Bandung"},
+		5: {
+			Title: "Juara 2", Details: bson.M{"rank": "1"}, Attachments: []model.Attachment{{FileName: "bukti.pdf"}},
+			EventAt: &eventAt, Organizer: "Kemdikbud", Location: "Bandung",
+			CertificateNumber: "SK-001", VerificationURL: "https://example.org/verify/SK-001",
+		},
+	}
+	s.mongoRepo.ListRevisionsFunc = func(mongoID string) ([]model.AchievementRevision, error) {
+		return []model.AchievementRevision{{Revision: 1}, {Revision: 2}, {Revision: 5}}, nil
+	}
+	s.mongoRepo.GetRevisionFunc = func(mongoID string, revision int64) (*model.AchievementRevision, error) {
+		if snapshots[revision] == nil {
+			return nil, nil
+		}
+		return &model.AchievementRevision{Revision: revision, Snapshot: snapshots[revision]}, nil
+	}
+
+	diff, err := s.service.DiffAchievementRevisions(s.achievementID, s.userID, "Admin", 2, 0)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(5), diff.To.Revision)
+	assert.Nil(s.T(), diff.To.Snapshot)
+	fields := make([]string, len(diff.Changes))
+	for i, c := range diff.Changes {
+		fields[i] = c.Field
+	}
+	// field kejadian & verifikasi ikut dibandingkan; location tidak berubah
+	assert.Equal(s.T(), []string{"attachments", "certificateNumber", "details.rank", "eventDate", "organizer", "verificationUrl"}, fields)
+
+	_, err = s.service.DiffAchievementRevisions(s.achievementID, s.userID, "Admin", 3, 5)
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusNotFound, fe.Code)
+}
+
+func (s *AchievementServiceTestSuite) TestAchievementRevisions_OnlyVisibleToOwnerAdvisorAdmin() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "submitted", Version: 2}, nil
+	}
+	s.mongoRepo.ListRevisionsFunc = func(mongoID string) ([]model.AchievementRevision, error) {
+		return []model.AchievementRevision{{Revision: 1}, {Revision: 2}}, nil
+	}
+	otherStudent, otherLecturer := uuid.New(), uuid.New()
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID == otherStudent {
+			return &model.Student{ID: uuid.New()}, nil
+		}
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.pgRepo.GetLecturerByUserIDFunc = func(userID uuid.UUID) (*model.Lecturer, error) { return &model.Lecturer{ID: userID}, nil }
+	s.pgRepo.GetStudentIDsByAdvisorFunc = func(advisorID uuid.UUID) ([]uuid.UUID, error) {
+		if advisorID == otherLecturer {
+			return []uuid.UUID{uuid.New()}, nil
+		}
+		return []uuid.UUID{s.studentID}, nil
+	}
+
+	revisions, err := s.service.GetAchievementRevisions(s.achievementID, s.userID, "Mahasiswa")
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), revisions, 2)
+	_, err = s.service.GetAchievementRevisions(s.achievementID, uuid.New(), "Dosen Wali")
+	assert.NoError(s.T(), err)
+
+	_, err = s.service.GetAchievementRevisions(s.achievementID, otherStudent, "Mahasiswa")
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err))
+	_, err = s.service.GetAchievementRevisions(s.achievementID, otherLecturer, "Dosen Wali")
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err))
+	_, err = s.service.DiffAchievementRevisions(s.achievementID, otherStudent, "Mahasiswa", 1, 0)
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err))
+}
+
+func (s *AchievementServiceTestSuite) TestAssignReviewer_AssigneeCanVerify() {
+	advisor, assignee := uuid.New(), uuid.New()
+	ref := &model.AchievementReference{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "submitted", Version: 2}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) { return ref, nil }
+	s.pgRepo.GetLecturerByUserIDFunc = func(userID uuid.UUID) (*model.Lecturer, error) { return &model.Lecturer{ID: userID}, nil }
+	s.pgRepo.GetStudentIDsByAdvisorFunc = func(advisorID uuid.UUID) ([]uuid.UUID, error) {
+		if advisorID == advisor {
+			return []uuid.UUID{s.studentID}, nil
+		}
+		return []uuid.UUID{}, nil
+	}
+	s.pgRepo.AssignReviewerFunc = func(id, reviewerID uuid.UUID) error {
+		now := time.Now()
+		ref.ClaimedBy, ref.ClaimedAt = &reviewerID, &now
+		return nil
+	}
+	var verifiedBy uuid.UUID
+	s.pgRepo.VerifyAchievementFunc = func(id uuid.UUID, by uuid.UUID, note *string, version int64) error {
+		verifiedBy = by
+		return nil
+	}
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, history model.StatusHistory) error { return nil }
+	s.mongoRepo.AddNotificationFunc = func(mongoID string, notif model.Notification) error { return nil }
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) { return &model.Achievement{ID: s.mongoID}, nil }
+
+	// dosen wali menugaskan ke dosen yang bukan dosen wali mahasiswa ini
+	require.NoError(s.T(), s.service.AssignReviewer(s.achievementID, assignee, advisor, "Dosen Wali"))
+	assert.Equal(s.T(), assignee, *ref.ClaimedBy)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", c.Get("X-User"))
+		c.Locals("role", "Dosen Wali")
+		return c.Next()
+	})
+	app.Post("/achievements/:id/verify", s.service.VerifyHandler)
+	verify := func(userID uuid.UUID) int {
+		req := httptest.NewRequest(http.MethodPost, "/achievements/"+s.achievementID.String()+"/verify", nil)
+		req.Header.Set("X-User", userID.String())
+		req.Header.Set("If-Match", `"2"`)
+		resp, err := app.Test(req)
+		require.NoError(s.T(), err)
+		return resp.StatusCode
+	}
+
+	// advisor tertahan claim milik assignee; dosen lain tanpa claim tetap ditolak
+	assert.Equal(s.T(), http.StatusConflict, verify(advisor))
+	assert.Equal(s.T(), http.StatusForbidden, verify(uuid.New()))
+	assert.Equal(s.T(), http.StatusOK, verify(assignee))
+	assert.Equal(s.T(), assignee, verifiedBy)
+
+	// claim yang sudah basi tidak lagi memberi akses
+	stale := time.Now().Add(-24 * time.Hour)
+	ref.ClaimedAt = &stale
+	assert.Equal(s.T(), http.StatusForbidden, verify(assignee))
+}
+
+func (s *AchievementServiceTestSuite) TestRevokeAchievement_AdminWithReason() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, MongoAchievementID: s.mongoID.Hex(), Status: "verified", Version: 3}, nil
+	}
+	s.pgRepo.RevokeAchievementFunc = func(id uuid.UUID, reason string, version int64) error {
+		assert.Equal(s.T(), "Sertifikat palsu", reason)
+		assert.Equal(s.T(), int64(3), version)
+		return nil
+	}
+	var history model.StatusHistory
+	var notif model.Notification
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, h model.StatusHistory) error { history = h; return nil }
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) { return &model.Achievement{Title: "Juara 1"}, nil }
+	s.mongoRepo.AddNotificationFunc = func(mongoID string, n model.Notification) error { notif = n; return nil }
+
+	err := s.service.RevokeAchievement(s.achievementID, s.userID, "Dosen Wali", "Sertifikat palsu", 3)
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusForbidden, fe.Code)
+
+	err = s.service.RevokeAchievement(s.achievementID, s.userID, "Admin", "  ", 3)
+	fe, ok = err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusBadRequest, fe.Code)
+
+	err = s.service.RevokeAchievement(s.achievementID, s.userID, "Admin", " Sertifikat palsu ", 3)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "revoked", history.Status)
+	assert.Equal(s.T(), "achievement_revoked", notif.Type)
+	assert.Contains(s.T(), notif.Message, "Sertifikat palsu")
+}
+
+func (s *AchievementServiceTestSuite) TestWithdrawAchievement_OwnerOnly() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "submitted", Version: 2}, nil
+	}
+	otherUser := uuid.New()
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID == otherUser {
+			return &model.Student{ID: uuid.New()}, nil
+		}
+		return &model.Student{ID: s.studentID}, nil
+	}
+	withdrawn := false
+	s.pgRepo.WithdrawAchievementFunc = func(id uuid.UUID, version int64) error {
+		withdrawn = true
+		return nil
+	}
+	var history model.StatusHistory
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, h model.StatusHistory) error { history = h; return nil }
+	s.mongoRepo.AddNotificationFunc = func(mongoID string, n model.Notification) error { return nil }
+
+	err := s.service.WithdrawAchievement(s.achievementID, otherUser, 2)
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusForbidden, fe.Code)
+	assert.False(s.T(), withdrawn)
+
+	err = s.service.WithdrawAchievement(s.achievementID, s.userID, 2)
+	require.NoError(s.T(), err)
+	assert.True(s.T(), withdrawn)
+	assert.Equal(s.T(), "draft", history.Status)
+}
+
+func (s *AchievementServiceTestSuite) TestCreateAchievement_TeamLeaderAndPendingMembers() {
+	memberA, memberB := uuid.New(), uuid.New()
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.pgRepo.CountStudentsByIDsFunc = func(ids []uuid.UUID) (int, error) {
+		assert.ElementsMatch(s.T(), []uuid.UUID{memberA, memberB}, ids)
+		return len(ids), nil
+	}
+	var stored model.Achievement
+	s.mongoRepo.CreateAchievementFunc = func(ach *model.Achievement) error {
+		ach.ID = s.mongoID
+		stored = *ach
+		return nil
+	}
+	s.pgRepo.CreateAchievementReferenceFunc = func(ref *model.AchievementReference) error { return nil }
+
+	ach := model.Achievement{
+		Title: "Juara 1 Gemastik",
+		Team: &model.Team{Name: "Tim A", Members: []model.TeamMember{
+			{StudentID: memberA, Role: model.TeamRoleLeader, Status: model.TeamMemberConfirmed},
+			{StudentID: memberB},
+			{StudentID: memberA},
+			{StudentID: s.studentID},
+		}},
+	}
+	_, err := s.service.CreateAchievement(s.userID, ach)
+	require.NoError(s.T(), err)
+
+	require.NotNil(s.T(), stored.Team)
+	assert.Equal(s.T(), model.PointsPolicySplit, stored.Team.PointsPolicy)
+	require.Len(s.T(), stored.Team.Members, 3)
+	assert.Equal(s.T(), model.TeamMember{StudentID: s.studentID, Role: model.TeamRoleLeader, Status: model.TeamMemberConfirmed}, stored.Team.Members[0])
+	assert.Equal(s.T(), model.TeamMember{StudentID: memberA, Role: model.TeamRoleMember, Status: model.TeamMemberPending}, stored.Team.Members[1])
+}
+
+func (s *AchievementServiceTestSuite) TestRespondTeamInvitation_ConfirmCreatesMemberReference() {
+	memberID := uuid.New()
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, MongoAchievementID: s.mongoID.Hex(), Status: "verified"}, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: memberID}, nil
+	}
+	s.mongoRepo.RespondTeamInvitationFunc = func(mongoID string, studentID uuid.UUID, status string) error {
+		assert.Equal(s.T(), memberID, studentID)
+		assert.Equal(s.T(), model.TeamMemberConfirmed, status)
+		return nil
+	}
+	s.pgRepo.CreateTeamMemberReferenceFunc = func(primaryID, studentID uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: uuid.New(), StudentID: studentID, Status: "verified", PrimaryReferenceID: &primaryID}, nil
+	}
+
+	ref, err := s.service.RespondTeamInvitation(s.achievementID, s.userID, true)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), memberID, ref.StudentID)
+	assert.Equal(s.T(), s.achievementID, *ref.PrimaryReferenceID)
+
+	s.mongoRepo.RespondTeamInvitationFunc = func(mongoID string, studentID uuid.UUID, status string) error {
+		return repository.ErrNoPendingInvitation
+	}
+	_, err = s.service.RespondTeamInvitation(s.achievementID, s.userID, true)
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusNotFound, fe.Code)
+}
+
+func (s *AchievementServiceTestSuite) TestSubmitAchievement_TeamMemberReferenceRejected() {
+	primary := uuid.New()
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, Status: "draft", Version: 1, PrimaryReferenceID: &primary}, nil
+	}
+
+	err := s.service.SubmitAchievement(s.achievementID, s.userID, 1)
+	fe, ok := err.(*fiber.Error)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), http.StatusBadRequest, fe.Code)
+	assert.Contains(s.T(), fe.Message, primary.String())
+}
+
+func TestTeamMemberPoints(t *testing.T) {
+	leader, a, b, pending := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	team := &model.Team{Members: []model.TeamMember{
+		{StudentID: leader, Role: model.TeamRoleLeader, Status: model.TeamMemberConfirmed},
+		{StudentID: a, Role: model.TeamRoleMember, Status: model.TeamMemberConfirmed},
+		{StudentID: b, Role: model.TeamRoleMember, Status: model.TeamMemberConfirmed},
+		{StudentID: pending, Role: model.TeamRoleMember, Status: model.TeamMemberPending},
+	}}
+
+	team.PointsPolicy = model.PointsPolicySplit
+	assert.Equal(t, map[uuid.UUID]int{leader: 34, a: 33, b: 33}, team.MemberPoints(100))
+
+	team.PointsPolicy = model.PointsPolicyLeaderWeighted
+	assert.Equal(t, map[uuid.UUID]int{leader: 50, a: 25, b: 25}, team.MemberPoints(100))
+
+	team.PointsPolicy = model.PointsPolicyFull
+	ach := &model.Achievement{Points: 100, Team: team}
+	assert.Equal(t, 100, ach.PointsFor(b))
+	assert.Equal(t, 0, ach.PointsFor(pending))
+}
+
+func (s *AchievementServiceTestSuite) TestPatchAchievement_NoChangesKeepsVersion() {
+	s.patchFixture()
+	s.pgRepo.BumpVersionFunc = func(id uuid.UUID, expectedStatus string, version int64) error {
+		s.T().Fatal("version must not be bumped without changes")
+		return nil
+	}
+
+	changes, err := s.service.PatchAchievement(s.achievementID, s.userID, "application/merge-patch+json", []byte(`{"title":"Juara 2 Hackathon"}`), 1)
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), changes)
+}
+
+const samplePDF = "%PDF-1.4 sertifikat\n%%EOF\n"
+
+func (s *AchievementServiceTestSuite) TestUploadAttachment_WritesToStorage() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft"}, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID}, nil
+	}
+	var saved model.Attachment
+	s.mongoRepo.AddAttachmentFunc = func(mongoID string, attachment model.Attachment) error {
+		saved = attachment
+		return nil
+	}
+
+	refs := map[string]int{}
+	s.mongoRepo.AdjustBlobRefCountFunc = func(blob model.AttachmentBlob, delta int) error {
+		refs[blob.Hash] += delta
+		return nil
+	}
+
+	// ekstensi & FileType mengikuti hasil sniffing, bukan nama file dari client
+	att, err := s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "abc.txt")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), "abc.pdf", saved.FileName)
+	assert.Equal(s.T(), model.BlobKey(att.ContentHash, model.MIMETypePDF), saved.StorageKey)
+	assert.Equal(s.T(), "application/pdf", saved.FileType)
+	assert.Equal(s.T(), model.ScanStatusPending, saved.ScanStatus)
+	assert.Equal(s.T(), int64(26), saved.Size)
+	assert.Len(s.T(), att.ContentHash, 64)
+	assert.Empty(s.T(), att.FileURL)
+
+	body, info, err := s.store.Get(context.Background(), att.Key())
+	require.NoError(s.T(), err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(s.T(), samplePDF, string(data))
+	assert.Equal(s.T(), int64(26), info.Size)
+
+	// isi identik disimpan sekali dengan key yang sama; refCount naik
+	second, err := s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "salinan.pdf")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), att.StorageKey, second.StorageKey)
+	assert.NotEqual(s.T(), att.ID, second.ID)
+	objects, err := s.store.List(context.Background(), "")
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), objects, 1)
+	assert.Equal(s.T(), 2, refs[att.ContentHash])
+
+	// metadata gagal disimpan -> referensi dilepas lagi; file tetap ada karena masih dipakai
+	s.mongoRepo.AddAttachmentFunc = func(mongoID string, attachment model.Attachment) error {
+		return assert.AnError
+	}
+	_, err = s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), 26, "gagal.pdf")
+	require.Error(s.T(), err)
+	assert.Equal(s.T(), 2, refs[att.ContentHash])
+	_, err = s.store.Stat(context.Background(), att.StorageKey)
+	assert.NoError(s.T(), err)
+}
+
+func (s *AchievementServiceTestSuite) TestUploadAttachment_Validation() {
+	svc := service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, s.scanner, preview.Builtin{}, extract.Builtin{}, nil, service.AchievementConfig{
+		AttachmentMaxSize: map[string]int64{model.MIMETypePDF: 64},
+		MaxAttachments:    2,
+	})
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft"}, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	existing := []model.Attachment{}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Attachments: existing}, nil
+	}
+	png := "\x89PNG\r\n\x1a\n....IEND\xaeB`\x82"
+
+	cases := []struct {
+		name, content string
+		size          int64
+		status        int
+		code          string
+	}{
+		{"pdf", samplePDF, -1, 0, ""},
+		{"png", png, -1, 0, ""},
+		{"pdf name that only looks like JS", "%PDF-1.4 /JSON\n%%EOF", -1, 0, ""},
+		{"windows executable", "MZ\x90\x00 this program cannot be run in DOS mode", -1, http.StatusUnsupportedMediaType, model.AttachmentErrExecutable},
+		{"plain text", "bukan sertifikat", -1, http.StatusUnsupportedMediaType, model.AttachmentErrUnsupported},
+		{"pdf over limit", "%PDF-1.4 " + strings.Repeat("x", 64) + "\n%%EOF", -1, http.StatusRequestEntityTooLarge, model.AttachmentErrTooLarge},
+		{"declared size over limit", samplePDF, 100, http.StatusRequestEntityTooLarge, model.AttachmentErrTooLarge},
+		{"pdf with javascript", "%PDF-1.4 /JS (app.alert(1))\n%%EOF", -1, http.StatusUnsupportedMediaType, model.AttachmentErrPolyglot},
+		{"pdf with appended zip", samplePDF + "PK\x03\x04", -1, http.StatusUnsupportedMediaType, model.AttachmentErrPolyglot},
+		{"png with appended data", png + "<script>", -1, http.StatusUnsupportedMediaType, model.AttachmentErrPolyglot},
+	}
+	for _, tc := range cases {
+		_, err := svc.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(tc.content), tc.size, "f.bin")
+		if tc.status == 0 {
+			assert.NoError(s.T(), err, tc.name)
+			continue
+		}
+		var ae *model.AttachmentError
+		if assert.ErrorAs(s.T(), err, &ae, tc.name) {
+			assert.Equal(s.T(), tc.status, ae.Status, tc.name)
+			assert.Equal(s.T(), tc.code, ae.Code, tc.name)
+		}
+	}
+
+	existing = []model.Attachment{{FileName: "a.pdf"}, {FileName: "b.pdf"}}
+	app := fiber.New()
+	app.Post("/achievements/:id/attachments", func(c *fiber.Ctx) error {
+		c.Locals("user_id", s.userID.String())
+		return c.Next()
+	}, svc.UploadAttachmentHandler)
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, _ := mw.CreateFormFile("file", "sertifikat.pdf")
+	fw.Write([]byte(samplePDF))
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, "/achievements/"+s.achievementID.String()+"/attachments", &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	resp, err := app.Test(req, -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusConflict, resp.StatusCode)
+	var body map[string]interface{}
+	require.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(s.T(), model.AttachmentErrTooMany, body["code"])
+}
+
+func TestAttachmentKey_LegacyUpload(t *testing.T) {
+	assert.Equal(t, "601acd9f.pdf", model.Attachment{FileURL: "/uploads/601acd9f.pdf"}.Key())
+	assert.Equal(t, "attachments/x.pdf", model.Attachment{FileURL: "/uploads/y.pdf", StorageKey: "attachments/x.pdf"}.Key())
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.NewLocal(t.TempDir(), "/api/v1/files", []byte("secret"))
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "attachments/a.pdf", bytes.NewBufferString("hello"), 5, "application/pdf"))
+	info, err := store.Stat(ctx, "attachments/a.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), info.Size)
+	assert.Equal(t, "application/pdf", info.ContentType)
+
+	for _, key := range []string{"../etc/passwd", "/abs.pdf", "a//b.pdf", ""} {
+		assert.Error(t, store.Put(ctx, key, bytes.NewBufferString("x"), 1, ""), key)
+	}
+
+	link, err := store.SignedURL(ctx, "attachments/a.pdf", time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "/api/v1/files/attachments/a.pdf", u.Path)
+	expires, _ := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	assert.NoError(t, store.VerifySignature("attachments/a.pdf", expires, u.Query().Get("signature")))
+	assert.ErrorIs(t, store.VerifySignature("attachments/b.pdf", expires, u.Query().Get("signature")), storage.ErrInvalidSignature)
+	assert.ErrorIs(t, store.VerifySignature("attachments/a.pdf", time.Now().Add(-time.Second).Unix(), u.Query().Get("signature")), storage.ErrInvalidSignature)
+
+	require.NoError(t, store.Put(ctx, "quarantine/b.pdf", bytes.NewBufferString("x"), 1, ""))
+	objects, err := store.List(ctx, "attachments/")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "attachments/a.pdf", objects[0].Key)
+	objects, err = store.List(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, objects, 2)
+
+	require.NoError(t, store.Delete(ctx, "attachments/a.pdf"))
+	_, _, err = store.Get(ctx, "attachments/a.pdf")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, "attachments/a.pdf"), storage.ErrNotFound)
+
+	// kunci turunan deterministik dan tidak sama dengan secret asalnya
+	derived := storage.DeriveSigningKey("secret")
+	assert.Len(t, derived, 32)
+	assert.Equal(t, derived, storage.DeriveSigningKey("secret"))
+	assert.NotEqual(t, []byte("secret"), derived)
+	other, err := storage.NewLocal(t.TempDir(), "/api/v1/files", derived)
+	require.NoError(t, err)
+	assert.ErrorIs(t, other.VerifySignature("attachments/a.pdf", expires, u.Query().Get("signature")), storage.ErrInvalidSignature)
+}
+
+// newS3Stub adalah server S3-compatible minimal (path-style, seperti MinIO) yang menyimpan object di memori
+func newS3Stub(t *testing.T) *httptest.Server {
+	objects := map[string][]byte{}
+	types := map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		presigned := r.URL.Query().Get("X-Amz-Signature") != ""
+		if !presigned && (!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=minio/") || r.Header.Get("X-Amz-Date") == "") {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>missing signature</Message></Error>`))
+			return
+		}
+		if r.URL.Query().Get("list-type") == "2" {
+			// ListObjectsV2, dua object per halaman agar continuation token ikut teruji
+			bucket := strings.TrimSuffix(r.URL.Path, "/") + "/"
+			keys := []string{}
+			for k := range objects {
+				key := strings.TrimPrefix(k, bucket)
+				if strings.HasPrefix(key, r.URL.Query().Get("prefix")) && key > r.URL.Query().Get("continuation-token") {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			var body strings.Builder
+			body.WriteString("<ListBucketResult>")
+			for i, k := range keys {
+				if i == 2 {
+					body.WriteString("<IsTruncated>true</IsTruncated><NextContinuationToken>" + keys[1] + "</NextContinuationToken>")
+					break
+				}
+				body.WriteString("<Contents><Key>" + k + "</Key><Size>" + strconv.Itoa(len(objects[bucket+k])) + "</Size><LastModified>2026-01-02T03:04:05.000Z</LastModified></Contents>")
+			}
+			body.WriteString("</ListBucketResult>")
+			w.Write([]byte(body.String()))
+			return
+		}
+		key := r.URL.Path
+		switch r.Method {
+		case http.MethodPut:
+			data, _ := io.ReadAll(r.Body)
+			objects[key] = data
+			types[key] = r.Header.Get("Content-Type")
+		case http.MethodGet, http.MethodHead:
+			data, ok := objects[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", types[key])
+			w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+			if r.Method == http.MethodGet {
+				w.Write(data)
+			}
+		case http.MethodDelete:
+			delete(objects, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestS3Storage_AgainstStub(t *testing.T) {
+	srv := newS3Stub(t)
+	defer srv.Close()
+	ctx := context.Background()
+
+	_, err := storage.NewS3(storage.S3Config{Endpoint: srv.URL, Bucket: "achievements"})
+	assert.Error(t, err)
+
+	store, err := storage.New(storage.Config{Driver: "s3", S3: storage.S3Config{
+		Endpoint: srv.URL, Bucket: "achievements", AccessKey: "minio", SecretKey: "minio123", PathStyle: true,
+	}})
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "attachments/sertifikat lomba.pdf", bytes.NewBufferString("%PDF-1.7"), -1, "application/pdf"))
+	info, err := store.Stat(ctx, "attachments/sertifikat lomba.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size)
+	assert.Equal(t, "application/pdf", info.ContentType)
+
+	body, _, err := store.Get(ctx, "attachments/sertifikat lomba.pdf")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "%PDF-1.7", string(data))
+
+	link, err := store.SignedURL(ctx, "attachments/sertifikat lomba.pdf", 15*time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, link, "/achievements/attachments/sertifikat%20lomba.pdf?")
+	assert.Contains(t, link, "X-Amz-Expires=900")
+	resp, err := http.Get(link)
+	require.NoError(t, err)
+	data, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "%PDF-1.7", string(data))
+
+	for _, k := range []string{"attachments/b.pdf", "attachments/c.pdf", "quarantine/d.pdf"} {
+		require.NoError(t, store.Put(ctx, k, bytes.NewBufferString("x"), 1, ""))
+	}
+	objects, err := store.List(ctx, "attachments/")
+	require.NoError(t, err)
+	keys := []string{}
+	for _, o := range objects {
+		keys = append(keys, o.Key)
+	}
+	assert.Equal(t, []string{"attachments/b.pdf", "attachments/c.pdf", "attachments/sertifikat lomba.pdf"}, keys)
+	assert.Equal(t, int64(8), objects[2].Size)
+	assert.Equal(t, 2026, objects[2].ModTime.Year())
+
+	require.NoError(t, store.Delete(ctx, "attachments/sertifikat lomba.pdf"))
+	_, err = store.Stat(ctx, "attachments/sertifikat lomba.pdf")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, "attachments/sertifikat lomba.pdf"), storage.ErrNotFound)
+
+	_, err = storage.New(storage.Config{Driver: "ftp"})
+	assert.Error(t, err)
+}
+
+func (s *AchievementServiceTestSuite) TestDownloadAttachmentHandler_RangeAndAccess() {
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return &model.AchievementReference{ID: id, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "submitted"}, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID == s.userID {
+			return &model.Student{ID: s.studentID}, nil
+		}
+		return &model.Student{ID: uuid.New()}, nil
+	}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Attachments: []model.Attachment{
+			{FileName: "abc.pdf", FileType: "application/pdf", StorageKey: "attachments/abc.pdf", ScanStatus: model.ScanStatusClean},
+			{ID: "pending", FileName: "new.pdf", StorageKey: "attachments/new.pdf", ScanStatus: model.ScanStatusPending},
+		}}, nil
+	}
+	require.NoError(s.T(), s.store.Put(context.Background(), "attachments/abc.pdf", bytes.NewBufferString("0123456789"), 10, "application/pdf"))
+
+	app := fiber.New()
+	app.Get("/api/v1/files/*", s.service.SignedFileHandler)
+	auth := func(c *fiber.Ctx) error {
+		c.Locals("user_id", c.Get("X-Test-User"))
+		c.Locals("role", "Mahasiswa")
+		return c.Next()
+	}
+	app.Get("/achievements/:id/attachments/:attachmentId", auth, s.service.DownloadAttachmentHandler)
+	app.Get("/achievements/:id/attachments/:attachmentId/link", auth, s.service.AttachmentLinkHandler)
+
+	get := func(path, user, rng string) *http.Response {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Test-User", user)
+		if rng != "" {
+			req.Header.Set("Range", rng)
+		}
+		resp, err := app.Test(req, -1)
+		require.NoError(s.T(), err)
+		return resp
+	}
+	base := "/achievements/" + s.achievementID.String() + "/attachments/abc.pdf"
+
+	resp := get(base, s.userID.String(), "")
+	require.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(s.T(), "0123456789", string(body))
+	assert.Equal(s.T(), "application/pdf", resp.Header.Get("Content-Type"))
+	assert.Equal(s.T(), `attachment; filename=abc.pdf`, resp.Header.Get("Content-Disposition"))
+	assert.Equal(s.T(), "bytes", resp.Header.Get("Accept-Ranges"))
+
+	resp = get(base, s.userID.String(), "bytes=2-5")
+	require.Equal(s.T(), http.StatusPartialContent, resp.StatusCode)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(s.T(), "2345", string(body))
+	assert.Equal(s.T(), "bytes 2-5/10", resp.Header.Get("Content-Range"))
+
+	resp = get(base, s.userID.String(), "bytes=-3")
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(s.T(), "789", string(body))
+
+	assert.Equal(s.T(), http.StatusRequestedRangeNotSatisfiable, get(base, s.userID.String(), "bytes=20-").StatusCode)
+	assert.Equal(s.T(), http.StatusForbidden, get(base, uuid.New().String(), "").StatusCode)
+	assert.Equal(s.T(), http.StatusNotFound, get("/achievements/"+s.achievementID.String()+"/attachments/lain.pdf", s.userID.String(), "").StatusCode)
+	assert.Equal(s.T(), http.StatusConflict, get("/achievements/"+s.achievementID.String()+"/attachments/pending", s.userID.String(), "").StatusCode)
+
+	// signed link bisa dibuka tanpa header autentikasi, signature yang diubah ditolak
+	resp = get(base+"/link", s.userID.String(), "")
+	require.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var link model.AttachmentLink
+	require.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&link))
+	assert.True(s.T(), link.ExpiresAt.After(time.Now()))
+
+	resp = get(link.URL, "", "")
+	require.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Equal(s.T(), "0123456789", string(body))
+	assert.Equal(s.T(), http.StatusForbidden, get(link.URL+"0", "", "").StatusCode)
+}
+
+func (s *AchievementServiceTestSuite) TestReplaceAndDeleteAttachment() {
+	ctx := context.Background()
+	ref := &model.AchievementReference{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft"}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return ref, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID == s.userID {
+			return &model.Student{ID: s.studentID}, nil
+		}
+		return &model.Student{ID: uuid.New()}, nil
+	}
+	legacy := model.Attachment{FileName: "old.pdf", FileURL: "/uploads/old.pdf", FileType: "application/pdf"}
+	current := model.Attachment{ID: "att-1", FileName: "r.pdf", StorageKey: "attachments/r.pdf", FileType: "application/pdf"}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, Attachments: []model.Attachment{legacy, current}}, nil
+	}
+	var notes []string
+	s.mongoRepo.AddStatusHistoryFunc = func(mongoID string, history model.StatusHistory) error {
+		notes = append(notes, history.Note)
+		return nil
+	}
+	var replaced model.Attachment
+	s.mongoRepo.ReplaceAttachmentFunc = func(mongoID string, old, replacement model.Attachment) error {
+		assert.Equal(s.T(), "old.pdf", old.FileName)
+		replaced = replacement
+		return nil
+	}
+	var removed model.Attachment
+	s.mongoRepo.RemoveAttachmentFunc = func(mongoID string, attachment model.Attachment) error {
+		removed = attachment
+		return nil
+	}
+	require.NoError(s.T(), s.store.Put(ctx, "old.pdf", bytes.NewBufferString(samplePDF), -1, ""))
+	require.NoError(s.T(), s.store.Put(ctx, "attachments/r.pdf", bytes.NewBufferString(samplePDF), -1, ""))
+	png := "\x89PNG\r\n\x1a\n....IEND\xaeB`\x82"
+
+	_, err := s.service.ReplaceAttachment(s.achievementID, uuid.New(), "old.pdf", bytes.NewBufferString(png), -1, "new.png")
+	assert.Equal(s.T(), http.StatusForbidden, err.(*fiber.Error).Code)
+
+	// draft: upload lama (tanpa ID, dicari lewat fileName) diganti, mendapat ID, file lama dihapus
+	att, err := s.service.ReplaceAttachment(s.achievementID, s.userID, "old.pdf", bytes.NewBufferString(png), -1, "new.png")
+	require.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), att.ID)
+	assert.Equal(s.T(), att.ID, replaced.ID)
+	assert.Equal(s.T(), "image/png", replaced.FileType)
+	_, err = s.store.Stat(ctx, "old.pdf")
+	assert.ErrorIs(s.T(), err, storage.ErrNotFound)
+	_, err = s.store.Stat(ctx, model.BlobKey(replaced.ContentHash, model.MIMETypePNG))
+	assert.NoError(s.T(), err)
+
+	// rejected: file yang sudah direview dipindah ke karantina
+	ref.Status = "rejected"
+	require.NoError(s.T(), s.service.DeleteAttachment(s.achievementID, s.userID, "att-1"))
+	assert.Equal(s.T(), "att-1", removed.ID)
+	_, err = s.store.Stat(ctx, "attachments/r.pdf")
+	assert.ErrorIs(s.T(), err, storage.ErrNotFound)
+	_, err = s.store.Stat(ctx, "quarantine/r.pdf")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{"Attachment old.pdf diganti dengan new.png", "Attachment r.pdf dihapus"}, notes)
+
+	err = s.service.DeleteAttachment(s.achievementID, s.userID, "tidak-ada")
+	assert.Equal(s.T(), http.StatusNotFound, err.(*fiber.Error).Code)
+
+	ref.Status = "submitted"
+	err = s.service.DeleteAttachment(s.achievementID, s.userID, "att-1")
+	assert.Equal(s.T(), http.StatusBadRequest, err.(*fiber.Error).Code)
+}
+
+func (s *AchievementServiceTestSuite) TestScanPendingAttachments() {
+	ctx := context.Background()
+	clean := model.Attachment{ID: "a1", FileName: "ok.pdf", StorageKey: "attachments/ok.pdf", ScanStatus: model.ScanStatusPending}
+	infected := model.Attachment{ID: "a2", FileName: "virus.pdf", StorageKey: "attachments/virus.pdf", ScanStatus: model.ScanStatusPending}
+	missing := model.Attachment{FileName: "hilang.pdf", FileURL: "/uploads/hilang.pdf"}
+	require.NoError(s.T(), s.store.Put(ctx, clean.StorageKey, bytes.NewBufferString(samplePDF), -1, ""))
+	require.NoError(s.T(), s.store.Put(ctx, infected.StorageKey, bytes.NewBufferString(scanner.EICAR), -1, ""))
+
+	s.mongoRepo.FindAttachmentsPendingScanFunc = func(limit int) ([]model.AttachmentRef, error) {
+		return []model.AttachmentRef{
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: clean},
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: infected},
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: missing},
+		}, nil
+	}
+	updates := map[string]model.Attachment{}
+	s.mongoRepo.ReplaceAttachmentFunc = func(mongoID string, old, replacement model.Attachment) error {
+		updates[old.FileName] = replacement
+		return nil
+	}
+	var notifs []model.Notification
+	s.mongoRepo.AddNotificationFunc = func(mongoID string, notif model.Notification) error {
+		notifs = append(notifs, notif)
+		return nil
+	}
+
+	result, err := s.service.ScanPendingAttachments(ctx)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), model.ScanRunResult{Clean: 1, Infected: 1, Failed: 1}, *result)
+	assert.Equal(s.T(), model.ScanStatusClean, updates["ok.pdf"].ScanStatus)
+	assert.Equal(s.T(), model.ScanStatusInfected, updates["virus.pdf"].ScanStatus)
+	assert.Equal(s.T(), "Eicar-Test-Signature", updates["virus.pdf"].ScanResult)
+	assert.Equal(s.T(), "quarantine/virus.pdf", updates["virus.pdf"].StorageKey)
+	assert.Equal(s.T(), model.ScanStatusFailed, updates["hilang.pdf"].ScanStatus)
+	_, err = s.store.Stat(ctx, "attachments/virus.pdf")
+	assert.ErrorIs(s.T(), err, storage.ErrNotFound)
+	require.Len(s.T(), notifs, 1)
+	assert.Equal(s.T(), "attachment_infected", notifs[0].Type)
+
+	// daemon mati: status tidak berubah, dicoba lagi nanti
+	updates = map[string]model.Attachment{}
+	s.scanner.Err = errors.New("connection refused")
+	s.mongoRepo.FindAttachmentsPendingScanFunc = func(limit int) ([]model.AttachmentRef, error) {
+		return []model.AttachmentRef{{MongoAchievementID: s.mongoID.Hex(), Attachment: clean}}, nil
+	}
+	result, err = s.service.ScanPendingAttachments(ctx)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, result.Retry)
+	assert.Empty(s.T(), updates)
+}
+
+func (s *AchievementServiceTestSuite) TestScanPendingAttachments_ScannerDisabled() {
+	svc := service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, nil, nil, nil, nil, service.AchievementConfig{})
+
+	// upload baru langsung bersih
+	ach := s.uploadTarget()
+	att, err := svc.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "baru.pdf")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), model.ScanStatusClean, att.ScanStatus)
+	assert.Equal(s.T(), model.ScanStatusClean, ach.Attachments[0].ScanStatus)
+
+	// upload lama tanpa status dan sisa antrean scan dilepas, bukan dibiarkan menunggu selamanya
+	s.mongoRepo.FindAttachmentsPendingScanFunc = func(limit int) ([]model.AttachmentRef, error) {
+		return []model.AttachmentRef{
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: model.Attachment{FileName: "lama.pdf", FileURL: "/uploads/lama.pdf"}},
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: model.Attachment{ID: "a1", FileName: "antre.pdf", ScanStatus: model.ScanStatusPending}},
+		}, nil
+	}
+	updates := map[string]model.Attachment{}
+	s.mongoRepo.ReplaceAttachmentFunc = func(mongoID string, old, replacement model.Attachment) error {
+		updates[old.FileName] = replacement
+		return nil
+	}
+	result, err := svc.ScanPendingAttachments(context.Background())
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), model.ScanRunResult{Clean: 2}, *result)
+	for _, name := range []string{"lama.pdf", "antre.pdf"} {
+		assert.Equal(s.T(), model.ScanStatusClean, updates[name].ScanStatus, name)
+		assert.Equal(s.T(), "not scanned: scanner disabled", updates[name].ScanResult, name)
+		assert.NotNil(s.T(), updates[name].ScannedAt, name)
+	}
+}
+
+func (s *AchievementServiceTestSuite) TestSubmitAchievement_BlockedByUnscannedAttachments() {
+	ref := &model.AchievementReference{ID: s.achievementID, MongoAchievementID: s.mongoID.Hex(), Status: "draft", Version: 1}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return ref, nil
+	}
+	s.pgRepo.SubmitAchievementFunc = func(id uuid.UUID, expectedStatus string, version int64) error {
+		s.T().Fatal("submit must not be attempted with unscanned attachments")
+		return nil
+	}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return &model.Achievement{ID: s.mongoID, AchievementType: "academic", Attachments: []model.Attachment{
+			{FileName: "ok.pdf", ScanStatus: model.ScanStatusClean},
+			{FileName: "lama.pdf"},
+			{FileName: "virus.pdf", ScanStatus: model.ScanStatusInfected},
+		}}, nil
+	}
+
+	err := s.service.SubmitAchievement(s.achievementID, s.userID, ref.Version)
+	require.Error(s.T(), err)
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, err.(*fiber.Error).Code)
+	assert.Contains(s.T(), err.Error(), "lama.pdf (pending_scan), virus.pdf (infected)")
+	assert.NotContains(s.T(), err.Error(), "ok.pdf")
+}
+
+func TestClamAVScanner_Instream(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	// clamd tiruan: membaca perintah zINSTREAM dan chunk, lalu menjawab sesuai isi stream
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				cmd, _ := r.ReadString(0)
+				if cmd != "zINSTREAM\x00" {
+					conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+				var data []byte
+				for {
+					size := make([]byte, 4)
+					if _, err := io.ReadFull(r, size); err != nil {
+						return
+					}
+					n := binary.BigEndian.Uint32(size)
+					if n == 0 {
+						break
+					}
+					chunk := make([]byte, n)
+					io.ReadFull(r, chunk)
+					data = append(data, chunk...)
+				}
+				if bytes.Contains(data, []byte(scanner.EICAR)) {
+					conn.Write([]byte("stream: Win.Test.EICAR_HDB-1 FOUND\x00"))
+					return
+				}
+				conn.Write([]byte("stream: OK\x00"))
+			}(conn)
+		}
+	}()
+
+	clam := scanner.NewClamAV("tcp", ln.Addr().String())
+	res, err := clam.Scan(context.Background(), bytes.NewBufferString(samplePDF))
+	require.NoError(t, err)
+	assert.False(t, res.Infected)
+
+	big := strings.Repeat("a", 200<<10) + scanner.EICAR
+	res, err = clam.Scan(context.Background(), bytes.NewBufferString(big))
+	require.NoError(t, err)
+	assert.True(t, res.Infected)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", res.Signature)
+
+	_, err = scanner.NewClamAV("tcp", "127.0.0.1:1").Scan(context.Background(), bytes.NewBufferString("x"))
+	assert.Error(t, err)
+}
+
+func (s *AchievementServiceTestSuite) TestCollectAttachmentGarbage() {
+	ctx := context.Background()
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	hashA, hashB, hashC, hashD := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64), strings.Repeat("d", 64)
+	keyA, keyB, keyC := model.BlobKey(hashA, model.MIMETypePDF), model.BlobKey(hashB, model.MIMETypePNG), model.BlobKey(hashC, model.MIMETypePDF)
+
+	put := func(key string, modTime time.Time) {
+		require.NoError(s.T(), s.store.Put(ctx, key, bytes.NewBufferString(samplePDF), -1, ""))
+		require.NoError(s.T(), os.Chtimes(filepath.Join(s.storeRoot, filepath.FromSlash(key)), modTime, modTime))
+	}
+	put(keyA, old)                  // dipakai a1
+	put(keyB, old)                  // blob yatim, refCount 0
+	put(keyC, now)                  // upload yang sedang berjalan: refCount 1, metadata belum tersimpan
+	put("lama.pdf", old)            // upload lama yatim
+	put("baru.pdf", now)            // yatim tetapi masih dalam grace period
+	put("quarantine/x.pdf", old)    // karantina tidak pernah disentuh
+	put("attachments/rev.pdf", old) // hanya dipakai snapshot revisi
+
+	a1 := model.Attachment{ID: "a1", FileName: "a1.pdf", FileType: model.MIMETypePDF, ContentHash: hashA, StorageKey: keyA}
+	relinkable := model.Attachment{FileName: "hilang.pdf", FileURL: "/uploads/hilang.pdf", FileType: model.MIMETypePDF, ContentHash: hashA}
+	lost := model.Attachment{ID: "m2", FileName: "m2.pdf", FileType: model.MIMETypePDF, ContentHash: hashD, StorageKey: model.BlobKey(hashD, model.MIMETypePDF)}
+	s.mongoRepo.ListAttachmentRefsFunc = func() ([]model.AttachmentRef, error) {
+		return []model.AttachmentRef{
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: a1},
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: relinkable},
+			{MongoAchievementID: s.mongoID.Hex(), Attachment: lost},
+			{MongoAchievementID: s.mongoID.Hex(), Revision: 3, Attachment: model.Attachment{FileName: "rev.pdf", StorageKey: "attachments/rev.pdf"}},
+			{MongoAchievementID: s.mongoID.Hex(), Revision: 2, Attachment: model.Attachment{FileName: "rev-hilang.pdf", StorageKey: "attachments/rev-hilang.pdf"}},
+		}, nil
+	}
+	s.mongoRepo.ListBlobsFunc = func() ([]model.AttachmentBlob, error) {
+		return []model.AttachmentBlob{
+			{Hash: hashA, Key: keyA, RefCount: 3, UpdatedAt: old},
+			{Hash: hashB, Key: keyB, RefCount: 0, UpdatedAt: old},
+			{Hash: hashC, Key: keyC, RefCount: 1, UpdatedAt: now},
+		}, nil
+	}
+	replaced := map[string]model.Attachment{}
+	s.mongoRepo.ReplaceAttachmentFunc = func(mongoID string, old, replacement model.Attachment) error {
+		replaced[old.FileName] = replacement
+		return nil
+	}
+	adjusted := map[string]int{}
+	s.mongoRepo.AdjustBlobRefCountFunc = func(blob model.AttachmentBlob, delta int) error {
+		adjusted[blob.Hash] += delta
+		return nil
+	}
+	var deletedBlobs []string
+	s.mongoRepo.DeleteUnreferencedBlobFunc = func(hash string, untouchedSince time.Time) (bool, error) {
+		assert.Equal(s.T(), now.Add(-24*time.Hour), untouchedSince)
+		deletedBlobs = append(deletedBlobs, hash)
+		return true, nil
+	}
+	orphanKeys := func(report *model.AttachmentGCReport) map[string]bool {
+		keys := map[string]bool{}
+		for _, o := range report.OrphanFiles {
+			keys[o.Key] = o.Deleted
+		}
+		return keys
+	}
+	fixes := func(report *model.AttachmentGCReport) map[string]model.RefCountFix {
+		byHash := map[string]model.RefCountFix{}
+		for _, f := range report.RefCountFixes {
+			byHash[f.Hash] = f
+		}
+		return byHash
+	}
+
+	// laporan saja: tidak ada yang diubah
+	report, err := s.service.CollectAttachmentGarbage(ctx, false, now)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 7, report.ObjectsScanned)
+	assert.Equal(s.T(), 5, report.References)
+	assert.Equal(s.T(), map[string]bool{"lama.pdf": false, "baru.pdf": false, keyB: false}, orphanKeys(report))
+	assert.Equal(s.T(), int64(3*len(samplePDF)), report.OrphanBytes)
+	require.Len(s.T(), report.MissingFiles, 3)
+	for _, m := range report.MissingFiles {
+		assert.Empty(s.T(), m.Action)
+	}
+	assert.Equal(s.T(), model.RefCountFix{Hash: hashA, Stored: 3, Actual: 1}, fixes(report)[hashA])
+	assert.Equal(s.T(), model.RefCountFix{Hash: hashC, Stored: 1, Actual: 0}, fixes(report)[hashC])
+	assert.Empty(s.T(), replaced)
+	assert.Empty(s.T(), adjusted)
+	assert.Empty(s.T(), deletedBlobs)
+
+	// repair
+	report, err = s.service.CollectAttachmentGarbage(ctx, true, now)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), map[string]bool{"lama.pdf": true, "baru.pdf": false, keyB: true}, orphanKeys(report))
+	assert.Equal(s.T(), []string{hashB}, deletedBlobs)
+	for key, exists := range map[string]bool{"lama.pdf": false, keyB: false, "baru.pdf": true, keyC: true, "quarantine/x.pdf": true, "attachments/rev.pdf": true} {
+		_, err := s.store.Stat(ctx, key)
+		assert.Equal(s.T(), exists, err == nil, key)
+	}
+
+	actions := map[string]string{}
+	for _, m := range report.MissingFiles {
+		actions[m.FileName] = m.Action
+	}
+	assert.Equal(s.T(), map[string]string{"hilang.pdf": model.GCActionRelinked, "m2.pdf": model.GCActionMarkedFailed, "rev-hilang.pdf": ""}, actions)
+	assert.Equal(s.T(), keyA, replaced["hilang.pdf"].StorageKey)
+	assert.Equal(s.T(), model.ScanStatusFailed, replaced["m2.pdf"].ScanStatus)
+
+	// a1 + attachment yang diarahkan ulang = 2; blob yang baru diubah (C) tidak dikoreksi
+	assert.Equal(s.T(), model.RefCountFix{Hash: hashA, Stored: 3, Actual: 2, Fixed: true}, fixes(report)[hashA])
+	assert.False(s.T(), fixes(report)[hashC].Fixed)
+	assert.Equal(s.T(), map[string]int{hashA: -1}, adjusted)
+}
+
+func (s *AchievementServiceTestSuite) TestCollectAttachmentGarbage_BlobReusedAfterListing() {
+	ctx := context.Background()
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	sum := sha256.Sum256([]byte(samplePDF))
+	hash := hex.EncodeToString(sum[:])
+	key := model.BlobKey(hash, model.MIMETypePDF)
+	require.NoError(s.T(), s.store.Put(ctx, key, bytes.NewBufferString(samplePDF), -1, ""))
+	require.NoError(s.T(), os.Chtimes(filepath.Join(s.storeRoot, filepath.FromSlash(key)), old, old))
+
+	// daftar blob yang dibaca GC masih refCount 0, tetapi upload baru memakai blob itu sebelum dihapus
+	s.mongoRepo.ListAttachmentRefsFunc = func() ([]model.AttachmentRef, error) { return nil, nil }
+	s.mongoRepo.ListBlobsFunc = func() ([]model.AttachmentBlob, error) {
+		return []model.AttachmentBlob{{Hash: hash, Key: key, RefCount: 0, UpdatedAt: old}}, nil
+	}
+	refCount := 0
+	s.mongoRepo.AdjustBlobRefCountFunc = func(blob model.AttachmentBlob, delta int) error {
+		refCount += delta
+		return nil
+	}
+	s.mongoRepo.DeleteUnreferencedBlobFunc = func(h string, untouchedSince time.Time) (bool, error) {
+		return refCount == 0, nil
+	}
+	s.uploadTarget()
+	_, err := s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "sama.pdf")
+	require.NoError(s.T(), err)
+
+	report, err := s.service.CollectAttachmentGarbage(ctx, true, now)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), report.OrphanFiles, 1)
+	assert.False(s.T(), report.OrphanFiles[0].Deleted)
+	_, err = s.store.Stat(ctx, key)
+	assert.NoError(s.T(), err)
+}
+
+// encodeTestImage membuat gambar w x h (gradasi) sebagai PNG atau JPEG
+func encodeTestImage(t *testing.T, w, h int, format string) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if format == "png" {
+		require.NoError(t, png.Encode(&buf, img))
+	} else {
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+	}
+	return buf.Bytes()
+}
+
+// pdfWithJPEGs membungkus JPEG sebagai image XObject /DCTDecode di satu halaman, seperti sertifikat hasil scan
+func pdfWithJPEGs(jpegs ...[]byte) []byte {
+	return pdfWithPages(jpegs)
+}
+
+// pdfWithPages menyusun PDF dengan page tree lengkap; tiap halaman memakai JPEG-nya sendiri sebagai XObject.
+// Objek gambar ditulis lebih dulu sehingga urutan di file tidak sama dengan urutan halaman.
+func pdfWithPages(pages ...[][]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	num := 0
+	var pageObjs []string
+	var imageRefs [][]string
+	for p := len(pages) - 1; p >= 0; p-- {
+		var refs []string
+		for _, j := range pages[p] {
+			num++
+			fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /XObject /Subtype /Image /Filter /DCTDecode /Length %d >>\nstream\r\n", num, len(j))
+			buf.Write(j)
+			buf.WriteString("\r\nendstream\nendobj\n")
+			refs = append(refs, fmt.Sprintf("/Im%d %d 0 R", num, num))
+		}
+		imageRefs = append([][]string{refs}, imageRefs...)
+	}
+	pagesNum := num + len(pages) + 1
+	for p := range pages {
+		num++
+		fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Page /Parent %d 0 R /MediaBox [0 0 595 842] /Resources << /XObject << %s >> >> /Contents [] >>\nendobj\n",
+			num, pagesNum, strings.Join(imageRefs[p], " "))
+		pageObjs = append(pageObjs, fmt.Sprintf("%d 0 R", num))
+	}
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", pagesNum, strings.Join(pageObjs, " "), len(pages))
+	fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Catalog /Pages %d 0 R >>\nendobj\n", pagesNum+1, pagesNum)
+	fmt.Fprintf(&buf, "trailer\n<< /Root %d 0 R >>\n%%%%EOF\n", pagesNum+1)
+	return buf.Bytes()
+}
+
+func TestPreviewBuiltinRenderer(t *testing.T) {
+	ctx := context.Background()
+	r := preview.Builtin{}
+
+	img, err := r.Render(ctx, encodeTestImage(t, 600, 300, "png"), model.MIMETypePNG)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 256, 128), preview.Scale(img, preview.ThumbnailSize).Bounds())
+	// gambar kecil tidak diperbesar
+	assert.Equal(t, image.Rect(0, 0, 600, 300), preview.Scale(img, preview.PreviewSize).Bounds())
+
+	encoded, err := preview.EncodeJPEG(preview.Scale(img, preview.ThumbnailSize))
+	require.NoError(t, err)
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	assert.Equal(t, 256, cfg.Width)
+
+	// PDF hasil scan: logo kecil dilewati, gambar halaman yang dipakai
+	scan := pdfWithJPEGs(encodeTestImage(t, 40, 40, "jpeg"), encodeTestImage(t, 420, 600, "jpeg"))
+	img, err = r.Render(ctx, scan, model.MIMETypePDF)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 420, 600), img.Bounds())
+
+	// hanya gambar halaman pertama yang dipakai, meskipun gambar halaman lain muncul lebih dulu di file
+	multi := pdfWithPages([][]byte{encodeTestImage(t, 300, 400, "jpeg")}, [][]byte{encodeTestImage(t, 640, 480, "jpeg")})
+	img, err = r.Render(ctx, multi, model.MIMETypePDF)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 300, 400), img.Bounds())
+
+	// halaman pertama vektor: tidak memakai gambar dari halaman berikutnya
+	_, err = r.Render(ctx, pdfWithPages(nil, [][]byte{encodeTestImage(t, 640, 480, "jpeg")}), model.MIMETypePDF)
+	assert.ErrorIs(t, err, preview.ErrUnsupported)
+
+	// JPEG tersisip tanpa page tree yang bisa ditelusuri: placeholder, bukan gambar acak
+	loose := []byte("%PDF-1.4\n1 0 obj\n<< /Subtype /Image /Filter /DCTDecode >>\nstream\n")
+	loose = append(loose, encodeTestImage(t, 420, 600, "jpeg")...)
+	loose = append(loose, "\nendstream\nendobj\n%%EOF\n"...)
+	_, err = r.Render(ctx, loose, model.MIMETypePDF)
+	assert.ErrorIs(t, err, preview.ErrUnsupported)
+
+	// PDF vektor & isi rusak
+	_, err = r.Render(ctx, []byte(samplePDF), model.MIMETypePDF)
+	assert.ErrorIs(t, err, preview.ErrUnsupported)
+	_, err = r.Render(ctx, []byte("\x89PNG\r\n\x1a\nrusak"), model.MIMETypePNG)
+	assert.ErrorIs(t, err, preview.ErrUnsupported)
+
+	none, err := preview.New(preview.Config{Driver: "none"})
+	require.NoError(t, err)
+	assert.Nil(t, none)
+	_, err = preview.New(preview.Config{Driver: "imagemagick"})
+	assert.Error(t, err)
+}
+
+func (s *AchievementServiceTestSuite) TestUploadAttachment_OwnerAndStatus() {
+	ach := s.uploadTarget()
+	ref, _ := s.pgRepo.GetAchievementReferenceByID(s.achievementID)
+
+	_, err := s.service.UploadAttachment(s.achievementID, uuid.New(), bytes.NewBufferString(samplePDF), -1, "orang-lain.pdf")
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err))
+
+	for _, status := range []string{"submitted", "verified"} {
+		ref.Status = status
+		_, err = s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "telat.pdf")
+		assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err), status)
+	}
+	assert.Empty(s.T(), ach.Attachments)
+	objects, err := s.store.List(context.Background(), "")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), objects)
+
+	ref.Status = "rejected"
+	_, err = s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "revisi.pdf")
+	require.NoError(s.T(), err)
+	assert.Len(s.T(), ach.Attachments, 1)
+}
+
+func (s *AchievementServiceTestSuite) TestUploadAttachment_GeneratesPreviews() {
+	ctx := context.Background()
+	ref := &model.AchievementReference{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft"}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return ref, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		return &model.Student{ID: s.studentID}, nil
+	}
+	ach := &model.Achievement{ID: s.mongoID}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return ach, nil
+	}
+	s.mongoRepo.AddAttachmentFunc = func(mongoID string, attachment model.Attachment) error {
+		ach.Attachments = append(ach.Attachments, attachment)
+		return nil
+	}
+
+	photo := encodeTestImage(s.T(), 800, 400, "png")
+	att, err := s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewReader(photo), -1, "foto.png")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), model.ThumbnailKey(att.ContentHash), att.ThumbnailKey)
+	require.Equal(s.T(), model.PreviewKey(att.ContentHash), att.PreviewKey)
+	body, _, err := s.store.Get(ctx, att.ThumbnailKey)
+	require.NoError(s.T(), err)
+	cfg, err := jpeg.DecodeConfig(body)
+	body.Close()
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []int{256, 128}, []int{cfg.Width, cfg.Height})
+
+	// PDF tanpa gambar tersisip: upload tetap berhasil, tanpa preview
+	doc, err := s.service.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "sertifikat.pdf")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), doc.ThumbnailKey)
+
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID == s.userID {
+			return &model.Student{ID: s.studentID}, nil
+		}
+		return &model.Student{ID: uuid.New()}, nil
+	}
+	// preview baru muncul setelah lolos scan antivirus
+	detail, err := s.service.GetAchievementDetail(s.achievementID, s.userID, "Mahasiswa")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), detail.Previews)
+
+	for i := range ach.Attachments {
+		ach.Attachments[i].ScanStatus = model.ScanStatusClean
+	}
+	detail, err = s.service.GetAchievementDetail(s.achievementID, s.userID, "Mahasiswa")
+	require.NoError(s.T(), err)
+	require.Len(s.T(), detail.Previews, 1)
+	p := detail.Previews[0]
+	assert.Equal(s.T(), att.ID, p.AttachmentID)
+	assert.True(s.T(), strings.HasPrefix(p.ThumbnailURL, "/api/v1/files/"+att.ThumbnailKey+"?"), p.ThumbnailURL)
+	assert.True(s.T(), strings.HasPrefix(p.PreviewURL, "/api/v1/files/"+att.PreviewKey+"?"), p.PreviewURL)
+
+	// mahasiswa lain tetap bisa melihat detail, tetapi tanpa signed link preview
+	detail, err = s.service.GetAchievementDetail(s.achievementID, uuid.New(), "Mahasiswa")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), detail.Previews)
+}
+
+// fakeUploads menyimpan sesi resumable upload di memori, termasuk filter offset AppendUploadChunk dan
+// compare-and-set SetUploadFinalized
+func (s *AchievementServiceTestSuite) fakeUploads() map[string]*model.AttachmentUpload {
+	uploads := map[string]*model.AttachmentUpload{}
+	s.mongoRepo.CreateUploadFunc = func(upload *model.AttachmentUpload) error {
+		cp := *upload
+		uploads[upload.ID] = &cp
+		return nil
+	}
+	s.mongoRepo.GetUploadFunc = func(id string) (*model.AttachmentUpload, error) {
+		u, ok := uploads[id]
+		if !ok {
+			return nil, nil
+		}
+		cp := *u
+		cp.Chunks = append([]model.UploadChunk(nil), u.Chunks...)
+		return &cp, nil
+	}
+	s.mongoRepo.AppendUploadChunkFunc = func(id string, chunk model.UploadChunk, fileType string, expiresAt time.Time) error {
+		u, ok := uploads[id]
+		if !ok || u.Offset != chunk.Offset {
+			return mongo.ErrNoDocuments
+		}
+		u.Offset += chunk.Size
+		u.Chunks = append(u.Chunks, chunk)
+		u.FileType, u.ExpiresAt = fileType, expiresAt
+		return nil
+	}
+	s.mongoRepo.SetUploadFinalizedFunc = func(id string, finalized bool) error {
+		u, ok := uploads[id]
+		if !ok || u.Finalized == finalized {
+			return mongo.ErrNoDocuments
+		}
+		u.Finalized = finalized
+		return nil
+	}
+	s.mongoRepo.DeleteUploadFunc = func(id string) error {
+		delete(uploads, id)
+		return nil
+	}
+	s.mongoRepo.FindExpiredUploadsFunc = func(now time.Time) ([]model.AttachmentUpload, error) {
+		var expired []model.AttachmentUpload
+		for _, u := range uploads {
+			if u.ExpiresAt.Before(now) {
+				expired = append(expired, *u)
+			}
+		}
+		return expired, nil
+	}
+	return uploads
+}
+
+// uploadTarget menyiapkan prestasi draft milik s.userID; attachment yang ditambahkan dikumpulkan di ach
+func (s *AchievementServiceTestSuite) uploadTarget() *model.Achievement {
+	ref := &model.AchievementReference{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft"}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return ref, nil
+	}
+	s.pgRepo.GetStudentByUserIDFunc = func(userID uuid.UUID) (*model.Student, error) {
+		if userID == s.userID {
+			return &model.Student{ID: s.studentID}, nil
+		}
+		return &model.Student{ID: uuid.New()}, nil
+	}
+	ach := &model.Achievement{ID: s.mongoID}
+	s.mongoRepo.GetAchievementByIDFunc = func(mongoID string) (*model.Achievement, error) {
+		return ach, nil
+	}
+	s.mongoRepo.AddAttachmentFunc = func(mongoID string, attachment model.Attachment) error {
+		ach.Attachments = append(ach.Attachments, attachment)
+		return nil
+	}
+	return ach
+}
+
+// fiberStatus returns the status code of a *fiber.Error, 0 otherwise
+func fiberStatus(err error) int {
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		return fe.Code
+	}
+	return 0
+}
+
+func uploadChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256 " + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func (s *AchievementServiceTestSuite) chunkObjects(uploadID string) []storage.ObjectInfo {
+	objects, err := s.store.List(context.Background(), model.UploadKeyPrefix+uploadID+"/")
+	require.NoError(s.T(), err)
+	return objects
+}
+
+func (s *AchievementServiceTestSuite) TestResumableUpload_ChunksAndFinalize() {
+	ach := s.uploadTarget()
+	s.fakeUploads()
+	svc := service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, s.scanner, nil, nil, nil, service.AchievementConfig{UploadChunkMax: 512})
+
+	doc := []byte("%PDF-1.4 portofolio\n" + strings.Repeat("halaman\n", 150) + "%%EOF\n")
+	upload, err := svc.CreateUpload(s.achievementID, s.userID, int64(len(doc)), "portofolio.pdf")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), upload.Offset)
+	assert.Equal(s.T(), "/api/v1/achievements/"+s.achievementID.String()+"/uploads/"+upload.ID, upload.Path())
+
+	_, err = svc.CreateUpload(s.achievementID, uuid.New(), int64(len(doc)), "x.pdf")
+	assert.Equal(s.T(), http.StatusForbidden, fiberStatus(err), fmt.Sprint(err))
+
+	first := doc[:512]
+	upload, att, err := svc.PatchUpload(s.achievementID, upload.ID, s.userID, 0, uploadChecksum(first), bytes.NewReader(first))
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), att)
+	assert.Equal(s.T(), int64(512), upload.Offset)
+	assert.Equal(s.T(), model.MIMETypePDF, upload.FileType)
+
+	// chunk yang sama dikirim ulang (offset lama), checksum salah, chunk terlalu besar
+	_, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 0, "", bytes.NewReader(first))
+	assert.Equal(s.T(), http.StatusConflict, fiberStatus(err), fmt.Sprint(err))
+	_, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 512, uploadChecksum(first), bytes.NewReader(doc[512:1024]))
+	assert.Equal(s.T(), 460, fiberStatus(err), fmt.Sprint(err))
+	_, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 512, "md5 AAAA", bytes.NewReader(doc[512:1024]))
+	assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err), fmt.Sprint(err))
+	_, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 512, "", bytes.NewReader(doc[512:]))
+	assert.Equal(s.T(), http.StatusRequestEntityTooLarge, fiberStatus(err), fmt.Sprint(err))
+	assert.Len(s.T(), s.chunkObjects(upload.ID), 1)
+
+	// hanya pemilik sesi yang bisa melihat offset
+	_, err = svc.GetUpload(s.achievementID, upload.ID, uuid.New())
+	assert.Equal(s.T(), http.StatusNotFound, fiberStatus(err), fmt.Sprint(err))
+	current, err := svc.GetUpload(s.achievementID, upload.ID, s.userID)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(512), current.Offset)
+
+	_, att, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 512, "", bytes.NewReader(doc[512:1024]))
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), att)
+	_, att, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 1024, uploadChecksum(doc[1024:]), bytes.NewReader(doc[1024:]))
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), att)
+	assert.Equal(s.T(), model.MIMETypePDF, att.FileType)
+	assert.Equal(s.T(), int64(len(doc)), att.Size)
+	assert.Equal(s.T(), model.ScanStatusPending, att.ScanStatus)
+	require.Len(s.T(), ach.Attachments, 1)
+	assert.Equal(s.T(), att.ID, ach.Attachments[0].ID)
+
+	body, _, err := s.store.Get(context.Background(), att.StorageKey)
+	require.NoError(s.T(), err)
+	stored, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(s.T(), doc, stored)
+
+	// sesi dan chunk dibuang setelah selesai
+	_, err = svc.GetUpload(s.achievementID, upload.ID, s.userID)
+	assert.Equal(s.T(), http.StatusNotFound, fiberStatus(err), fmt.Sprint(err))
+	assert.Empty(s.T(), s.chunkObjects(upload.ID))
+}
+
+func (s *AchievementServiceTestSuite) TestResumableUpload_StatusAndSingleFinalize() {
+	ach := s.uploadTarget()
+	uploads := s.fakeUploads()
+	ref, _ := s.pgRepo.GetAchievementReferenceByID(s.achievementID)
+	doc := []byte("%PDF-1.4 portofolio\n" + strings.Repeat("halaman\n", 100) + "%%EOF\n")
+
+	// sesi hanya bisa dibuat saat draft atau rejected
+	ref.Status = "submitted"
+	_, err := s.service.CreateUpload(s.achievementID, s.userID, int64(len(doc)), "a.pdf")
+	assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err), fmt.Sprint(err))
+
+	// prestasi disubmit setelah sesi dibuat: penggabungan ditolak, sesi tetap ada untuk dicoba lagi
+	ref.Status = "draft"
+	upload, err := s.service.CreateUpload(s.achievementID, s.userID, int64(len(doc)), "a.pdf")
+	require.NoError(s.T(), err)
+	ref.Status = "submitted"
+	_, att, err := s.service.PatchUpload(s.achievementID, upload.ID, s.userID, 0, "", bytes.NewReader(doc))
+	assert.Equal(s.T(), http.StatusBadRequest, fiberStatus(err), fmt.Sprint(err))
+	assert.Nil(s.T(), att)
+	assert.Empty(s.T(), ach.Attachments)
+	require.Contains(s.T(), uploads, upload.ID)
+	assert.False(s.T(), uploads[upload.ID].Finalized)
+
+	// PATCH akhir lain sedang menggabungkan: PATCH ini tidak menambah attachment
+	ref.Status = "rejected"
+	uploads[upload.ID].Finalized = true
+	_, att, err = s.service.PatchUpload(s.achievementID, upload.ID, s.userID, int64(len(doc)), "", bytes.NewReader(nil))
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), att)
+	assert.Empty(s.T(), ach.Attachments)
+
+	uploads[upload.ID].Finalized = false
+	_, att, err = s.service.PatchUpload(s.achievementID, upload.ID, s.userID, int64(len(doc)), "", bytes.NewReader(nil))
+	require.NoError(s.T(), err)
+	require.NotNil(s.T(), att)
+	assert.Len(s.T(), ach.Attachments, 1)
+	assert.NotContains(s.T(), uploads, upload.ID)
+}
+
+func (s *AchievementServiceTestSuite) TestResumableUpload_ValidationAndVideo() {
+	ach := s.uploadTarget()
+	uploads := s.fakeUploads()
+	svc := service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, s.scanner, nil, nil, nil, service.AchievementConfig{
+		UploadChunkMax:    1024,
+		AttachmentMaxSize: map[string]int64{model.MIMETypeMP4: 4096},
+	})
+	ctx := context.Background()
+
+	// executable ditolak dari chunk pertama dan sesi langsung dibuang
+	exe := append([]byte("MZ"), make([]byte, 600)...)
+	upload, err := svc.CreateUpload(s.achievementID, s.userID, 2000, "setup.pdf")
+	require.NoError(s.T(), err)
+	_, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 0, "", bytes.NewReader(exe))
+	var ae *model.AttachmentError
+	require.ErrorAs(s.T(), err, &ae)
+	assert.Equal(s.T(), model.AttachmentErrExecutable, ae.Code)
+	assert.NotContains(s.T(), uploads, upload.ID)
+
+	video := append([]byte("\x00\x00\x00\x18ftypisom\x00\x00\x02\x00isomiso2"), bytes.Repeat([]byte{0x42, 0x17, 0x99}, 1000)...)
+
+	// Upload-Length melebihi batas video terdeteksi setelah sniffing
+	upload, err = svc.CreateUpload(s.achievementID, s.userID, 5000, "lomba.mp4")
+	require.NoError(s.T(), err)
+	_, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 0, "", bytes.NewReader(video[:1024]))
+	require.ErrorAs(s.T(), err, &ae)
+	assert.Equal(s.T(), model.AttachmentErrTooLarge, ae.Code)
+	assert.Equal(s.T(), int64(4096), ae.MaxSize)
+
+	// video di-stream dari chunk tanpa validasi polyglot
+	upload, err = svc.CreateUpload(s.achievementID, s.userID, int64(len(video)), "lomba.mov")
+	require.NoError(s.T(), err)
+	var att *model.Attachment
+	for offset := 0; offset < len(video); offset += 1024 {
+		chunk := video[offset:min(offset+1024, len(video))]
+		upload, att, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, int64(offset), uploadChecksum(chunk), bytes.NewReader(chunk))
+		require.NoError(s.T(), err)
+	}
+	require.NotNil(s.T(), att)
+	assert.Equal(s.T(), model.MIMETypeMP4, att.FileType)
+	assert.Equal(s.T(), "lomba.mp4", att.FileName)
+	assert.Equal(s.T(), model.BlobKey(att.ContentHash, model.MIMETypeMP4), att.StorageKey)
+	sum := sha256.Sum256(video)
+	assert.Equal(s.T(), hex.EncodeToString(sum[:]), att.ContentHash)
+	require.Len(s.T(), ach.Attachments, 1)
+
+	// chunk yang berubah di storage terdeteksi saat penggabungan
+	doc := []byte("%PDF-1.4 " + strings.Repeat("x", 700) + "\n%%EOF\n")
+	upload, err = svc.CreateUpload(s.achievementID, s.userID, int64(len(doc)), "a.pdf")
+	require.NoError(s.T(), err)
+	upload, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 0, "", bytes.NewReader(doc[:600]))
+	require.NoError(s.T(), err)
+	tampered := append([]byte(nil), doc[:600]...)
+	tampered[100] = 'y'
+	require.NoError(s.T(), s.store.Put(ctx, upload.Chunks[0].Key, bytes.NewReader(tampered), 600, "application/octet-stream"))
+	_, _, err = svc.PatchUpload(s.achievementID, upload.ID, s.userID, 600, "", bytes.NewReader(doc[600:]))
+	assert.Equal(s.T(), http.StatusUnprocessableEntity, fiberStatus(err), fmt.Sprint(err))
+	assert.NotContains(s.T(), uploads, upload.ID)
+	assert.Empty(s.T(), s.chunkObjects(upload.ID))
+	assert.Len(s.T(), ach.Attachments, 1)
+}
+
+func (s *AchievementServiceTestSuite) TestResumableUpload_Handlers() {
+	s.uploadTarget()
+	s.fakeUploads()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", s.userID.String())
+		return c.Next()
+	})
+	app.Post("/api/v1/achievements/:id/uploads", s.service.CreateUploadHandler)
+	app.Head("/api/v1/achievements/:id/uploads/:uploadId", s.service.HeadUploadHandler)
+	app.Patch("/api/v1/achievements/:id/uploads/:uploadId", s.service.PatchUploadHandler)
+	app.Delete("/api/v1/achievements/:id/uploads/:uploadId", s.service.DeleteUploadHandler)
+
+	doc := []byte("%PDF-1.4 " + strings.Repeat("x", 700) + "\n%%EOF\n")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/achievements/"+s.achievementID.String()+"/uploads", nil)
+	req.Header.Set("Upload-Length", strconv.Itoa(len(doc)))
+	resp, err := app.Test(req, -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusPreconditionFailed, resp.StatusCode)
+	assert.Equal(s.T(), "1.0.0", resp.Header.Get("Tus-Version"))
+
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Metadata", "filename "+base64.StdEncoding.EncodeToString([]byte("sertifikat.pdf")))
+	resp, err = app.Test(req, -1)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), http.StatusCreated, resp.StatusCode)
+	location := resp.Header.Get("Location")
+	assert.True(s.T(), strings.HasPrefix(location, "/api/v1/achievements/"+s.achievementID.String()+"/uploads/"), location)
+	assert.NotEmpty(s.T(), resp.Header.Get("Upload-Expires"))
+
+	patch := func(offset int, body []byte, contentType string) *http.Response {
+		req := httptest.NewRequest(http.MethodPatch, location, bytes.NewReader(body))
+		req.Header.Set("Tus-Resumable", "1.0.0")
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Upload-Offset", strconv.Itoa(offset))
+		resp, err := app.Test(req, -1)
+		require.NoError(s.T(), err)
+		return resp
+	}
+	assert.Equal(s.T(), http.StatusUnsupportedMediaType, patch(0, doc[:512], "application/octet-stream").StatusCode)
+	// chunk pertama harus cukup panjang untuk sniffing; sesi tetap bisa dilanjutkan
+	assert.Equal(s.T(), http.StatusBadRequest, patch(0, doc[:10], "application/offset+octet-stream").StatusCode)
+	resp = patch(0, doc[:512], "application/offset+octet-stream")
+	assert.Equal(s.T(), http.StatusNoContent, resp.StatusCode)
+	assert.Equal(s.T(), "512", resp.Header.Get("Upload-Offset"))
+
+	head := httptest.NewRequest(http.MethodHead, location, nil)
+	head.Header.Set("Tus-Resumable", "1.0.0")
+	resp, err = app.Test(head, -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	assert.Equal(s.T(), "512", resp.Header.Get("Upload-Offset"))
+	assert.Equal(s.T(), strconv.Itoa(len(doc)), resp.Header.Get("Upload-Length"))
+	assert.Equal(s.T(), "no-store", resp.Header.Get("Cache-Control"))
+
+	resp = patch(512, doc[512:], "application/offset+octet-stream")
+	require.Equal(s.T(), http.StatusOK, resp.StatusCode)
+	var att model.Attachment
+	require.NoError(s.T(), json.NewDecoder(resp.Body).Decode(&att))
+	assert.True(s.T(), strings.HasSuffix(att.FileName, ".pdf"), att.FileName)
+
+	resp, err = app.Test(head, -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusNotFound, resp.StatusCode)
+
+	// pembatalan sesi
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/achievements/"+s.achievementID.String()+"/uploads", nil)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.Header.Set("Upload-Length", "100")
+	resp, err = app.Test(req, -1)
+	require.NoError(s.T(), err)
+	del := httptest.NewRequest(http.MethodDelete, resp.Header.Get("Location"), nil)
+	del.Header.Set("Tus-Resumable", "1.0.0")
+	resp, err = app.Test(del, -1)
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), http.StatusNoContent, resp.StatusCode)
+}
+
+func (s *AchievementServiceTestSuite) TestCleanupExpiredUploads() {
+	s.uploadTarget()
+	uploads := s.fakeUploads()
+	ctx := context.Background()
+
+	doc := []byte("%PDF-1.4 " + strings.Repeat("x", 700) + "\n%%EOF\n")
+	stale, err := s.service.CreateUpload(s.achievementID, s.userID, int64(len(doc)), "a.pdf")
+	require.NoError(s.T(), err)
+	_, _, err = s.service.PatchUpload(s.achievementID, stale.ID, s.userID, 0, "", bytes.NewReader(doc[:600]))
+	require.NoError(s.T(), err)
+	fresh, err := s.service.CreateUpload(s.achievementID, s.userID, int64(len(doc)), "b.pdf")
+	require.NoError(s.T(), err)
+
+	// sesi yang kedaluwarsa tidak bisa dilanjutkan walaupun belum dibersihkan
+	uploads[stale.ID].ExpiresAt = time.Now().Add(-time.Minute)
+	_, _, err = s.service.PatchUpload(s.achievementID, stale.ID, s.userID, 600, "", bytes.NewReader(doc[600:]))
+	assert.Equal(s.T(), http.StatusNotFound, fiberStatus(err), fmt.Sprint(err))
+
+	n, err := s.service.CleanupExpiredUploads(ctx, time.Now())
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, n)
+	assert.NotContains(s.T(), uploads, stale.ID)
+	assert.Contains(s.T(), uploads, fresh.ID)
+	assert.Empty(s.T(), s.chunkObjects(stale.ID))
+
+	// chunk resumable upload bukan urusan GC attachment
+	s.mongoRepo.ListAttachmentRefsFunc = func() ([]model.AttachmentRef, error) { return nil, nil }
+	s.mongoRepo.ListBlobsFunc = func() ([]model.AttachmentBlob, error) { return nil, nil }
+	require.NoError(s.T(), s.store.Put(ctx, model.UploadKeyPrefix+fresh.ID+"/0000000000000000-x", bytes.NewBufferString("chunk"), 5, "application/octet-stream"))
+	report, err := s.service.CollectAttachmentGarbage(ctx, true, time.Now().Add(48*time.Hour))
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), report.OrphanFiles)
+}
+
+// fakeExtractor mengembalikan hasil ekstraksi tetap, sehingga alur upload bisa diuji tanpa zbarimg
+type fakeExtractor struct {
+	result *extract.Result
+	calls  []string
+}
+
+func (f *fakeExtractor) Extract(ctx context.Context, data []byte, mimeType string) (*extract.Result, error) {
+	f.calls = append(f.calls, mimeType)
+	if f.result == nil {
+		return nil, extract.ErrUnsupported
+	}
+	return f.result, nil
+}
+
+func TestBuiltinExtractor_PDFText(t *testing.T) {
+	ctx := context.Background()
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte("BT /F1 10 Tf 72 700 Td [(Nomor) -300 (Sertifikat:) -300 (045/LKTI/2024)] TJ 0 -14 Td (Diberikan kepada \\(peserta\\)) Tj ET"))
+	zw.Close()
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+	content := "BT /F1 24 Tf 100 750 Td (SERTIFIKAT) Tj T* <FEFF004A00750061007200610020003100200023> Tj ET"
+	fmt.Fprintf(&buf, "1 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+	fmt.Fprintf(&buf, "2 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", compressed.Len())
+	buf.Write(compressed.Bytes())
+	buf.WriteString("\nendstream\nendobj\n")
+	buf.WriteString("3 0 obj\n<< /Type /Annot /Subtype /Link /A << /S /URI /URI (https://lkti.example.ac.id/verify?no=045-LKTI-2024) >> >>\nendobj\n")
+	// gambar tidak dibaca sebagai teks
+	buf.WriteString("4 0 obj\n<< /Subtype /Image /Length 8 >>\nstream\nBT (x) Tj\nendstream\nendobj\n%%EOF\n")
+
+	res, err := extract.Builtin{}.Extract(ctx, buf.Bytes(), model.MIMETypePDF)
+	require.NoError(t, err)
+	assert.Equal(t, "SERTIFIKAT\nJuara 1 #\nNomor Sertifikat: 045/LKTI/2024\nDiberikan kepada (peserta)", res.Text)
+	assert.Equal(t, []string{"https://lkti.example.ac.id/verify?no=045-LKTI-2024"}, res.Links)
+
+	_, err = extract.Builtin{}.Extract(ctx, encodeTestImage(t, 10, 10, "png"), model.MIMETypePNG)
+	assert.ErrorIs(t, err, extract.ErrUnsupported)
+
+	meta := model.NewAttachmentMetadata(res.Text, res.Links, nil, time.Now())
+	require.NotNil(t, meta)
+	assert.Equal(t, "045/LKTI/2024", meta.CertificateNumber)
+	hint := model.Attachment{ID: "a1", FileName: "sertifikat.pdf", Metadata: meta}.VerificationHint()
+	require.NotNil(t, hint)
+	assert.Equal(t, model.VerificationHint{
+		AttachmentID: "a1", FileName: "sertifikat.pdf", CertificateNumber: "045/LKTI/2024",
+		IssuerURL: "https://lkti.example.ac.id/verify?no=045-LKTI-2024", Source: model.HintSourceText,
+	}, *hint)
+}
+
+func TestBuiltinExtractor_DecompressionLimits(t *testing.T) {
+	ctx := context.Background()
+	flate := func(content string) []byte {
+		var b bytes.Buffer
+		zw := zlib.NewWriter(&b)
+		zw.Write([]byte(content))
+		zw.Close()
+		return b.Bytes()
+	}
+	// spasi tambahan agar zlib benar-benar memampatkan isinya (blok stored akan memuat link apa adanya)
+	link := func(n int) string {
+		return fmt.Sprintf("<< /Annots [<< /A << /S /URI /URI (https://cert.example.org/%d) >> >>] >>", n) + strings.Repeat(" ", 1024)
+	}
+	pdf := func(streams ...[]byte) []byte {
+		var buf bytes.Buffer
+		buf.WriteString("%PDF-1.5\n")
+		for i, data := range streams {
+			fmt.Fprintf(&buf, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", i+1, len(data))
+			buf.Write(data)
+			buf.WriteString("\nendstream\nendobj\n")
+		}
+		buf.WriteString("%%EOF\n")
+		return buf.Bytes()
+	}
+
+	// banyak stream kecil yang masing-masing di bawah batas per stream: total hasil dekompresi tetap dibatasi
+	padding := strings.Repeat(" ", 4<<20-200)
+	var streams [][]byte
+	for i := 1; i <= 6; i++ {
+		streams = append(streams, flate(link(i)+padding))
+	}
+	res, err := extract.Builtin{}.Extract(ctx, pdf(streams...), model.MIMETypePDF)
+	require.NoError(t, err)
+	assert.Contains(t, res.Links, "https://cert.example.org/1")
+	assert.NotContains(t, res.Links, "https://cert.example.org/6")
+
+	// jumlah stream dibatasi
+	streams = [][]byte{flate(link(1))}
+	for i := 0; i < 1500; i++ {
+		streams = append(streams, flate(" "))
+	}
+	streams = append(streams, flate(link(2)))
+	res, err = extract.Builtin{}.Extract(ctx, pdf(streams...), model.MIMETypePDF)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://cert.example.org/1"}, res.Links)
+}
+
+func TestAttachmentMetadata_QRHints(t *testing.T) {
+	now := time.Now()
+	// URL verifikasi dari QR: nomor diambil dari parameter query, URL QR didahulukan dari URL di teks
+	meta := model.NewAttachmentMetadata("Cek keaslian di https://other.example.com/ ya.", nil,
+		[]string{"https://cert.example.org/verify?id=ABC-2024-0042"}, now)
+	require.NotNil(t, meta)
+	assert.Equal(t, "ABC-2024-0042", meta.CertificateNumber)
+	assert.Equal(t, []string{"https://cert.example.org/verify?id=ABC-2024-0042", "https://other.example.com/"}, meta.URLs)
+	hint := model.Attachment{FileName: "x.png", Metadata: meta}.VerificationHint()
+	require.NotNil(t, hint)
+	assert.Equal(t, model.HintSourceQR, hint.Source)
+	assert.Equal(t, "x.png", hint.AttachmentID)
+
+	// QR berisi kode saja
+	meta = model.NewAttachmentMetadata("", nil, []string{"SN-99817"}, now)
+	assert.Equal(t, "SN-99817", meta.CertificateNumber)
+	assert.Empty(t, meta.URLs)
+
+	// label tanpa kode berangka bukan nomor sertifikat
+	meta = model.NewAttachmentMetadata("Nomor Induk Mahasiswa tercantum. Certificate No. CRT-7781.", nil, nil, now)
+	assert.Equal(t, "CRT-7781", meta.CertificateNumber)
+
+	assert.Nil(t, model.NewAttachmentMetadata("", nil, nil, now))
+	assert.Nil(t, model.Attachment{Metadata: &model.AttachmentMetadata{Text: "tanpa petunjuk"}}.VerificationHint())
+}
+
+func (s *AchievementServiceTestSuite) TestUploadAttachment_ExtractsVerificationHints() {
+	ach := s.uploadTarget()
+	ref := &model.AchievementReference{ID: s.achievementID, StudentID: s.studentID, MongoAchievementID: s.mongoID.Hex(), Status: "draft"}
+	s.pgRepo.GetAchievementReferenceByIDFunc = func(id uuid.UUID) (*model.AchievementReference, error) {
+		return ref, nil
+	}
+	extractor := &fakeExtractor{result: &extract.Result{QRCodes: []string{"https://cert.example.org/v/LKTI-2024-017?serial=LKTI-2024-017"}}}
+	svc := service.NewAchievementService(s.pgRepo, s.mongoRepo, s.tagRepo, s.store, s.scanner, nil, extractor, nil, service.AchievementConfig{})
+
+	att, err := svc.UploadAttachment(s.achievementID, s.userID, bytes.NewReader(encodeTestImage(s.T(), 64, 64, "png")), -1, "sertifikat.png")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), []string{model.MIMETypePNG}, extractor.calls)
+	require.NotNil(s.T(), att.Metadata)
+	assert.Equal(s.T(), "LKTI-2024-017", att.Metadata.CertificateNumber)
+	require.Len(s.T(), ach.Attachments, 1)
+	require.NotNil(s.T(), ach.Attachments[0].Metadata)
+
+	// gagal ekstraksi tidak menggagalkan upload
+	extractor.result = nil
+	doc, err := svc.UploadAttachment(s.achievementID, s.userID, bytes.NewBufferString(samplePDF), -1, "lampiran.pdf")
+	require.NoError(s.T(), err)
+	assert.Nil(s.T(), doc.Metadata)
+	ref.Status = "submitted"
+
+	want := []model.VerificationHint{{
+		AttachmentID: att.ID, FileName: att.FileName, CertificateNumber: "LKTI-2024-017",
+		IssuerURL: "https://cert.example.org/v/LKTI-2024-017?serial=LKTI-2024-017", Source: model.HintSourceQR,
+	}}
+	// petunjuk hanya untuk reviewer, bukan pemilik
+	detail, err := svc.GetAchievementDetail(s.achievementID, s.userID, "Mahasiswa")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), detail.Hints)
+	detail, err = svc.GetAchievementDetail(s.achievementID, uuid.New(), "Admin")
+	require.NoError(s.T(), err)
+	assert.Equal(s.T(), want, detail.Hints)
+
+	s.pgRepo.ListSubmittedAchievementReferencesFunc = func(filter model.SubmittedReferenceFilter, page, limit int) (*model.PaginatedResponse[model.AchievementReference], error) {
+		return &model.PaginatedResponse[model.AchievementReference]{Data: []model.AchievementReference{*ref}, Page: page, Limit: limit, Total: 1, TotalPages: 1}, nil
+	}
+	s.mongoRepo.GetAchievementsByIDsFunc = func(mongoIDs []string) (map[string]*model.Achievement, error) {
+		return map[string]*model.Achievement{s.mongoID.Hex(): ach}, nil
+	}
+	s.mongoRepo.FindDuplicateCandidatesFunc = func(a *model.Achievement) ([]model.Achievement, error) { return nil, nil }
+	queue, err := svc.GetVerificationQueue(uuid.New(), "Admin", model.VerificationQueueFilter{}, 1, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), queue.Data, 1)
+	assert.Equal(s.T(), want, queue.Data[0].Hints)
+
+	// attachment terinfeksi tidak memberi petunjuk
+	ach.Attachments[0].ScanStatus = model.ScanStatusInfected
+	detail, err = svc.GetAchievementDetail(s.achievementID, uuid.New(), "Admin")
+	require.NoError(s.T(), err)
+	assert.Empty(s.T(), detail.Hints)
+}
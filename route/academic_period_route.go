@@ -0,0 +1,22 @@
+package route
+
+import (
+	"BACKEND-UAS/middleware"
+	"BACKEND-UAS/pgmongo/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupAcademicPeriodRoutes men-setup route kalender akademik
+func SetupAcademicPeriodRoutes(app *fiber.App, periodSvc *service.AcademicPeriodService, authMiddleware *middleware.AuthMiddlewareConfig) {
+	v1 := app.Group("/api/v1")
+	periods := v1.Group("/academic-periods")
+
+	periods.Use(authMiddleware.AuthRequired())
+
+	periods.Get("/", periodSvc.ListPeriodsHandler)
+
+	// Pengelolaan kalender (admin, dicek di service)
+	periods.Post("/", periodSvc.CreatePeriodHandler)
+	periods.Delete("/:id", periodSvc.DeletePeriodHandler)
+}
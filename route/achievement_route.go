@@ -11,12 +11,38 @@ func SetupAchievementRoutes(app *fiber.App, svc *service.AchievementService, aut
 	v1 := app.Group("/api/v1")
 	achievements := v1.Group("/achievements")
 
+	// Signed link attachment dari storage lokal (publik, diverifikasi lewat signature)
+	v1.Get("/files/*", svc.SignedFileHandler)
+
+	// Pemeriksaan receipt verifikasi prestasi (publik) dan kunci publik penandatangannya
+	v1.Get("/verification-receipts/verify", svc.VerifyReceiptHandler)
+	v1.Post("/verification-receipts/verify", svc.VerifyReceiptHandler)
+	v1.Get("/verification-receipts/keys", svc.ReceiptKeysHandler)
+
 	// Semua route achievement butuh autentikasi
 	achievements.Use(authMiddleware.AuthRequired())
 
 	// List achievements (dengan role dari locals)
 	achievements.Get("/", svc.ListHandler)
 
+	// Full-text search
+	achievements.Get("/search", svc.SearchHandler)
+
+	// Export CSV (filter sama dengan list)
+	achievements.Get("/export", svc.ExportHandler)
+
+	// Garbage collection storage attachment (admin): GET laporan, POST perbaiki
+	achievements.Get("/attachments/gc", svc.AttachmentGCHandler)
+	achievements.Post("/attachments/gc", svc.AttachmentGCHandler)
+
+	// Review queue & batch review (harus didaftarkan sebelum route /:id)
+	achievements.Get("/queue", svc.QueueHandler)
+	achievements.Post("/batch/verify", svc.BatchVerifyHandler)
+	achievements.Post("/batch/reject", svc.BatchRejectHandler)
+
+	// Undangan tim untuk mahasiswa yang login
+	achievements.Get("/team/invitations", svc.TeamInvitationsHandler)
+
 	// Detail
 	achievements.Get("/:id", svc.DetailHandler)
 
@@ -25,6 +51,11 @@ func SetupAchievementRoutes(app *fiber.App, svc *service.AchievementService, aut
 
 	// Update
 	achievements.Put("/:id", svc.UpdateHandler)
+	achievements.Patch("/:id", svc.PatchHandler)
+
+	// Autosave draft (tanpa ETag & history)
+	achievements.Get("/:id/autosave", svc.GetAutosaveHandler)
+	achievements.Put("/:id/autosave", svc.AutosaveHandler)
 
 	// Delete
 	achievements.Delete("/:id", svc.DeleteHandler)
@@ -35,12 +66,46 @@ func SetupAchievementRoutes(app *fiber.App, svc *service.AchievementService, aut
 	// Verify
 	achievements.Post("/:id/verify", svc.VerifyHandler)
 
+	// Terbitkan ulang receipt verifikasi (admin)
+	achievements.Post("/:id/receipt", svc.ReissueReceiptHandler)
+
 	// Reject
 	achievements.Post("/:id/reject", svc.RejectHandler)
 
+	// Revoke verifikasi (admin) & withdraw submission (mahasiswa)
+	achievements.Post("/:id/revoke", svc.RevokeHandler)
+	achievements.Post("/:id/withdraw", svc.WithdrawHandler)
+
+	// Konfirmasi / tolak keanggotaan tim
+	achievements.Post("/:id/team/confirm", svc.ConfirmTeamHandler)
+	achievements.Post("/:id/team/decline", svc.DeclineTeamHandler)
+
+	// Claim / assign reviewer
+	achievements.Post("/:id/claim", svc.ClaimHandler)
+	achievements.Delete("/:id/claim", svc.ReleaseClaimHandler)
+	achievements.Post("/:id/assign", svc.AssignHandler)
+
 	// History
 	achievements.Get("/:id/history", svc.HistoryHandler)
 
+	// Revisions (snapshot konten) & diff antar revisi
+	achievements.Get("/:id/revisions", svc.RevisionsHandler)
+	achievements.Get("/:id/revisions/:a/diff/:b", svc.RevisionDiffHandler)
+
 	// Upload attachment
 	achievements.Post("/:id/attachments", svc.UploadAttachmentHandler)
+
+	// Resumable upload (tus) untuk file besar: create, offset, chunk, batal
+	achievements.Post("/:id/uploads", svc.CreateUploadHandler)
+	achievements.Head("/:id/uploads/:uploadId", svc.HeadUploadHandler)
+	achievements.Patch("/:id/uploads/:uploadId", svc.PatchUploadHandler)
+	achievements.Delete("/:id/uploads/:uploadId", svc.DeleteUploadHandler)
+
+	// Download attachment (Range didukung) & signed link berumur pendek
+	achievements.Get("/:id/attachments/:attachmentId", svc.DownloadAttachmentHandler)
+	achievements.Get("/:id/attachments/:attachmentId/link", svc.AttachmentLinkHandler)
+
+	// Ganti / hapus attachment (draft atau rejected)
+	achievements.Put("/:id/attachments/:attachmentId", svc.ReplaceAttachmentHandler)
+	achievements.Delete("/:id/attachments/:attachmentId", svc.DeleteAttachmentHandler)
 }
\ No newline at end of file
@@ -0,0 +1,26 @@
+package route
+
+import (
+	"BACKEND-UAS/middleware"
+	"BACKEND-UAS/pgmongo/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SetupTagRoutes men-setup route vocabulary tag
+func SetupTagRoutes(app *fiber.App, tagSvc *service.TagService, authMiddleware *middleware.AuthMiddlewareConfig) {
+	v1 := app.Group("/api/v1")
+	tags := v1.Group("/tags")
+
+	tags.Use(authMiddleware.AuthRequired())
+
+	// GET /api/v1/tags/autocomplete (sebelum /:id)
+	tags.Get("/autocomplete", tagSvc.AutocompleteHandler)
+
+	tags.Get("/", tagSvc.ListTagsHandler)
+
+	// Pengelolaan vocabulary (admin, dicek di service)
+	tags.Post("/", tagSvc.CreateTagHandler)
+	tags.Put("/:id", tagSvc.UpdateTagHandler)
+	tags.Delete("/:id", tagSvc.DeleteTagHandler)
+}